@@ -0,0 +1,203 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// stableIterations is the number of iterations the exemplars of affinity
+// propagation must stay unchanged for the run to stop.
+const stableIterations = 15
+
+// AffinityPropagation implements affinity propagation, which chooses
+// exemplars among the observations by passing messages between them. The
+// similarity of two observations is their negated squared distance and the
+// similarity of an observation to itself, its preference, sets how likely it
+// is to become an exemplar: higher preferences give more clusters. Each
+// iteration updates the responsibility of every candidate exemplar for every
+// observation and the availability of every candidate, blending the new
+// messages with the previous ones by damping, between 0.5 and 1. It stops
+// when the exemplars are unchanged for 15 iterations or after
+// iterationThreshold iterations; every observation then joins its most
+// similar exemplar, and each cluster's exemplar is reset to its member most
+// similar to the others.
+//
+// The preference is set with WithPreference and defaults to the median
+// similarity. Observations are compared with the configured distance, and
+// time and memory grow with the square of the number of observations. The
+// number of clusters follows from the data and the result's Medoids are the
+// exemplars.
+func AffinityPropagation[T Observation](dataset []T, damping float64, iterationThreshold int, opts ...Option) (*MedoidResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate damping
+	if !(damping >= 0.5 && damping < 1) {
+		return nil, fmt.Errorf("invalid damping: %f", damping)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	n := len(points)
+	distance := cfg.distanceFunc()
+
+	// Similarities, with the preference on the diagonal
+	similarities := newMatrix(n, n)
+	offDiagonal := make([]float64, 0, n*(n-1))
+	for i := range n {
+		for j := range n {
+			if i != j {
+				d := distance(points[i], points[j])
+				similarities[i][j] = -d * d
+				offDiagonal = append(offDiagonal, similarities[i][j])
+			}
+		}
+	}
+	preference := cfg.preference
+	if preference == 0 && len(offDiagonal) > 0 {
+		slices.Sort(offDiagonal)
+		preference = offDiagonal[len(offDiagonal)/2]
+		if len(offDiagonal)%2 == 0 {
+			preference = (preference + offDiagonal[len(offDiagonal)/2-1]) / 2
+		}
+	}
+	for i := range n {
+		similarities[i][i] = preference
+	}
+
+	// A single observation is its own exemplar
+	if n == 1 {
+		return newMedoidResult(dataset, points, []int{0}, 0, 0, cfg), nil
+	}
+
+	// Messages cannot break ties between equally similar observations, such as
+	// duplicates: they form one cluster, or one each if the preference is
+	// higher than their similarity
+	if !slices.ContainsFunc(offDiagonal, func(s float64) bool { return s != offDiagonal[0] }) {
+		exemplars := []int{0}
+		if preference > offDiagonal[0] {
+			exemplars = make([]int, n)
+			for i := range exemplars {
+				exemplars[i] = i
+			}
+		}
+		return newMedoidResult(dataset, points, exemplars, medoidCost(points, exemplars, distance), 0, cfg), nil
+	}
+
+	responsibilities := newMatrix(n, n)
+	availabilities := newMatrix(n, n)
+	var exemplars []int
+	iterations, converged, stable := 0, false, 0
+	for range iterationThreshold {
+		iterations++
+
+		// Responsibility of k for i: how much better k is than i's best
+		// alternative
+		for i := range n {
+			first, second, best := math.Inf(-1), math.Inf(-1), -1
+			for k := range n {
+				switch v := availabilities[i][k] + similarities[i][k]; {
+				case v > first:
+					first, second, best = v, first, k
+				case v > second:
+					second = v
+				}
+			}
+			for k := range n {
+				alternative := first
+				if k == best {
+					alternative = second
+				}
+				responsibilities[i][k] = damping*responsibilities[i][k] + (1-damping)*(similarities[i][k]-alternative)
+			}
+		}
+
+		// Availability of k for i: the support k receives from the others
+		for k := range n {
+			support := responsibilities[k][k]
+			for i := range n {
+				if i != k {
+					support += max(0, responsibilities[i][k])
+				}
+			}
+			for i := range n {
+				a := support - responsibilities[k][k]
+				if i != k {
+					a = min(0, support-max(0, responsibilities[i][k]))
+				}
+				availabilities[i][k] = damping*availabilities[i][k] + (1-damping)*a
+			}
+		}
+
+		// Observations currently preferring themselves as exemplars
+		var current []int
+		for k := range n {
+			if availabilities[k][k]+responsibilities[k][k] > 0 {
+				current = append(current, k)
+			}
+		}
+		if slices.Equal(current, exemplars) {
+			stable++
+		} else {
+			stable = 0
+		}
+		exemplars = current
+		if stable >= stableIterations && len(exemplars) > 0 {
+			converged = true
+			break
+		}
+	}
+
+	// Validate the outcome
+	if len(exemplars) == 0 {
+		return nil, fmt.Errorf("affinity propagation found no exemplar")
+	}
+
+	// Reset the exemplar of each cluster to its most central member
+	labels := make([]int, n)
+	for i := range n {
+		best := math.Inf(-1)
+		for j, e := range exemplars {
+			if e == i {
+				labels[i] = j
+				break
+			}
+			if similarities[i][e] > best {
+				best, labels[i] = similarities[i][e], j
+			}
+		}
+	}
+	for j := range exemplars {
+		best := math.Inf(-1)
+		for candidate := range n {
+			if labels[candidate] != j {
+				continue
+			}
+			total := 0.0
+			for i := range n {
+				if labels[i] == j && i != candidate {
+					total += similarities[i][candidate]
+				}
+			}
+			if total > best {
+				best, exemplars[j] = total, candidate
+			}
+		}
+	}
+
+	result := newMedoidResult(dataset, points, exemplars, medoidCost(points, exemplars, distance), iterations, cfg)
+	result.Converged = converged
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAffinityPropagation(t *testing.T) {
+	centers := []Vector{{0, 0}, {20, 0}, {0, 20}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 30, 1)
+
+	result, err := AffinityPropagation(dataset, 0.5, 200, WithPreference(-200))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Medoids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d exemplars, got %v", len(centers), result.Medoids)
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/30*30] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+	for j, m := range result.Medoids {
+		if result.Labels[m] != j {
+			t.Errorf("expected exemplar %d in its own cluster", m)
+		}
+	}
+}
+
+func TestAffinityPropagationPreference(t *testing.T) {
+	centers := []Vector{{0, 0}, {20, 0}, {0, 20}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 30, 1)
+
+	// Higher preferences give more exemplars
+	low, err := AffinityPropagation(dataset, 0.9, 500, WithPreference(-2000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	high, err := AffinityPropagation(dataset, 0.9, 500, WithPreference(-1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(low.Medoids) >= len(high.Medoids) {
+		t.Errorf("expected fewer exemplars with a lower preference, got %d and %d", len(low.Medoids), len(high.Medoids))
+	}
+}
+
+func TestAffinityPropagationDuplicates(t *testing.T) {
+	dataset := []Vector{{3, 4}, {3, 4}, {3, 4}, {3, 4}}
+
+	result, err := AffinityPropagation(dataset, 0.5, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Medoids) != 1 || result.Cost != 0 {
+		t.Fatalf("expected a single exemplar, got %v", result.Medoids)
+	}
+	for i, label := range result.Labels {
+		if label != 0 {
+			t.Errorf("expected observation %d in the single cluster, got %d", i, label)
+		}
+	}
+
+	// Equidistant observations with a higher preference are their own exemplars
+	result, err = AffinityPropagation([]Vector{{0}, {1}}, 0.5, 100, WithPreference(-0.5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Medoids) != 2 {
+		t.Errorf("expected 2 exemplars, got %v", result.Medoids)
+	}
+}
+
+func TestAffinityPropagationValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := AffinityPropagation([]Numbers{}, 0.5, 100); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := AffinityPropagation(dataset, 0.4, 100); err == nil {
+		t.Error("expected error for invalid damping")
+	}
+	if _, err := AffinityPropagation(dataset, 1, 100); err == nil {
+		t.Error("expected error for invalid damping")
+	}
+	if _, err := AffinityPropagation(dataset, 0.5, 0); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	result, err := AffinityPropagation([]Numbers{7}, 0.5, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Medoids) != 1 {
+		t.Errorf("expected a single exemplar, got %v", result.Medoids)
+	}
+}
@@ -0,0 +1,248 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// Linkage selects how agglomerative clustering measures the distance between
+// two clusters.
+type Linkage int
+
+const (
+	// SingleLinkage uses the distance between the closest members of the two
+	// clusters. It follows elongated shapes but chains clusters joined by a
+	// few close observations.
+	SingleLinkage Linkage = iota
+	// CompleteLinkage uses the distance between the farthest members of the
+	// two clusters, favouring compact clusters of similar diameters.
+	CompleteLinkage
+	// AverageLinkage uses the average distance between the members of the
+	// two clusters.
+	AverageLinkage
+	// WardLinkage merges the two clusters whose union least increases the
+	// total within-cluster sum of squares, like k-means. It requires the
+	// Euclidean distance and mean centroids.
+	WardLinkage
+
+	// numLinkages is the number of supported linkages.
+	numLinkages
+)
+
+// valid reports whether l is a supported linkage.
+func (l Linkage) valid() bool {
+	return l >= 0 && l < numLinkages
+}
+
+// Merge is a step of a hierarchical clustering of n observations, a node of
+// its dendrogram. The cluster created by the i-th merge is numbered n+i and
+// joins clusters Left and Right, each either the observation of that index,
+// when below n, or the cluster created by an earlier merge.
+type Merge struct {
+	Left, Right int
+	// Distance is the distance between the two clusters when they merged.
+	Distance float64
+	// Size is the number of observations of the new cluster.
+	Size int
+}
+
+// HierarchyResult holds the outcome of an agglomerative clustering run: the
+// flat clustering at the requested number of clusters and the full merge
+// tree, from which other flat clusterings can be cut.
+type HierarchyResult[T Observation] struct {
+	Result[T]
+	// Merges holds the n-1 merges leading from single observations to a
+	// single cluster, by increasing distance.
+	Merges []Merge
+
+	dataset []T
+	points  [][]float64
+	cfg     *config
+}
+
+// Agglomerative implements agglomerative hierarchical clustering: starting
+// from one cluster per observation, it repeatedly merges the two closest
+// clusters, as measured by linkage, until a single cluster is left. The
+// result holds every merge and the flat clustering obtained by stopping at k
+// clusters, whose Centroids are the centers of the clusters.
+//
+// Observations are compared with the configured distance and centers are
+// computed as in Cluster. Memory grows with the square and time with the cube
+// of the number of observations.
+func Agglomerative[T Observation](dataset []T, k int, linkage Linkage, opts ...Option) (*HierarchyResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate linkage
+	if !linkage.valid() {
+		return nil, fmt.Errorf("invalid linkage: %d", linkage)
+	}
+	if linkage == WardLinkage && !cfg.euclidean() {
+		return nil, fmt.Errorf("Ward linkage requires the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	// Distances between observations
+	distance := cfg.distanceFunc()
+	dists := newMatrix(len(points), len(points))
+	for i := range points {
+		for j := range i {
+			d := distance(points[i], points[j])
+			dists[i][j], dists[j][i] = d, d
+		}
+	}
+	merges := agglomerate(dists, linkage)
+
+	result := &HierarchyResult[T]{
+		Merges:  merges,
+		dataset: dataset,
+		points:  points,
+		cfg:     cfg,
+	}
+	result.Result = *result.cut(k)
+	return result, nil
+}
+
+// agglomerate merges clusters from one per row of the matrix of distances
+// dists, which it overwrites, until a single cluster is left, and returns
+// the merges.
+func agglomerate(dists [][]float64, linkage Linkage) []Merge {
+	n := len(dists)
+
+	// Squared distances for Ward's update formula
+	if linkage == WardLinkage {
+		for i := range dists {
+			for j := range dists[i] {
+				dists[i][j] *= dists[i][j]
+			}
+		}
+	}
+
+	// Cluster held by each row of dists and its size
+	nodes := make([]int, n)
+	sizes := make([]int, n)
+	active := make([]bool, n)
+	for i := range n {
+		nodes[i], sizes[i], active[i] = i, 1, true
+	}
+
+	merges := make([]Merge, 0, n-1)
+	for range n - 1 {
+		// Closest pair of clusters
+		a, b, closest := -1, -1, math.Inf(1)
+		for i := range n {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && dists[i][j] < closest {
+					a, b, closest = i, j, dists[i][j]
+				}
+			}
+		}
+		if linkage == WardLinkage {
+			closest = math.Sqrt(closest)
+		}
+		merges = append(merges, Merge{min(nodes[a], nodes[b]), max(nodes[a], nodes[b]), closest, sizes[a] + sizes[b]})
+
+		// Distances to the merged cluster, by the Lance-Williams formulas
+		na, nb := float64(sizes[a]), float64(sizes[b])
+		for c := range n {
+			if !active[c] || c == a || c == b {
+				continue
+			}
+			var d float64
+			switch linkage {
+			case SingleLinkage:
+				d = min(dists[a][c], dists[b][c])
+			case CompleteLinkage:
+				d = max(dists[a][c], dists[b][c])
+			case AverageLinkage:
+				d = (na*dists[a][c] + nb*dists[b][c]) / (na + nb)
+			case WardLinkage:
+				nc := float64(sizes[c])
+				d = ((na+nc)*dists[a][c] + (nb+nc)*dists[b][c] - nc*dists[a][b]) / (na + nb + nc)
+			}
+			dists[a][c], dists[c][a] = d, d
+		}
+		nodes[a] = n + len(merges) - 1
+		sizes[a] += sizes[b]
+		active[b] = false
+	}
+	return merges
+}
+
+// Cut returns the flat clustering with k clusters obtained by stopping the
+// merges when k clusters are left.
+func (h *HierarchyResult[T]) Cut(k int) (*Result[T], error) {
+	// Validate k
+	if k <= 0 || k > len(h.points) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+	return h.cut(k), nil
+}
+
+// cut applies the first n-k merges and returns the resulting clusters,
+// numbered by their first observation.
+func (h *HierarchyResult[T]) cut(k int) *Result[T] {
+	n := len(h.points)
+
+	// Observation standing for each cluster of the merge tree
+	parents := make([]int, n)
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	representatives := make([]int, n, 2*n-1)
+	for i := range representatives {
+		representatives[i] = i
+	}
+	for _, m := range h.Merges[:n-k] {
+		a, b := find(representatives[m.Left]), find(representatives[m.Right])
+		parents[b] = a
+		representatives = append(representatives, a)
+	}
+
+	// Number the clusters and compute their centers
+	numbers := make(map[int]int, k)
+	labels := make([]int, n)
+	members := make([][][]float64, 0, k)
+	for i := range n {
+		root := find(i)
+		j, ok := numbers[root]
+		if !ok {
+			j = len(members)
+			numbers[root] = j
+			members = append(members, nil)
+		}
+		labels[i] = j
+		members[j] = append(members[j], h.points[i])
+	}
+	centroids := make([][]float64, len(members))
+	for j := range members {
+		centroids[j] = h.cfg.centerOf(members[j])
+	}
+
+	result := newResult(h.dataset, h.points, centroids, labels, nil, h.cfg.lossFunc())
+	result.Iterations = n - k
+	result.Converged = true
+	return result
+}
@@ -0,0 +1,84 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestAgglomerative(t *testing.T) {
+	dataset := []Numbers{1, 2, 4, 8, 16}
+	for _, tc := range []struct {
+		name     string
+		linkage  Linkage
+		expected []Merge
+	}{
+		{"single", SingleLinkage, []Merge{{0, 1, 1, 2}, {2, 5, 2, 3}, {3, 6, 4, 4}, {4, 7, 8, 5}}},
+		{"complete", CompleteLinkage, []Merge{{0, 1, 1, 2}, {2, 5, 3, 3}, {3, 6, 7, 4}, {4, 7, 15, 5}}},
+		{"average", AverageLinkage, []Merge{{0, 1, 1, 2}, {2, 5, 2.5, 3}, {3, 6, 17.0 / 3, 4}, {4, 7, 12.25, 5}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Agglomerative(dataset, 2, tc.linkage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.EqualFunc(result.Merges, tc.expected, func(a, b Merge) bool {
+				return a.Left == b.Left && a.Right == b.Right && a.Size == b.Size && math.Abs(a.Distance-b.Distance) < 1e-12
+			}) {
+				t.Errorf("expected merges %v, got %v", tc.expected, result.Merges)
+			}
+			assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 4, 8}, {16}})
+		})
+	}
+}
+
+func TestAgglomerativeWard(t *testing.T) {
+	dataset := []Numbers{0, 1, 10, 11}
+	result, err := Agglomerative(dataset, 2, WardLinkage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The Ward distance of two clusters of two observations whose means are
+	// 10 apart is sqrt(2·2·2/4)·10
+	if last := result.Merges[2]; math.Abs(last.Distance-10*math.Sqrt2) > 1e-9 || last.Size != 4 {
+		t.Errorf("unexpected last merge: %v", last)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{0, 1}, {10, 11}})
+	if !slices.EqualFunc(result.Centroids, [][]float64{{0.5}, {10.5}}, slices.Equal) || result.Inertia != 1 {
+		t.Errorf("unexpected centroids %v and inertia %v", result.Centroids, result.Inertia)
+	}
+}
+
+func TestAgglomerativeCut(t *testing.T) {
+	dataset := []Numbers{1, 2, 4, 8, 16}
+	result, err := Agglomerative(dataset, 1, SingleLinkage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 4, 8, 16}})
+
+	cut, err := result.Cut(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, cut.Clusters, [][]Numbers{{1, 2, 4}, {8}, {16}})
+	if _, err := result.Cut(6); err == nil {
+		t.Error("expected error for invalid k")
+	}
+}
+
+func TestAgglomerativeValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := Agglomerative([]Numbers{}, 1, SingleLinkage); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Agglomerative(dataset, 0, SingleLinkage); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Agglomerative(dataset, 2, Linkage(-1)); err == nil {
+		t.Error("expected error for invalid linkage")
+	}
+	if _, err := Agglomerative(dataset, 2, WardLinkage, WithDistance(ManhattanDistance)); err == nil {
+		t.Error("expected error for Ward linkage with a custom distance")
+	}
+}
@@ -0,0 +1,145 @@
+package kmeans
+
+// Algorithm selects the engine used to assign observations to centroids.
+type Algorithm int
+
+const (
+	// Lloyd compares every observation with every centroid at each iteration.
+	Lloyd Algorithm = iota
+	// Elkan skips distance computations using the triangle inequality, with
+	// one upper bound per observation and one lower bound per observation and
+	// centroid. It gives the same clusters as Lloyd and requires the default
+	// Euclidean distance and mean centroids.
+	Elkan
+	// Hamerly keeps a single lower bound per observation, trading some of
+	// Elkan's pruning for memory linear in the number of observations. It has
+	// the same requirements as Elkan.
+	Hamerly
+	// Yinyang groups the centroids and keeps one lower bound per observation
+	// and group, which scales best to hundreds or thousands of clusters. It
+	// has the same requirements as Elkan.
+	Yinyang
+	// KDTree indexes the centroids in a kd-tree at each iteration to answer
+	// nearest-centroid queries in logarithmic time. It suits low-dimensional
+	// data (about 10 dimensions or fewer) with many clusters and has the same
+	// requirements as Elkan.
+	KDTree
+	// Annulus adds to Hamerly's bounds an ordering of the centroids by norm,
+	// so observations only compare themselves with centroids of similar norm.
+	// It needs almost no extra memory and has the same requirements as Elkan.
+	Annulus
+	// MiniBatch updates the centroids from a random sample of observations at
+	// each iteration, with a learning rate decreasing with the number of
+	// observations each centroid has absorbed (Sculley's mini-batch k-means).
+	// It is much faster on very large datasets at a small cost in inertia.
+	// The batch size is set with WithBatchSize and the delta threshold applies
+	// to the centroid movement over one batch.
+	MiniBatch
+	// MacQueen visits the observations in order and immediately moves the
+	// nearest centroid towards each of them. A single iteration gives a fast
+	// one-pass clustering; more iterations repeat the pass.
+	MacQueen
+	// HartiganWong starts from a Lloyd assignment, then visits the
+	// observations in order and moves each one to the cluster that most
+	// reduces the inertia, updating both centroids at once, as R's kmeans
+	// does by default. It usually reaches a lower inertia than Lloyd, an
+	// observation may end up closer to another centroid than to its own and
+	// it requires the default Euclidean distance and mean centroids.
+	HartiganWong
+
+	// numAlgorithms is the number of supported algorithms.
+	numAlgorithms
+)
+
+// valid reports whether a is a supported algorithm.
+func (a Algorithm) valid() bool {
+	return a >= 0 && a < numAlgorithms
+}
+
+// accelerated reports whether a relies on Euclidean bounds to skip work.
+func (a Algorithm) accelerated() bool {
+	switch a {
+	case Elkan, Hamerly, Yinyang, KDTree, Annulus:
+		return true
+	}
+	return false
+}
+
+// stochastic reports whether a samples observations with the random number generator.
+func (a Algorithm) stochastic() bool {
+	return a == MiniBatch
+}
+
+// assigner labels points with their nearest centroid. It is called once per
+// iteration with the current centroids and may keep state between calls.
+type assigner interface {
+	assign(centroids [][]float64, labels []int) *Stats
+}
+
+// newAssigner returns the assigner implementing the configured algorithm.
+func newAssigner(points [][]float64, cfg *config) assigner {
+	switch cfg.algorithm {
+	case Elkan:
+		return newElkan(points, cfg.workerCount())
+	case Hamerly:
+		return newHamerly(points, cfg.workerCount())
+	case Yinyang:
+		return newYinyang(points, cfg.workerCount())
+	case KDTree:
+		return &kdTree{points: points, workers: cfg.workerCount()}
+	case Annulus:
+		return newAnnulus(points, cfg.workerCount())
+	default:
+		return &lloyd{
+			points:   points,
+			distance: cfg.distanceFunc(),
+			// The nearest centroid is the same on squared distances
+			squared: cfg.distance == nil && !cfg.spherical,
+			workers: cfg.workerCount(),
+		}
+	}
+}
+
+// lloyd is the standard assignment step comparing every point with every centroid.
+type lloyd struct {
+	points   [][]float64
+	distance DistanceFunc
+	// squared selects the blocked squared Euclidean kernel over distance
+	squared bool
+	workers int
+}
+
+// assign labels every point with its nearest centroid and returns the sums
+// and counts of each cluster. Points are split across workers goroutines and
+// partial statistics are merged in shard order, so results only depend on the
+// number of workers.
+func (l *lloyd) assign(centroids [][]float64, labels []int) *Stats {
+	return shardStats(len(l.points), len(centroids), len(centroids[0]), l.workers, func(start, end int, partial *Stats) {
+		if l.squared {
+			nearestPanel(l.points[start:end], centroids, labels[start:end])
+		} else {
+			for i := start; i < end; i++ {
+				labels[i], _ = nearest(l.points[i], centroids, l.distance)
+			}
+		}
+		for i := start; i < end; i++ {
+			partial.add(l.points[i], labels[i])
+		}
+	})
+}
+
+// shardStats runs fn over shards of n points in parallel, each filling its own
+// statistics for k clusters of dimension dim, and merges them in shard order.
+func shardStats(n, k, dim, workers int, fn func(start, end int, partial *Stats)) *Stats {
+	partials := make([]*Stats, numShards(n, workers))
+	parallel(n, workers, func(shard, start, end int) {
+		partial := NewStats(k, dim)
+		fn(start, end, partial)
+		partials[shard] = partial
+	})
+	stats := partials[0]
+	for _, partial := range partials[1:] {
+		stats.merge(partial)
+	}
+	return stats
+}
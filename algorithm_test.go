@@ -0,0 +1,162 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type Vector []float64
+
+func (v Vector) Coordinates() []float64 {
+	return v
+}
+
+// blobs generates n points of dimension dim scattered around k random centers.
+func blobs(rng *rand.Rand, n, k, dim int) []Vector {
+	centers := make([]Vector, k)
+	for j := range centers {
+		centers[j] = make(Vector, dim)
+		for d := range dim {
+			centers[j][d] = rng.Float64() * 100
+		}
+	}
+	dataset := make([]Vector, n)
+	for i := range dataset {
+		center := centers[rng.Intn(k)]
+		dataset[i] = make(Vector, dim)
+		for d := range dim {
+			dataset[i][d] = center[d] + rng.NormFloat64()*3
+		}
+	}
+	return dataset
+}
+
+// assertSameAsLloyd checks that algorithm reaches the same solution as Lloyd.
+func assertSameAsLloyd(t *testing.T, algorithm Algorithm, opts ...Option) {
+	t.Helper()
+	dataset := blobs(rand.New(rand.NewSource(0)), 2000, 20, 4)
+
+	expected, err := ClusterResult(dataset, 20, 1e-9, 300, rand.New(rand.NewSource(1)), opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := ClusterResult(dataset, 20, 1e-9, 300, rand.New(rand.NewSource(1)), append(opts, WithAlgorithm(algorithm))...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(result.Labels, expected.Labels) {
+		t.Error("labels differ from Lloyd")
+	}
+	if result.Iterations != expected.Iterations {
+		t.Errorf("expected %d iterations, got %d", expected.Iterations, result.Iterations)
+	}
+	if math.Abs(result.Inertia-expected.Inertia) > 1e-6 {
+		t.Errorf("expected inertia %v, got %v", expected.Inertia, result.Inertia)
+	}
+}
+
+func TestClusterElkan(t *testing.T) {
+	assertSameAsLloyd(t, Elkan)
+	assertSameAsLloyd(t, Elkan, WithWorkers(4))
+}
+
+func TestClusterHamerly(t *testing.T) {
+	assertSameAsLloyd(t, Hamerly)
+	assertSameAsLloyd(t, Hamerly, WithWorkers(4))
+}
+
+// assertSingleCentroid checks that algorithm handles a single centroid, which
+// has no runner-up for the bounds to track.
+func assertSingleCentroid(t *testing.T, algorithm Algorithm) {
+	t.Helper()
+	dataset := blobs(rand.New(rand.NewSource(0)), 200, 3, 2)
+
+	// Frozen centroids bypass the k == 1 shortcut and run the main loop
+	result, err := ClusterResult(dataset, 1, 1e-9, 100, rand.New(rand.NewSource(1)), WithFrozenCentroids([][]float64{}), WithAlgorithm(algorithm))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, err := ClusterResult(dataset, 1, 1e-9, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for d := range expected.Centroids[0] {
+		if math.Abs(result.Centroids[0][d]-expected.Centroids[0][d]) > 1e-9 {
+			t.Fatalf("expected centroid %v, got %v", expected.Centroids[0], result.Centroids[0])
+		}
+	}
+}
+
+func TestClusterHamerlySingleCentroid(t *testing.T) {
+	assertSingleCentroid(t, Hamerly)
+}
+
+func TestClusterYinyang(t *testing.T) {
+	assertSameAsLloyd(t, Yinyang)
+	assertSameAsLloyd(t, Yinyang, WithWorkers(4))
+}
+
+func TestClusterKDTree(t *testing.T) {
+	assertSameAsLloyd(t, KDTree)
+	assertSameAsLloyd(t, KDTree, WithWorkers(4))
+}
+
+func TestKDTreeNearest(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	centroids := make([][]float64, 50)
+	indices := make([]int, len(centroids))
+	for j := range centroids {
+		centroids[j] = []float64{rng.Float64(), rng.Float64(), rng.Float64()}
+		indices[j] = j
+	}
+	root := buildKDTree(centroids, indices)
+
+	for range 1000 {
+		point := []float64{rng.Float64(), rng.Float64(), rng.Float64()}
+		expected, _ := nearest(point, centroids, EuclideanDistance)
+		best, bestDist := -1, math.Inf(1)
+		root.nearest(point, centroids, &best, &bestDist)
+		if best != expected {
+			t.Fatalf("point %v: expected centroid %d, got %d", point, expected, best)
+		}
+	}
+}
+
+func TestClusterAnnulus(t *testing.T) {
+	assertSameAsLloyd(t, Annulus)
+	assertSameAsLloyd(t, Annulus, WithWorkers(4))
+}
+
+func TestClusterAnnulusSingleCentroid(t *testing.T) {
+	assertSingleCentroid(t, Annulus)
+}
+
+func TestGroupCentroids(t *testing.T) {
+	centroids := [][]float64{{0}, {100}, {1}, {101}, {2}}
+	groups, group := groupCentroids(centroids, 2)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %v", groups)
+	}
+	for j, g := range group {
+		if !slices.Contains(groups[g], j) {
+			t.Errorf("centroid %d missing from its group %v", j, groups[g])
+		}
+		if (centroids[j][0] < 50) != (centroids[groups[g][0]][0] < 50) {
+			t.Errorf("centroid %d grouped with distant centroids %v", j, groups[g])
+		}
+	}
+}
+
+func TestClusterInvalidAlgorithm(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithAlgorithm(Algorithm(-1))); err == nil {
+		t.Error("expected error for invalid algorithm")
+	}
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithAlgorithm(Elkan), WithDistance(ManhattanDistance)); err == nil {
+		t.Error("expected error for Elkan with a custom distance")
+	}
+}
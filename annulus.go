@@ -0,0 +1,134 @@
+package kmeans
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+)
+
+// annulus implements the annulus algorithm: Hamerly's bounds, plus centroids
+// sorted by norm so that a point failing the bounds test only compares itself
+// with centroids whose norm lies within an annulus around its own norm. The
+// closest and second closest centroids are guaranteed to lie in that annulus.
+type annulus struct {
+	points  [][]float64
+	norms   []float64
+	workers int
+	upper   []float64
+	lower   []float64
+	// runnerUp holds the second closest centroid found by the last search
+	runnerUp []int
+	// previous holds the centroids of the previous call, nil before the first one
+	previous [][]float64
+}
+
+// newAnnulus returns an annulus assigner for points.
+func newAnnulus(points [][]float64, workers int) *annulus {
+	norms := make([]float64, len(points))
+	origin := make([]float64, len(points[0]))
+	for i, p := range points {
+		norms[i] = EuclideanDistance(p, origin)
+	}
+	return &annulus{
+		points:   points,
+		norms:    norms,
+		workers:  workers,
+		upper:    make([]float64, len(points)),
+		lower:    make([]float64, len(points)),
+		runnerUp: make([]int, len(points)),
+	}
+}
+
+func (a *annulus) assign(centroids [][]float64, labels []int) *Stats {
+	k, dim := len(centroids), len(centroids[0])
+
+	// Centroids ordered by norm
+	origin := make([]float64, dim)
+	order := make([]int, k)
+	centroidNorms := make([]float64, k)
+	for j := range centroids {
+		order[j] = j
+		centroidNorms[j] = EuclideanDistance(centroids[j], origin)
+	}
+	slices.SortFunc(order, func(x, y int) int {
+		return cmp.Or(cmp.Compare(centroidNorms[x], centroidNorms[y]), cmp.Compare(x, y))
+	})
+	sortedNorms := make([]float64, k)
+	for rank, j := range order {
+		sortedNorms[rank] = centroidNorms[j]
+	}
+
+	// First call: compute every distance to initialize the bounds
+	if a.previous == nil {
+		a.previous = cloneAll(centroids)
+		return shardStats(len(a.points), k, dim, a.workers, func(start, end int, partial *Stats) {
+			for i := start; i < end; i++ {
+				labels[i], a.upper[i], a.runnerUp[i], a.lower[i] = twoNearestAmong(a.points[i], centroids, order)
+				partial.add(a.points[i], labels[i])
+			}
+		})
+	}
+
+	// How far each centroid moved, and the two largest moves
+	drift := centroidDrift(centroids, a.previous)
+	largest, second := twoLargest(drift)
+	a.previous = cloneAll(centroids)
+
+	// Half the distance from each centroid to its closest other centroid
+	closest := closestHalfDistances(centroidHalfDistances(centroids))
+
+	return shardStats(len(a.points), k, dim, a.workers, func(start, end int, partial *Stats) {
+		for i := start; i < end; i++ {
+			point := a.points[i]
+
+			// Loosen the bounds by the centroid drift
+			a.upper[i] += drift[labels[i]]
+			switch {
+			case k == 1:
+				// A single centroid has no runner-up: the lower bound stays infinite
+			case labels[i] == largest:
+				a.lower[i] -= drift[second]
+			default:
+				a.lower[i] -= drift[largest]
+			}
+
+			bound := max(closest[labels[i]], a.lower[i])
+			if a.upper[i] > bound {
+				a.upper[i] = EuclideanDistance(point, centroids[labels[i]])
+				if a.upper[i] > bound {
+					// Both closest centroids are within radius of the point, so
+					// their norms are within radius of the point's norm. The
+					// radius is widened slightly to absorb rounding errors.
+					radius := a.upper[i]
+					if a.runnerUp[i] >= 0 {
+						radius = max(radius, EuclideanDistance(point, centroids[a.runnerUp[i]]))
+					}
+					radius += 1e-9 * (radius + a.norms[i])
+					low := sort.SearchFloat64s(sortedNorms, a.norms[i]-radius)
+					high := sort.SearchFloat64s(sortedNorms, math.Nextafter(a.norms[i]+radius, math.Inf(1)))
+					labels[i], a.upper[i], a.runnerUp[i], a.lower[i] = twoNearestAmong(point, centroids, order[low:high])
+				}
+			}
+			partial.add(point, labels[i])
+		}
+	})
+}
+
+// twoNearestAmong returns, among the given candidate centroids, the index of
+// the one closest to point and its distance, followed by the index and
+// distance of the second closest. Ties are resolved towards the lowest index.
+func twoNearestAmong(point []float64, centroids [][]float64, candidates []int) (int, float64, int, float64) {
+	best, second := -1, -1
+	first, runnerUp := math.Inf(1), math.Inf(1)
+	for _, j := range candidates {
+		d := EuclideanDistance(point, centroids[j])
+		switch {
+		case d < first || (d == first && j < best):
+			best, first, second, runnerUp = j, d, best, first
+		case d < runnerUp || (d == runnerUp && j < second):
+			second, runnerUp = j, d
+		}
+	}
+	return best, first, second, runnerUp
+}
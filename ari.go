@@ -0,0 +1,47 @@
+package kmeans
+
+// AdjustedRandIndex returns the adjusted Rand index between two labelings of
+// the same observations, such as the Labels of a Result and known classes.
+// The Rand index is the proportion of pairs of observations on which both
+// labelings agree, either together or apart; the adjusted index corrects it
+// for chance, so that it is 1 for identical partitions whatever the label
+// values, close to 0 for independent ones and negative for worse than
+// chance. Labels are compared for equality only, so Noise is a label like
+// any other.
+func AdjustedRandIndex(labels, truth []int) (float64, error) {
+	counts, rows, cols, err := contingency(labels, truth)
+	if err != nil {
+		return 0, err
+	}
+
+	// A single observation has no pairs to disagree on
+	if len(labels) < 2 {
+		return 1, nil
+	}
+
+	together, rowPairs, colPairs := 0.0, 0.0, 0.0
+	for _, row := range counts {
+		for _, c := range row {
+			together += pairs(c)
+		}
+	}
+	for _, r := range rows {
+		rowPairs += pairs(r)
+	}
+	for _, c := range cols {
+		colPairs += pairs(c)
+	}
+
+	expected := rowPairs * colPairs / pairs(float64(len(labels)))
+	maximum := (rowPairs + colPairs) / 2
+	if maximum == expected {
+		// Both labelings are a single cluster or all singletons
+		return 1, nil
+	}
+	return (together - expected) / (maximum - expected), nil
+}
+
+// pairs returns the number of pairs among n items.
+func pairs(n float64) float64 {
+	return n * (n - 1) / 2
+}
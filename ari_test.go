@@ -0,0 +1,90 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAdjustedRandIndex(t *testing.T) {
+	// Identical partitions with different label values
+	ari, err := AdjustedRandIndex([]int{0, 0, 1, 1, 2, 2}, []int{5, 5, 3, 3, Noise, Noise})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ari != 1 {
+		t.Errorf("expected 1 for identical partitions, got %f", ari)
+	}
+
+	// 2 pairs together in both, 6 and 3 pairs together in each out of 15
+	ari, err = AdjustedRandIndex([]int{0, 0, 0, 1, 1, 1}, []int{0, 0, 1, 1, 2, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := (2 - 6.0*3/15) / ((6+3)/2.0 - 6.0*3/15)
+	if math.Abs(ari-want) > 1e-12 {
+		t.Errorf("expected %f, got %f", want, ari)
+	}
+
+	// Single clusters
+	ari, err = AdjustedRandIndex([]int{1, 1, 1}, []int{2, 2, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ari != 1 {
+		t.Errorf("expected 1 for single clusters, got %f", ari)
+	}
+
+	// Single observation
+	ari, err = AdjustedRandIndex([]int{4}, []int{7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ari != 1 {
+		t.Errorf("expected 1 for a single observation, got %f", ari)
+	}
+}
+
+func TestAdjustedRandIndexChance(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	labels, truth := make([]int, 5000), make([]int, 5000)
+	for i := range labels {
+		labels[i], truth[i] = rng.Intn(4), rng.Intn(3)
+	}
+	ari, err := AdjustedRandIndex(labels, truth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(ari) > 0.01 {
+		t.Errorf("expected close to 0 for independent labelings, got %f", ari)
+	}
+}
+
+func TestAdjustedRandIndexRecoversClasses(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+	truth := make([]int, len(dataset))
+	for i := range truth {
+		truth[i] = i / 40
+	}
+	labels, err := ClusterLabels(dataset, 3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ari, err := AdjustedRandIndex(labels, truth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ari != 1 {
+		t.Errorf("expected 1 for recovered classes, got %f", ari)
+	}
+}
+
+func TestAdjustedRandIndexValidation(t *testing.T) {
+	if _, err := AdjustedRandIndex(nil, nil); err == nil {
+		t.Error("expected error for empty labels")
+	}
+	if _, err := AdjustedRandIndex([]int{0, 1}, []int{0}); err == nil {
+		t.Error("expected error for labels of different lengths")
+	}
+}
@@ -0,0 +1,22 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Balanced implements balanced k-means: the k clusters have equal sizes, up
+// to one observation when k does not divide the number of observations. It is
+// SizeConstrained with the sizes rounded down and up from n/k.
+func Balanced[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k, before the sizes divide by it
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+	return SizeConstrained(dataset, k, len(dataset)/k, (len(dataset)+k-1)/k, deltaThreshold, iterationThreshold, rng, opts...)
+}
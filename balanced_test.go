@@ -0,0 +1,59 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBalanced(t *testing.T) {
+	// One blob holds most of the observations
+	rng := rand.New(rand.NewSource(0))
+	dataset := append(gaussians(rng, []Vector{{0, 0}}, 70, 5), gaussians(rng, []Vector{{50, 50}}, 23, 5)...)
+
+	result, err := Balanced(dataset, 3, 0.001, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Converged {
+		t.Error("expected convergence")
+	}
+	for j, cluster := range result.Clusters {
+		if len(cluster) != 31 {
+			t.Errorf("expected 31 observations in cluster %d, got %d", j, len(cluster))
+		}
+	}
+
+	// Sizes differ by one when k does not divide the dataset
+	result, err = Balanced(dataset[:92], 3, 0.001, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for j, cluster := range result.Clusters {
+		if len(cluster) != 30 && len(cluster) != 31 {
+			t.Errorf("expected 30 or 31 observations in cluster %d, got %d", j, len(cluster))
+		}
+	}
+}
+
+func TestBalancedValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Balanced([]Numbers{}, 2, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Balanced(dataset, 7, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Balanced(dataset, 2, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := Balanced(dataset, 2, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := Balanced(dataset, 2, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Balanced(dataset, 2, 0.01, 10, rng, WithCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for missing initial centroids")
+	}
+}
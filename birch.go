@@ -0,0 +1,262 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// BIRCH compresses a dataset into a clustering feature tree (CF-tree) in a
+// single pass, absorbing each observation into the nearest micro-cluster whose
+// radius stays within a threshold. Only the micro-cluster summaries are kept,
+// so datasets far larger than memory can be streamed through Add or Consume
+// and clustered afterwards with k-means over the summaries. Observations are
+// compared with the Euclidean distance.
+//
+// A BIRCH is safe for concurrent use.
+type BIRCH[T Observation] struct {
+	mu        sync.Mutex
+	threshold float64
+	branching int
+	dim       int
+	root      *cfNode
+}
+
+// clusteringFeature summarises a set of points by their number, linear sum and
+// sum of squared norms, which is enough to derive their centroid and radius
+// and to merge sets.
+type clusteringFeature struct {
+	n  float64
+	ls []float64
+	ss float64
+}
+
+// cfNode is a node of a CF-tree. Leaf entries are micro-clusters, the entries
+// of an inner node summarise its children.
+type cfNode struct {
+	entries  []*clusteringFeature
+	children []*cfNode
+}
+
+// NewBIRCH returns an empty CF-tree whose micro-clusters have a radius of at
+// most threshold and whose nodes hold at most branching entries. A larger
+// threshold gives fewer, coarser micro-clusters.
+func NewBIRCH[T Observation](threshold float64, branching int) (*BIRCH[T], error) {
+	// Validate threshold
+	if threshold < 0 {
+		return nil, fmt.Errorf("invalid threshold: %f", threshold)
+	}
+
+	// Validate branching
+	if branching < 2 {
+		return nil, fmt.Errorf("invalid branching factor: %d", branching)
+	}
+
+	return &BIRCH[T]{threshold: threshold, branching: branching, root: &cfNode{}}, nil
+}
+
+// Add inserts a batch of observations into the tree. It fails if an
+// observation does not have the dimension of the previous ones, in which case
+// the observations before it have already been inserted.
+func (b *BIRCH[T]) Add(observations ...T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, obs := range observations {
+		coords := obs.Coordinates()
+		if b.dim == 0 {
+			b.dim = len(coords)
+		}
+		if len(coords) != b.dim {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+		point := &clusteringFeature{n: 1, ls: slices.Clone(coords), ss: dot(coords, coords)}
+
+		// Grow a new root when the old one splits
+		if sibling := b.insert(b.root, point); sibling != nil {
+			b.root = &cfNode{
+				entries:  []*clusteringFeature{b.root.summary(), sibling.summary()},
+				children: []*cfNode{b.root, sibling},
+			}
+		}
+	}
+	return nil
+}
+
+// Consume inserts observations until the channel is closed.
+// It stops at the first error returned by Add.
+func (b *BIRCH[T]) Consume(observations <-chan T) error {
+	for obs := range observations {
+		if err := b.Add(obs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insert adds point below node. If node overflows, it is split and the new
+// sibling is returned for the parent to adopt.
+func (b *BIRCH[T]) insert(node *cfNode, point *clusteringFeature) *cfNode {
+	i := node.closest(point)
+	if node.children == nil {
+		// Absorb the point into the nearest micro-cluster if it stays tight
+		if i >= 0 && node.entries[i].radiusWith(point) <= b.threshold {
+			node.entries[i].absorb(point)
+			return nil
+		}
+		node.entries = append(node.entries, point)
+	} else {
+		node.entries[i].absorb(point)
+		if sibling := b.insert(node.children[i], point); sibling != nil {
+			node.entries[i] = node.children[i].summary()
+			node.entries = append(node.entries, sibling.summary())
+			node.children = append(node.children, sibling)
+		}
+	}
+	if len(node.entries) <= b.branching {
+		return nil
+	}
+	return node.split()
+}
+
+// MicroClusters returns the centroid and the number of observations of every
+// micro-cluster in the tree.
+func (b *BIRCH[T]) MicroClusters() ([][]float64, []float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var centroids [][]float64
+	var weights []float64
+	var walk func(node *cfNode)
+	walk = func(node *cfNode) {
+		if node.children == nil {
+			for _, entry := range node.entries {
+				centroids = append(centroids, entry.centroid())
+				weights = append(weights, entry.n)
+			}
+			return
+		}
+		for _, child := range node.children {
+			walk(child)
+		}
+	}
+	walk(b.root)
+	return centroids, weights
+}
+
+// Cluster runs k-means over the micro-clusters, each weighted by its number of
+// observations, seeding with weighted k-means++ and iterating until no
+// centroid moves by deltaThreshold or more or iterationThreshold iterations
+// ran. It returns the k centroids.
+func (b *BIRCH[T]) Cluster(k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand) ([][]float64, error) {
+	points, weights := b.MicroClusters()
+
+	// Validate k
+	if k <= 0 || k > len(points) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	centroids := seedPlusPlus(points, weights, k, 1, rng)
+	centroids, _, _ = weightedLloyd(points, weights, centroids, deltaThreshold, iterationThreshold)
+	return centroids, nil
+}
+
+// centroid returns the mean of the summarised points.
+func (cf *clusteringFeature) centroid() []float64 {
+	c := make([]float64, len(cf.ls))
+	for d := range c {
+		c[d] = cf.ls[d] / cf.n
+	}
+	return c
+}
+
+// absorb adds the points summarised by other to cf.
+func (cf *clusteringFeature) absorb(other *clusteringFeature) {
+	cf.n += other.n
+	for d := range cf.ls {
+		cf.ls[d] += other.ls[d]
+	}
+	cf.ss += other.ss
+}
+
+// radiusWith returns the root mean squared distance to their centroid of the
+// points summarised by cf and other together.
+func (cf *clusteringFeature) radiusWith(other *clusteringFeature) float64 {
+	n := cf.n + other.n
+	norm := 0.0
+	for d := range cf.ls {
+		c := (cf.ls[d] + other.ls[d]) / n
+		norm += c * c
+	}
+	return math.Sqrt(max(0, (cf.ss+other.ss)/n-norm))
+}
+
+// closest returns the index of the entry of node whose centroid is nearest to
+// the centroid of cf, or -1 if node is empty.
+func (node *cfNode) closest(cf *clusteringFeature) int {
+	target := cf.centroid()
+	best, bestDist := -1, math.Inf(1)
+	for i, entry := range node.entries {
+		if d := squaredDistance(target, entry.centroid()); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// summary returns a clustering feature summarising every entry of node.
+func (node *cfNode) summary() *clusteringFeature {
+	cf := &clusteringFeature{ls: make([]float64, len(node.entries[0].ls))}
+	for _, entry := range node.entries {
+		cf.absorb(entry)
+	}
+	return cf
+}
+
+// split moves the entries of node closer to the second of its two most distant
+// entries into a new sibling, which it returns.
+func (node *cfNode) split() *cfNode {
+	centroids := make([][]float64, len(node.entries))
+	for i, entry := range node.entries {
+		centroids[i] = entry.centroid()
+	}
+	a, b, farthest := 0, 0, -1.0
+	for i := range centroids {
+		for j := i + 1; j < len(centroids); j++ {
+			if d := squaredDistance(centroids[i], centroids[j]); d > farthest {
+				a, b, farthest = i, j, d
+			}
+		}
+	}
+
+	kept, sibling := &cfNode{}, &cfNode{}
+	for i, entry := range node.entries {
+		target := kept
+		if i == b || (i != a && squaredDistance(centroids[i], centroids[b]) < squaredDistance(centroids[i], centroids[a])) {
+			target = sibling
+		}
+		target.entries = append(target.entries, entry)
+		if node.children != nil {
+			target.children = append(target.children, node.children[i])
+		}
+	}
+	*node = *kept
+	return sibling
+}
@@ -0,0 +1,80 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBIRCH(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20000, 5, 2)
+	b, err := NewBIRCH[Vector](2, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Add(dataset...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The tree compresses the data and keeps every observation
+	points, weights := b.MicroClusters()
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if len(points) > len(dataset)/10 || total != float64(len(dataset)) {
+		t.Errorf("expected few micro-clusters of weight %d, got %d of weight %v", len(dataset), len(points), total)
+	}
+
+	// Clustering the summaries is close to clustering the whole dataset
+	centroids, err := b.Cluster(5, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(1)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inertia := 0.0
+	for _, obs := range dataset {
+		_, d := nearest(obs, centroids, EuclideanDistance)
+		inertia += d * d
+	}
+	if inertia > 1.05*expected.Inertia {
+		t.Errorf("expected inertia close to %v, got %v", expected.Inertia, inertia)
+	}
+}
+
+func TestClusteringFeature(t *testing.T) {
+	cf := &clusteringFeature{n: 1, ls: []float64{0, 0}, ss: 0}
+	other := &clusteringFeature{n: 1, ls: []float64{2, 0}, ss: 4}
+
+	// Two points 2 apart have a radius of 1 around their midpoint
+	if r := cf.radiusWith(other); math.Abs(r-1) > 1e-12 {
+		t.Errorf("expected radius 1, got %v", r)
+	}
+	cf.absorb(other)
+	if c := cf.centroid(); c[0] != 1 || c[1] != 0 || cf.n != 2 {
+		t.Errorf("expected centroid (1, 0) of 2 points, got %v of %v", c, cf.n)
+	}
+}
+
+func TestBIRCHValidation(t *testing.T) {
+	if _, err := NewBIRCH[Numbers](-1, 10); err == nil {
+		t.Error("expected error for negative threshold")
+	}
+	if _, err := NewBIRCH[Numbers](1, 1); err == nil {
+		t.Error("expected error for invalid branching factor")
+	}
+
+	b, err := NewBIRCH[Vector](0, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Add(Vector{1, 2}, Vector{1}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, err := b.Cluster(2, 0.01, 10, rand.New(rand.NewSource(0))); err == nil {
+		t.Error("expected error with fewer micro-clusters than clusters")
+	}
+}
@@ -0,0 +1,64 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// CalinskiHarabasz returns the Calinski-Harabasz index of clusters, or
+// variance ratio criterion: the sum of squared Euclidean distances from the
+// cluster means to the mean of all observations, weighted by cluster sizes,
+// over the sum of squared distances of the observations to their cluster
+// mean, each divided by its degrees of freedom. Higher is better, and it is
+// infinite when every cluster collapses to a point. Empty clusters are
+// ignored, at least two clusters must remain and there must be more
+// observations than clusters.
+//
+// It only needs the sums and counts of the clusters and one pass over the
+// observations, so it is cheap enough to compare many values of k.
+func CalinskiHarabasz[T Observation](clusters [][]T) (float64, error) {
+	points, labels, err := flattenClusters(clusters)
+	if err != nil {
+		return 0, err
+	}
+
+	// Sums and counts of the clusters
+	stats := NewStats(len(clusters), len(points[0]))
+	for i, j := range labels {
+		stats.add(points[i], j)
+	}
+	k, n := 0, float64(len(points))
+	means := make([][]float64, len(clusters))
+	mean := make([]float64, len(points[0]))
+	for j, count := range stats.Counts {
+		if count == 0 {
+			continue
+		}
+		k++
+		means[j] = make([]float64, len(mean))
+		for d, sum := range stats.Sums[j] {
+			means[j][d] = sum / count
+			mean[d] += sum / n
+		}
+	}
+
+	// Validate the degrees of freedom
+	if len(points) <= k {
+		return 0, fmt.Errorf("expected more than %d observations, got %d", k, len(points))
+	}
+
+	between := 0.0
+	for j, count := range stats.Counts {
+		if count > 0 {
+			between += count * squaredDistance(means[j], mean)
+		}
+	}
+	within := 0.0
+	for i, j := range labels {
+		within += squaredDistance(points[i], means[j])
+	}
+	if within == 0 {
+		return math.Inf(1), nil
+	}
+	return (between / float64(k-1)) / (within / (n - float64(k))), nil
+}
@@ -0,0 +1,72 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCalinskiHarabasz(t *testing.T) {
+	// Means 1 and 12, overall mean 6.5: between 2*5.5² + 2*5.5² = 121 over 1
+	// degree of freedom, within 1+1+4+4 = 10 over 2
+	index, err := CalinskiHarabasz([][]Numbers{{0, 2}, {10, 14}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 121.0 / 5; math.Abs(index-want) > 1e-9 {
+		t.Errorf("expected index %f, got %f", want, index)
+	}
+
+	// Empty clusters are ignored
+	again, err := CalinskiHarabasz([][]Numbers{{0, 2}, {}, {10, 14}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != index {
+		t.Errorf("expected index %f ignoring the empty cluster, got %f", index, again)
+	}
+
+	// Clusters collapsed to points
+	index, err = CalinskiHarabasz([][]Numbers{{1, 1}, {5, 5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(index, 1) {
+		t.Errorf("expected an infinite index for collapsed clusters, got %f", index)
+	}
+}
+
+func TestCalinskiHarabaszRanksK(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	best, bestIndex := 0, 0.0
+	for k := 2; k <= 6; k++ {
+		clusters, err := Cluster(dataset, k, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		index, err := CalinskiHarabasz(clusters)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if index > bestIndex {
+			best, bestIndex = k, index
+		}
+	}
+	if best != len(centers) {
+		t.Errorf("expected the highest index with %d clusters, got %d", len(centers), best)
+	}
+}
+
+func TestCalinskiHarabaszValidation(t *testing.T) {
+	if _, err := CalinskiHarabasz([][]Numbers{{1, 2}}); err == nil {
+		t.Error("expected error for a single cluster")
+	}
+	if _, err := CalinskiHarabasz([][]Numbers{{1}, {2}}); err == nil {
+		t.Error("expected error for as many observations as clusters")
+	}
+	if _, err := CalinskiHarabasz([][]Vector{{{1}}, {{2, 0}}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
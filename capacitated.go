@@ -0,0 +1,155 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Capacitated implements capacitated clustering: observation i has a demand
+// demands[i] and cluster j a capacity capacities[j], and the total demand of
+// a cluster never exceeds its capacity. Each iteration follows Mulvey and
+// Beck's heuristic: observations are assigned in decreasing order of regret,
+// the extra loss of their second nearest centroid over their nearest, each
+// to the nearest centroid with enough capacity left, then every centroid
+// moves to the center of its cluster. It iterates until no centroid moves by
+// deltaThreshold or more or iterationThreshold iterations ran.
+//
+// Meeting capacities with demands is NP-hard, and it fails when an
+// observation fits in no cluster, which the heuristic may run into when the
+// capacities are tight even if the demands could be met. Initial centroids
+// are chosen as in Cluster. It honours WithDistance and WithCenter.
+func Capacitated[T Observation](dataset []T, k int, demands, capacities []float64, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate demands and capacities
+	if len(demands) != len(dataset) {
+		return nil, fmt.Errorf("expected %d demands, got %d", len(dataset), len(demands))
+	}
+	if len(capacities) != k {
+		return nil, fmt.Errorf("expected %d capacities, got %d", k, len(capacities))
+	}
+	totalDemand, totalCapacity := 0.0, 0.0
+	for _, d := range demands {
+		if d < 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+			return nil, fmt.Errorf("invalid demand: %f", d)
+		}
+		totalDemand += d
+	}
+	for _, c := range capacities {
+		if c < 0 || math.IsNaN(c) || math.IsInf(c, 0) {
+			return nil, fmt.Errorf("invalid capacity: %f", c)
+		}
+		totalCapacity += c
+	}
+	if totalDemand > totalCapacity {
+		return nil, fmt.Errorf("total demand %f exceeds total capacity %f", totalDemand, totalCapacity)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCentroids(cfg.centroids, k, len(points[0])); err != nil {
+		return nil, err
+	}
+
+	loss := cfg.lossFunc()
+	centroids := initCentroids(points, k, cfg, rng)
+	labels := make([]int, len(points))
+	costs := newMatrix(len(points), k)
+	regrets := make([]float64, len(points))
+	order := make([]int, len(points))
+	remaining := make([]float64, k)
+	iterations, converged := 0, false
+	for range iterationThreshold {
+		iterations++
+
+		// Assignment step: observations with most to lose choose first
+		for i, p := range points {
+			first, second := math.Inf(1), math.Inf(1)
+			for j, centroid := range centroids {
+				costs[i][j] = loss(p, centroid)
+				if costs[i][j] < first {
+					first, second = costs[i][j], first
+				} else if costs[i][j] < second {
+					second = costs[i][j]
+				}
+			}
+			regrets[i] = second - first
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(regrets[b], regrets[a])
+		})
+		copy(remaining, capacities)
+		for _, i := range order {
+			best := -1
+			for j, c := range costs[i] {
+				if demands[i] <= remaining[j] && (best == -1 || c < costs[i][best]) {
+					best = j
+				}
+			}
+			if best == -1 {
+				return nil, fmt.Errorf("observation %d fits in no cluster", i)
+			}
+			labels[i] = best
+			remaining[best] -= demands[i]
+		}
+
+		// Update step: calculate new centroids
+		stats := NewStats(k, len(points[0]))
+		for i, j := range labels {
+			stats.add(points[i], j)
+		}
+		newCentroids := update(points, labels, centroids, stats, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	result := newResult(dataset, points, centroids, labels, nil, loss)
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCapacitated(t *testing.T) {
+	// The first blob demands more than a cluster holds
+	rng := rand.New(rand.NewSource(0))
+	dataset := append(gaussians(rng, []Vector{{0, 0}}, 60, 5), gaussians(rng, []Vector{{50, 50}}, 20, 5)...)
+	demands := make([]float64, len(dataset))
+	for i := range demands {
+		demands[i] = float64(1 + rng.Intn(3))
+	}
+	capacities := []float64{100, 100}
+
+	result, err := Capacitated(dataset, 2, demands, capacities, 0.001, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loads := make([]float64, 2)
+	for i, j := range result.Labels {
+		loads[j] += demands[i]
+	}
+	for j, load := range loads {
+		if load > capacities[j] {
+			t.Errorf("cluster %d carries %v over its capacity %v", j, load, capacities[j])
+		}
+	}
+
+	// The second blob stays together and takes the overflow
+	for i := 60; i < 80; i++ {
+		if result.Labels[i] != result.Labels[60] {
+			t.Fatalf("observation %v left its blob", dataset[i])
+		}
+	}
+}
+
+func TestCapacitatedValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	demands := []float64{1, 1, 1, 1, 1, 1}
+	capacities := []float64{3, 3}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Capacitated([]Numbers{}, 2, nil, capacities, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Capacitated(dataset, 7, demands, capacities, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Capacitated(dataset, 2, demands[:5], capacities, 0.01, 10, rng); err == nil {
+		t.Error("expected error for missing demands")
+	}
+	if _, err := Capacitated(dataset, 2, demands, capacities[:1], 0.01, 10, rng); err == nil {
+		t.Error("expected error for missing capacities")
+	}
+	if _, err := Capacitated(dataset, 2, []float64{1, 1, 1, 1, 1, -1}, capacities, 0.01, 10, rng); err == nil {
+		t.Error("expected error for negative demand")
+	}
+	if _, err := Capacitated(dataset, 2, demands, []float64{3, 2}, 0.01, 10, rng); err == nil {
+		t.Error("expected error for insufficient capacity")
+	}
+	if _, err := Capacitated(dataset, 2, []float64{4, 0, 0, 0, 0, 0}, capacities, 0.01, 10, rng); err == nil {
+		t.Error("expected error for a demand fitting nowhere")
+	}
+	if _, err := Capacitated(dataset, 2, demands, capacities, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := Capacitated(dataset, 2, demands, capacities, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := Capacitated(dataset, 2, demands, capacities, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Capacitated(dataset, 2, demands, capacities, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
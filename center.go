@@ -0,0 +1,86 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+)
+
+// CenterFunc computes the centroid of a non-empty group of points.
+type CenterFunc func(points [][]float64) []float64
+
+// HaversineCenter calculates the geographic midpoint of points given as
+// latitude and longitude in degrees, by averaging them as unit vectors in
+// three dimensions. Unlike the plain mean it handles the poles and the
+// antimeridian correctly.
+func HaversineCenter(points [][]float64) []float64 {
+	x, y, z := 0.0, 0.0, 0.0
+	for _, p := range points {
+		lat, lon := p[0]*math.Pi/180, p[1]*math.Pi/180
+		x += math.Cos(lat) * math.Cos(lon)
+		y += math.Cos(lat) * math.Sin(lon)
+		z += math.Sin(lat)
+	}
+	lat := math.Atan2(z, math.Hypot(x, y))
+	lon := math.Atan2(y, x)
+	return []float64{lat * 180 / math.Pi, lon * 180 / math.Pi}
+}
+
+// MajorityCenter calculates the centroid of binary points by majority vote:
+// a dimension is set to 1 when it is non-zero in more than half of the points
+// and to 0 otherwise, so centroids stay valid binary vectors.
+func MajorityCenter(points [][]float64) []float64 {
+	center := make([]float64, len(points[0]))
+	for d := range center {
+		ones := 0
+		for _, p := range points {
+			if p[d] != 0 {
+				ones++
+			}
+		}
+		if 2*ones > len(points) {
+			center[d] = 1
+		}
+	}
+	return center
+}
+
+// MedianCenter calculates the component-wise median of points, the average of
+// the two middle values for an even number of points. Paired with
+// ManhattanDistance it gives k-medians, which outliers barely move.
+func MedianCenter(points [][]float64) []float64 {
+	center := make([]float64, len(points[0]))
+	values := make([]float64, len(points))
+	for d := range center {
+		for i, p := range points {
+			values[i] = p[d]
+		}
+		slices.Sort(values)
+		mid := len(values) / 2
+		if len(values)%2 == 1 {
+			center[d] = values[mid]
+		} else {
+			center[d] = (values[mid-1] + values[mid]) / 2
+		}
+	}
+	return center
+}
+
+// ModeCenter calculates the most frequent value of each dimension of points,
+// the smallest one in case of a tie. Paired with HammingDistance it gives
+// k-modes for categorical data encoded as category codes.
+func ModeCenter(points [][]float64) []float64 {
+	center := make([]float64, len(points[0]))
+	counts := make(map[float64]int)
+	for d := range center {
+		clear(counts)
+		best, bestCount := 0.0, 0
+		for _, p := range points {
+			counts[p[d]]++
+			if c := counts[p[d]]; c > bestCount || (c == bestCount && p[d] < best) {
+				best, bestCount = p[d], c
+			}
+		}
+		center[d] = best
+	}
+	return center
+}
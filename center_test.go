@@ -0,0 +1,149 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestHaversineCenter(t *testing.T) {
+	// The midpoint of points straddling the antimeridian stays on it
+	center := HaversineCenter([][]float64{{-1, 179}, {1, -179}})
+	if math.Abs(center[0]) > 1e-9 || math.Abs(math.Abs(center[1])-180) > 1e-9 {
+		t.Errorf("expected (0, ±180), got %v", center)
+	}
+}
+
+func TestClusterHaversine(t *testing.T) {
+	dataset := []Coordinates{
+		{0, 179}, {1, -179}, {-1, 178}, {0, -178},
+		{0, 0}, {1, 1}, {-1, 0}, {0, -1},
+	}
+	result, err := ClusterResult(dataset, 2, 1e-6, 100, nil,
+		WithCentroids([][]float64{{0, 180}, {0, 10}}),
+		WithDistance(HaversineDistance),
+		WithCenter(HaversineCenter),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Coordinates{
+		{{0, 179}, {1, -179}, {-1, 178}, {0, -178}},
+		{{0, 0}, {1, 1}, {-1, 0}, {0, -1}},
+	})
+
+	// A planar mean would have put this centroid near the prime meridian
+	if lon := math.Abs(result.Centroids[0][1]); lon < 179 {
+		t.Errorf("expected centroid near the antimeridian, got %v", result.Centroids[0])
+	}
+}
+
+type Bits [6]bool
+
+func (b Bits) Coordinates() []float64 {
+	coords := make([]float64, len(b))
+	for i, set := range b {
+		if set {
+			coords[i] = 1
+		}
+	}
+	return coords
+}
+
+func TestMajorityCenter(t *testing.T) {
+	center := MajorityCenter([][]float64{{1, 0, 1}, {1, 1, 0}, {1, 0, 0}, {0, 1, 1}})
+	expected := []float64{1, 0, 0}
+	if !slices.Equal(center, expected) {
+		t.Errorf("expected %v, got %v", expected, center)
+	}
+}
+
+func TestClusterHamming(t *testing.T) {
+	dataset := []Bits{
+		{true, true, true, false, false, false},
+		{true, true, false, false, false, false},
+		{true, false, true, false, false, false},
+		{false, false, false, true, true, true},
+		{false, false, false, true, true, false},
+		{false, false, false, false, true, true},
+	}
+	result, err := ClusterResult(dataset, 2, 0.01, 100, nil,
+		WithInit(InitMaximin),
+		WithDistance(HammingDistance),
+		WithCenter(MajorityCenter),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Bits{dataset[:3], dataset[3:]})
+
+	// Centroids remain binary fingerprints
+	for j, centroid := range result.Centroids {
+		for _, x := range centroid {
+			if x != 0 && x != 1 {
+				t.Errorf("centroid %d is not binary: %v", j, centroid)
+			}
+		}
+	}
+}
+
+func TestMedianCenter(t *testing.T) {
+	center := MedianCenter([][]float64{{1, 4}, {3, 1}, {1000, 2}, {2, 3}})
+	expected := []float64{2.5, 2.5}
+	if !slices.Equal(center, expected) {
+		t.Errorf("expected %v, got %v", expected, center)
+	}
+}
+
+func TestClusterMedians(t *testing.T) {
+	// The outliers would drag the means out of their clusters
+	dataset := []Coordinates{
+		{1, 1}, {2, 2}, {3, 1}, {2, 1000},
+		{11, 11}, {12, 12}, {13, 11}, {1000, 12},
+	}
+	result, err := ClusterResult(dataset, 2, 0.01, 100, nil,
+		WithCentroids([][]float64{{1, 1}, {11, 11}}),
+		WithMedians(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]float64{{2, 1}, {12, 12}}
+	if !slices.EqualFunc(result.Centroids, expected, slices.Equal) {
+		t.Errorf("expected centroids %v, got %v", expected, result.Centroids)
+	}
+}
+
+type Categories [3]string
+
+// categoryCodes maps each category to the code it is clustered with.
+var categoryCodes = map[string]float64{"red": 0, "green": 1, "blue": 2, "small": 0, "large": 1, "wood": 0, "metal": 1, "glass": 2}
+
+func (c Categories) Coordinates() []float64 {
+	return []float64{categoryCodes[c[0]], categoryCodes[c[1]], categoryCodes[c[2]]}
+}
+
+func TestModeCenter(t *testing.T) {
+	center := ModeCenter([][]float64{{1, 2}, {3, 2}, {3, 1}, {1, 0}})
+	expected := []float64{1, 2}
+	if !slices.Equal(center, expected) {
+		t.Errorf("expected %v, got %v", expected, center)
+	}
+}
+
+func TestClusterModes(t *testing.T) {
+	dataset := []Categories{
+		{"red", "small", "wood"}, {"red", "small", "metal"}, {"red", "large", "wood"},
+		{"blue", "large", "glass"}, {"blue", "large", "metal"}, {"green", "large", "glass"},
+	}
+	result, err := ClusterResult(dataset, 2, 0.01, 100, nil, WithInit(InitMaximin), WithModes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Categories{dataset[:3], dataset[3:]})
+
+	// Each observation differs from its mode in one attribute at most
+	if result.Inertia != 4 {
+		t.Errorf("expected 4 mismatches, got %v", result.Inertia)
+	}
+}
@@ -0,0 +1,145 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// defaultNumLocal is the number of local searches CLARANS runs when none is
+// configured.
+const defaultNumLocal = 2
+
+// CLARANS implements Clustering Large Applications based on RANdomized Search:
+// starting from random medoids, it tries swaps of a random medoid with a
+// random non-medoid and moves to the first swap that lowers the cost, until
+// maxneighbor consecutive swaps fail. The best of numlocal such local searches
+// is kept. Unlike PAM, it never evaluates every possible swap, which makes it
+// faster for large datasets and many clusters.
+//
+// numlocal is set with WithNumLocal (default 2) and maxneighbor with
+// WithMaxNeighbor (default 1.25% of k(n-k), at least 250). Observations are
+// compared with the configured distance, which need not be Euclidean.
+func CLARANS[T Observation](dataset []T, k int, rng *rand.Rand, opts ...Option) (*MedoidResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate numlocal and maxneighbor
+	if cfg.numLocal < 0 {
+		return nil, fmt.Errorf("invalid numlocal: %d", cfg.numLocal)
+	}
+	if cfg.maxNeighbor < 0 {
+		return nil, fmt.Errorf("invalid maxneighbor: %d", cfg.maxNeighbor)
+	}
+	numLocal, maxNeighbor := cfg.numLocal, cfg.maxNeighbor
+	if numLocal == 0 {
+		numLocal = defaultNumLocal
+	}
+	if maxNeighbor == 0 {
+		maxNeighbor = max(250, k*(len(dataset)-k)/80)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	distance := cfg.distanceFunc()
+
+	var best []int
+	bestCost := math.Inf(1)
+	bestSwaps := 0
+	for range numLocal {
+		medoids, cost, swaps := claransSearch(points, k, maxNeighbor, distance, rng)
+		if cost < bestCost {
+			best, bestCost, bestSwaps = medoids, cost, swaps
+		}
+	}
+	return newMedoidResult(dataset, points, best, bestCost, bestSwaps, cfg), nil
+}
+
+// claransSearch runs one CLARANS local search from random medoids and returns
+// the medoids, their cost and the number of swaps applied.
+func claransSearch(points [][]float64, k, maxNeighbor int, distance DistanceFunc, rng *rand.Rand) ([]int, float64, int) {
+	n := len(points)
+	medoids := slices.Clone(randomIndices(n, k, rng))
+	isMedoid := make([]bool, n)
+	for _, m := range medoids {
+		isMedoid[m] = true
+	}
+
+	// Distance of each point to its nearest and second nearest medoids, and
+	// the position in medoids of the nearest
+	first := make([]float64, n)
+	second := make([]float64, n)
+	closest := make([]int, n)
+	refresh := func() float64 {
+		cost := 0.0
+		for o, p := range points {
+			first[o], second[o], closest[o] = math.Inf(1), math.Inf(1), -1
+			for j, m := range medoids {
+				switch d := distance(p, points[m]); {
+				case d < first[o]:
+					first[o], second[o], closest[o] = d, first[o], j
+				case d < second[o]:
+					second[o] = d
+				}
+			}
+			cost += first[o]
+		}
+		return cost
+	}
+	cost := refresh()
+
+	// Nothing to swap when every point is a medoid
+	if k == n {
+		return medoids, cost, 0
+	}
+
+	swaps := 0
+	for failures := 0; failures < maxNeighbor; {
+		// Draw a random neighbour of the current medoids
+		i := rng.Intn(k)
+		h := rng.Intn(n)
+		for isMedoid[h] {
+			h = rng.Intn(n)
+		}
+
+		delta := 0.0
+		for o, p := range points {
+			d := distance(p, points[h])
+			if closest[o] == i {
+				delta += min(d, second[o]) - first[o]
+			} else {
+				delta += min(d-first[o], 0)
+			}
+		}
+		if delta >= -1e-12 {
+			failures++
+			continue
+		}
+
+		// Move to the better neighbour
+		swaps++
+		failures = 0
+		isMedoid[medoids[i]] = false
+		isMedoid[h] = true
+		medoids[i] = h
+		cost = refresh()
+	}
+	return medoids, cost, swaps
+}
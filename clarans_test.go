@@ -0,0 +1,42 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestCLARANS(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13, 21, 22, 23, 1000}
+	result, err := CLARANS(dataset, 4, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The default search explores this small dataset exhaustively
+	medoids := slices.Sorted(slices.Values(result.Medoids))
+	if !slices.Equal(medoids, []int{1, 4, 7, 9}) {
+		t.Errorf("expected medoids [1 4 7 9], got %v", medoids)
+	}
+	if result.Cost != 6 {
+		t.Errorf("expected cost 6, got %v", result.Cost)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 3}, {11, 12, 13}, {21, 22, 23}, {1000}})
+}
+
+func TestCLARANSValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := CLARANS(dataset, 2, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := CLARANS(dataset, 0, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := CLARANS(dataset, 2, rng, WithNumLocal(-1)); err == nil {
+		t.Error("expected error for negative numlocal")
+	}
+	if _, err := CLARANS(dataset, 2, rng, WithMaxNeighbor(-1)); err == nil {
+		t.Error("expected error for negative maxneighbor")
+	}
+}
@@ -0,0 +1,327 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// cluStreamBoundary is the number of RMS radii within which an observation is
+// absorbed by its nearest micro-cluster.
+const cluStreamBoundary = 2
+
+// cluStreamSnapshots is the number of snapshots kept for each order of the
+// pyramidal time frame.
+const cluStreamSnapshots = 3
+
+// CluStream maintains a fixed number of micro-clusters over an evolving
+// stream, each summarising its observations and their timestamps. Snapshots
+// of the micro-clusters are stored in a pyramidal time frame, dense for the
+// recent past and sparse for the distant past, so that the clusters over a
+// time horizon can be recovered by subtracting an old snapshot from the
+// current state. Observations are compared with the Euclidean distance.
+//
+// A CluStream is safe for concurrent use.
+type CluStream[T Observation] struct {
+	mu       sync.Mutex
+	size     int
+	interval time.Duration
+	expiry   time.Duration
+	dim      int
+	start    time.Time
+	now      float64
+	ticks    int
+	nextID   int
+	clusters []*microCluster
+	// snapshots holds the last snapshots of each order: a snapshot taken at
+	// tick c has the largest order i such that 2^i divides c
+	snapshots map[int][]cluStreamSnapshot
+}
+
+// microCluster extends a clustering feature with the linear and squared sums
+// of the timestamps of its observations, and the ids of the micro-clusters
+// merged into it.
+type microCluster struct {
+	cf  clusteringFeature
+	lst float64
+	sst float64
+	ids []int
+}
+
+// cluStreamSnapshot is a copy of the micro-clusters taken at a point in time,
+// in seconds since the first observation.
+type cluStreamSnapshot struct {
+	at       float64
+	clusters []*microCluster
+}
+
+// NewCluStream returns a CluStream keeping at most microClusters
+// micro-clusters, taking a snapshot every interval. A micro-cluster that has
+// not received observations for about expiry is dropped to make room for new
+// ones; otherwise the two closest micro-clusters are merged.
+func NewCluStream[T Observation](microClusters int, interval, expiry time.Duration) (*CluStream[T], error) {
+	// Validate microClusters
+	if microClusters < 2 {
+		return nil, fmt.Errorf("invalid number of micro-clusters: %d", microClusters)
+	}
+
+	// Validate interval
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid snapshot interval: %v", interval)
+	}
+
+	// Validate expiry
+	if expiry <= 0 {
+		return nil, fmt.Errorf("invalid expiry: %v", expiry)
+	}
+
+	return &CluStream[T]{
+		size:      microClusters,
+		interval:  interval,
+		expiry:    expiry,
+		snapshots: map[int][]cluStreamSnapshot{},
+	}, nil
+}
+
+// Add absorbs a batch of observations received at time at. It fails if an
+// observation does not have the dimension of the previous ones, in which case
+// the observations before it have already been absorbed.
+func (s *CluStream[T]) Add(at time.Time, observations ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.start.IsZero() {
+		s.start = at
+	}
+	s.now = max(s.now, at.Sub(s.start).Seconds())
+
+	// Take a snapshot when a new interval started
+	if ticks := int(s.now / s.interval.Seconds()); ticks > s.ticks {
+		s.ticks = ticks
+		s.snapshot()
+	}
+
+	for _, obs := range observations {
+		coords := obs.Coordinates()
+		if s.dim == 0 {
+			s.dim = len(coords)
+		}
+		if len(coords) != s.dim {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+		s.absorb(coords)
+	}
+	return nil
+}
+
+// absorb adds point, received now, to its nearest micro-cluster if it falls
+// within its boundary, or to a new micro-cluster otherwise.
+func (s *CluStream[T]) absorb(coords []float64) {
+	point := &microCluster{
+		cf:  clusteringFeature{n: 1, ls: slices.Clone(coords), ss: dot(coords, coords)},
+		lst: s.now,
+		sst: s.now * s.now,
+	}
+
+	if j, d := s.nearest(coords); j >= 0 && d <= s.boundary(j) {
+		s.clusters[j].absorb(point)
+		return
+	}
+
+	point.ids = []int{s.nextID}
+	s.nextID++
+	if len(s.clusters) < s.size {
+		s.clusters = append(s.clusters, point)
+		return
+	}
+
+	// Replace the stalest micro-cluster if it expired
+	stalest := 0
+	for j, mc := range s.clusters {
+		if mc.relevance() < s.clusters[stalest].relevance() {
+			stalest = j
+		}
+	}
+	if s.clusters[stalest].relevance() < s.now-s.expiry.Seconds() {
+		s.clusters[stalest] = point
+		return
+	}
+
+	// Otherwise merge the two closest micro-clusters to make room
+	a, b, closest := 0, 1, math.Inf(1)
+	for i := range s.clusters {
+		for j := i + 1; j < len(s.clusters); j++ {
+			if d := squaredDistance(s.clusters[i].cf.centroid(), s.clusters[j].cf.centroid()); d < closest {
+				a, b, closest = i, j, d
+			}
+		}
+	}
+	s.clusters[a].absorb(s.clusters[b])
+	s.clusters[a].ids = append(s.clusters[a].ids, s.clusters[b].ids...)
+	s.clusters[b] = point
+}
+
+// nearest returns the index of the micro-cluster whose centroid is nearest to
+// point and the distance to it, or -1 if there is none.
+func (s *CluStream[T]) nearest(point []float64) (int, float64) {
+	best, bestDist := -1, math.Inf(1)
+	for j, mc := range s.clusters {
+		if d := EuclideanDistance(point, mc.cf.centroid()); d < bestDist {
+			best, bestDist = j, d
+		}
+	}
+	return best, bestDist
+}
+
+// boundary returns the distance within which micro-cluster j absorbs points:
+// a multiple of its RMS radius, or the distance to the nearest other
+// micro-cluster while it holds a single observation.
+func (s *CluStream[T]) boundary(j int) float64 {
+	mc := s.clusters[j]
+	if mc.cf.n > 1 {
+		return cluStreamBoundary * math.Sqrt(max(0, mc.cf.ss/mc.cf.n-dot(mc.cf.centroid(), mc.cf.centroid())))
+	}
+	centroid := mc.cf.centroid()
+	boundary := math.Inf(1)
+	for i, other := range s.clusters {
+		if i != j {
+			boundary = min(boundary, EuclideanDistance(centroid, other.cf.centroid()))
+		}
+	}
+	return boundary
+}
+
+// snapshot stores a copy of the micro-clusters in the pyramidal time frame,
+// dropping the oldest snapshot of the same order.
+func (s *CluStream[T]) snapshot() {
+	order := 0
+	for s.ticks%(1<<(order+1)) == 0 {
+		order++
+	}
+	clusters := make([]*microCluster, len(s.clusters))
+	for j, mc := range s.clusters {
+		clusters[j] = mc.clone()
+	}
+	snapshots := append(s.snapshots[order], cluStreamSnapshot{at: s.now, clusters: clusters})
+	if len(snapshots) > cluStreamSnapshots {
+		snapshots = snapshots[1:]
+	}
+	s.snapshots[order] = snapshots
+}
+
+// MicroClusters returns the centroid and the number of observations of every
+// micro-cluster over the last horizon, or since the first observation if the
+// horizon is zero or longer than the history kept. The horizon is matched to
+// the closest snapshot.
+func (s *CluStream[T]) MicroClusters(horizon time.Duration) ([][]float64, []float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Find the snapshot closest to the start of the horizon
+	var past *cluStreamSnapshot
+	if target := s.now - horizon.Seconds(); horizon > 0 && target >= 0 {
+		for _, snapshots := range s.snapshots {
+			for i := range snapshots {
+				if past == nil || math.Abs(snapshots[i].at-target) < math.Abs(past.at-target) {
+					past = &snapshots[i]
+				}
+			}
+		}
+	}
+
+	// Subtract the past micro-clusters from the ones they were merged into
+	current := make([]*microCluster, len(s.clusters))
+	owner := map[int]int{}
+	for j, mc := range s.clusters {
+		current[j] = mc.clone()
+		for _, id := range mc.ids {
+			owner[id] = j
+		}
+	}
+	if past != nil {
+		for _, mc := range past.clusters {
+			if j, ok := owner[mc.ids[0]]; ok {
+				current[j].subtract(mc)
+			}
+		}
+	}
+
+	var centroids [][]float64
+	var weights []float64
+	for _, mc := range current {
+		// Rounding can leave a tiny weight for emptied micro-clusters
+		if mc.cf.n > 0.5 {
+			centroids = append(centroids, mc.cf.centroid())
+			weights = append(weights, mc.cf.n)
+		}
+	}
+	return centroids, weights
+}
+
+// Cluster runs k-means over the micro-clusters of the last horizon, as
+// returned by MicroClusters, each weighted by its number of observations. It
+// seeds with weighted k-means++ and iterates until no centroid moves by
+// deltaThreshold or more or iterationThreshold iterations ran.
+func (s *CluStream[T]) Cluster(k int, horizon time.Duration, deltaThreshold float64, iterationThreshold int, rng *rand.Rand) ([][]float64, error) {
+	points, weights := s.MicroClusters(horizon)
+
+	// Validate k
+	if k <= 0 || k > len(points) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	centroids := seedPlusPlus(points, weights, k, 1, rng)
+	centroids, _, _ = weightedLloyd(points, weights, centroids, deltaThreshold, iterationThreshold)
+	return centroids, nil
+}
+
+// absorb adds the observations summarised by other to mc.
+func (mc *microCluster) absorb(other *microCluster) {
+	mc.cf.absorb(&other.cf)
+	mc.lst += other.lst
+	mc.sst += other.sst
+}
+
+// subtract removes the observations summarised by other from mc.
+func (mc *microCluster) subtract(other *microCluster) {
+	mc.cf.n -= other.cf.n
+	for d := range mc.cf.ls {
+		mc.cf.ls[d] -= other.cf.ls[d]
+	}
+	mc.cf.ss -= other.cf.ss
+	mc.lst -= other.lst
+	mc.sst -= other.sst
+}
+
+// relevance estimates when mc last received observations as the mean plus one
+// standard deviation of its timestamps.
+func (mc *microCluster) relevance() float64 {
+	mean := mc.lst / mc.cf.n
+	return mean + math.Sqrt(max(0, mc.sst/mc.cf.n-mean*mean))
+}
+
+// clone returns a deep copy of mc.
+func (mc *microCluster) clone() *microCluster {
+	c := *mc
+	c.cf.ls = slices.Clone(mc.cf.ls)
+	c.ids = slices.Clone(mc.ids)
+	return &c
+}
@@ -0,0 +1,89 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCluStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	s, err := NewCluStream[Vector](20, time.Minute, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The stream moves from one cluster to another after an hour
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	phases := []Vector{{0, 0}, {100, 100}}
+	for minute := range 120 {
+		center := phases[minute/60]
+		batch := make([]Vector, 20)
+		for i := range batch {
+			batch[i] = Vector{center[0] + rng.NormFloat64(), center[1] + rng.NormFloat64()}
+		}
+		if err := s.Add(start.Add(time.Duration(minute)*time.Minute), batch...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The whole history keeps every observation
+	_, weights := s.MicroClusters(0)
+	if total := sum(weights); math.Abs(total-2400) > 1e-6 {
+		t.Errorf("expected a total weight of 2400, got %v", total)
+	}
+
+	centroids, err := s.Cluster(1, 0, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := EuclideanDistance(centroids[0], []float64{50, 50}); d > 1 {
+		t.Errorf("expected centroid close to (50, 50), got %v", centroids[0])
+	}
+
+	// The last half hour only holds the cluster of the second phase
+	_, weights = s.MicroClusters(30 * time.Minute)
+	if total := sum(weights); total < 400 || total > 800 {
+		t.Errorf("expected about 600 recent observations, got %v", total)
+	}
+	centroids, err = s.Cluster(1, 30*time.Minute, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := EuclideanDistance(centroids[0], phases[1]); d > 1 {
+		t.Errorf("expected centroid close to %v, got %v", phases[1], centroids[0])
+	}
+}
+
+func TestCluStreamValidation(t *testing.T) {
+	if _, err := NewCluStream[Numbers](1, time.Minute, time.Hour); err == nil {
+		t.Error("expected error for invalid number of micro-clusters")
+	}
+	if _, err := NewCluStream[Numbers](10, 0, time.Hour); err == nil {
+		t.Error("expected error for invalid interval")
+	}
+	if _, err := NewCluStream[Numbers](10, time.Minute, 0); err == nil {
+		t.Error("expected error for invalid expiry")
+	}
+
+	s, err := NewCluStream[Vector](10, time.Minute, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Add(time.Now(), Vector{1, 2}, Vector{1}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, err := s.Cluster(2, 0, 0.01, 10, rand.New(rand.NewSource(0))); err == nil {
+		t.Error("expected error with fewer micro-clusters than clusters")
+	}
+}
+
+// sum returns the sum of values.
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,103 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ConsensusResult holds the outcome of Consensus.
+type ConsensusResult[T Observation] struct {
+	// Result describes the consensus partition.
+	Result[T]
+	// CoAssociation holds, at CoAssociation[i][j], the fraction of runs that
+	// put observations i and j in the same cluster.
+	CoAssociation [][]float64
+}
+
+// Consensus implements consensus clustering by evidence accumulation (Fred
+// and Jain): it runs ClusterResult runs times with different seeds, counts
+// how often every pair of observations ends up in the same cluster, and
+// extracts k clusters from this co-association matrix by average linkage
+// agglomerative clustering over the distance of one minus the fraction of
+// runs. Pairs that most runs agree on stay together, smoothing out the
+// variations of individual runs on noisy data. With WithSampleSize, every
+// run also clusters its own random sample before labelling all
+// observations, which varies the runs further.
+//
+// The Centroids of the result are the centers of the consensus clusters,
+// computed as in Cluster, and its Iterations the number of runs. Memory
+// grows with the square and time with the cube of the number of
+// observations. The seeds are drawn from rng beforehand, so results do not
+// depend on the number of WithWorkers goroutines the runs are spread over,
+// each run using one. Other options are passed to every run.
+func Consensus[T Observation](dataset []T, k, runs int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*ConsensusResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate runs
+	if runs <= 0 {
+		return nil, fmt.Errorf("invalid number of runs: %d", runs)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// One seed per run, drawn in order
+	seeds := make([]int64, runs)
+	for r := range seeds {
+		seeds[r] = rng.Int63()
+	}
+
+	labels := make([][]int, runs)
+	errs := make([]error, runs)
+	runOpts := append(opts[:len(opts):len(opts)], WithWorkers(1))
+	parallel(runs, cfg.workerCount(), func(_, start, end int) {
+		for r := start; r < end; r++ {
+			labels[r], errs[r] = ClusterLabels(dataset, k, deltaThreshold, iterationThreshold, rand.New(rand.NewSource(seeds[r])), runOpts...)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Co-association of every pair, and the distances derived from it
+	n := len(points)
+	coAssociation, dists := newMatrix(n, n), newMatrix(n, n)
+	parallel(n, cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				together := 0
+				for _, run := range labels {
+					if run[i] == run[j] {
+						together++
+					}
+				}
+				coAssociation[i][j] = float64(together) / float64(runs)
+				dists[i][j] = 1 - coAssociation[i][j]
+			}
+		}
+	})
+
+	hierarchy := &HierarchyResult[T]{
+		Merges:  agglomerate(dists, AverageLinkage),
+		dataset: dataset,
+		points:  points,
+		cfg:     cfg,
+	}
+	result := hierarchy.cut(k)
+	result.Iterations = runs
+	return &ConsensusResult[T]{Result: *result, CoAssociation: coAssociation}, nil
+}
@@ -0,0 +1,80 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestConsensus(t *testing.T) {
+	centers := []Vector{{0, 0}, {20, 0}, {10, 16}, {40, 30}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 30, 3)
+	truth := make([]int, len(dataset))
+	for i := range truth {
+		truth[i] = i / 30
+	}
+
+	// Random seeds often trap single runs in a poor local optimum
+	trapped := 0
+	rng := rand.New(rand.NewSource(0))
+	for range 20 {
+		labels, err := ClusterLabels(dataset, 4, 0.001, 100, rng)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ari, _ := AdjustedRandIndex(labels, truth); ari < 1 {
+			trapped++
+		}
+	}
+	if trapped == 0 {
+		t.Fatal("expected some single runs to be trapped")
+	}
+
+	result, err := Consensus(dataset, 4, 20, 0.001, 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ari, _ := AdjustedRandIndex(result.Labels, truth); ari != 1 {
+		t.Errorf("expected the consensus to recover the clusters, got ARI %f", ari)
+	}
+	if len(result.Centroids) != 4 || result.Iterations != 20 {
+		t.Errorf("expected 4 centroids after 20 runs, got %d after %d", len(result.Centroids), result.Iterations)
+	}
+	for i := range result.CoAssociation {
+		if result.CoAssociation[i][i] != 1 {
+			t.Fatalf("expected observation %d to always be with itself", i)
+		}
+		for j := range i {
+			if result.CoAssociation[i][j] != result.CoAssociation[j][i] {
+				t.Fatalf("expected a symmetric co-association at %d, %d", i, j)
+			}
+		}
+	}
+
+	// Results do not depend on the number of workers
+	again, err := Consensus(dataset, 4, 20, 0.001, 100, rand.New(rand.NewSource(0)), WithWorkers(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range result.Labels {
+		if again.Labels[i] != result.Labels[i] {
+			t.Fatalf("label of observation %d differs between runs", i)
+		}
+	}
+}
+
+func TestConsensusValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Consensus(dataset, 2, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid number of runs")
+	}
+	if _, err := Consensus(dataset, 2, 5, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Consensus(dataset, 7, 5, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Consensus(dataset, 2, 5, 0.01, 10, rng, WithWorkers(-1)); err == nil {
+		t.Error("expected error for invalid number of workers")
+	}
+}
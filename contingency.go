@@ -0,0 +1,104 @@
+package kmeans
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Contingency is the contingency table, or confusion matrix, of a clustering
+// against known classes, telling what each cluster actually contains.
+type Contingency struct {
+	// Clusters holds the distinct cluster labels, in increasing order.
+	Clusters []int
+	// Classes holds the distinct class labels, in increasing order.
+	Classes []int
+	// Counts holds, at Counts[i][j], the number of observations of cluster
+	// Clusters[i] with class Classes[j].
+	Counts [][]int
+}
+
+// NewContingency returns the contingency table of a clustering against
+// known classes, given as labels and truth for the same observations.
+// Labels are compared for equality only, so Noise is a cluster like any
+// other.
+func NewContingency(labels, truth []int) (*Contingency, error) {
+	counts, _, _, err := contingency(labels, truth)
+	if err != nil {
+		return nil, err
+	}
+	table := &Contingency{
+		Clusters: distinctLabels(labels),
+		Classes:  distinctLabels(truth),
+		Counts:   make([][]int, len(counts)),
+	}
+	for i, row := range counts {
+		table.Counts[i] = make([]int, len(row))
+		for j, c := range row {
+			table.Counts[i][j] = int(c)
+		}
+	}
+	return table, nil
+}
+
+// Purity returns the purity of a clustering against known classes, given as
+// labels and truth for the same observations: the proportion of
+// observations belonging to the most frequent class of their cluster. It
+// ranges from 0 to 1 and is 1 when every cluster holds a single class, which
+// singletons trivially do, so it only compares clusterings with similar
+// numbers of clusters.
+func Purity(labels, truth []int) (float64, error) {
+	counts, _, _, err := contingency(labels, truth)
+	if err != nil {
+		return 0, err
+	}
+	majority := 0.0
+	for _, row := range counts {
+		majority += slices.Max(row)
+	}
+	return majority / float64(len(labels)), nil
+}
+
+// contingency returns the contingency table of two labelings of the same
+// observations, counts[i][j] being the number of observations with the i-th
+// distinct label of a and the j-th distinct label of b, distinct labels
+// being taken in increasing order, along with the row and column totals. It
+// fails if the labelings are empty or of different lengths.
+func contingency(a, b []int) ([][]float64, []float64, []float64, error) {
+	// Validate labelings
+	if len(a) == 0 {
+		return nil, nil, nil, fmt.Errorf("labels are empty")
+	}
+	if len(a) != len(b) {
+		return nil, nil, nil, fmt.Errorf("expected %d labels, got %d", len(a), len(b))
+	}
+
+	rowOf, colOf := denseLabels(a), denseLabels(b)
+	counts := make([][]float64, len(rowOf))
+	for i := range counts {
+		counts[i] = make([]float64, len(colOf))
+	}
+	rows, cols := make([]float64, len(rowOf)), make([]float64, len(colOf))
+	for i := range a {
+		r, c := rowOf[a[i]], colOf[b[i]]
+		counts[r][c]++
+		rows[r]++
+		cols[c]++
+	}
+	return counts, rows, cols, nil
+}
+
+// distinctLabels returns the distinct labels of labels in increasing order.
+func distinctLabels(labels []int) []int {
+	return slices.Compact(slices.Sorted(slices.Values(labels)))
+}
+
+// denseLabels maps the distinct labels of labels, in increasing order, to
+// consecutive indices from 0.
+func denseLabels(labels []int) map[int]int {
+	distinct := distinctLabels(labels)
+	index := make(map[int]int, len(distinct))
+	for i, label := range distinct {
+		index[label] = i
+	}
+	return index
+}
@@ -0,0 +1,57 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNewContingency(t *testing.T) {
+	labels := []int{1, 1, 1, Noise, 0, 0}
+	truth := []int{7, 7, 3, 3, 3, 3}
+	table, err := NewContingency(labels, truth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(table.Clusters, []int{Noise, 0, 1}) {
+		t.Errorf("expected clusters [-1 0 1], got %v", table.Clusters)
+	}
+	if !slices.Equal(table.Classes, []int{3, 7}) {
+		t.Errorf("expected classes [3 7], got %v", table.Classes)
+	}
+	want := [][]int{{1, 0}, {2, 0}, {1, 2}}
+	for i := range want {
+		if !slices.Equal(table.Counts[i], want[i]) {
+			t.Errorf("expected row %d to be %v, got %v", i, want[i], table.Counts[i])
+		}
+	}
+}
+
+func TestPurity(t *testing.T) {
+	// Majorities 1, 2 and 2 out of 6
+	purity, err := Purity([]int{1, 1, 1, Noise, 0, 0}, []int{7, 7, 3, 3, 3, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 5.0 / 6; math.Abs(purity-want) > 1e-12 {
+		t.Errorf("expected %f, got %f", want, purity)
+	}
+
+	// Singletons are trivially pure
+	purity, err = Purity([]int{0, 1, 2}, []int{0, 0, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purity != 1 {
+		t.Errorf("expected 1 for singletons, got %f", purity)
+	}
+}
+
+func TestContingencyValidation(t *testing.T) {
+	if _, err := NewContingency(nil, nil); err == nil {
+		t.Error("expected error for empty labels")
+	}
+	if _, err := Purity([]int{0, 1}, []int{0}); err == nil {
+		t.Error("expected error for labels of different lengths")
+	}
+}
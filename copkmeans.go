@@ -0,0 +1,184 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// COPKMeans implements COP-k-means, Wagstaff et al.'s k-means under pairwise
+// constraints. mustLink lists pairs of indices of observations that must
+// share a cluster and cannotLink pairs that must not. Observations joined by
+// must-links, directly or through others, move together as one group. Each
+// iteration visits the groups in input order and assigns each one to the
+// centroid of least total loss that puts it in no cluster with a group it
+// cannot link to, then moves every centroid to the center of its cluster. It
+// iterates until no centroid moves by deltaThreshold or more or
+// iterationThreshold iterations ran.
+//
+// It fails if the constraints contradict each other, or if a group finds no
+// cluster allowed, which can happen with many cannot-links even when the
+// constraints can be met. Initial centroids are chosen as in Cluster. It
+// honours WithDistance and WithCenter.
+func COPKMeans[T Observation](dataset []T, k int, mustLink, cannotLink [][2]int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate constraints
+	for _, pair := range slices.Concat(mustLink, cannotLink) {
+		if pair[0] < 0 || pair[0] >= len(dataset) || pair[1] < 0 || pair[1] >= len(dataset) || pair[0] == pair[1] {
+			return nil, fmt.Errorf("invalid constraint: %v", pair)
+		}
+	}
+	groups, members := linkGroups(len(dataset), mustLink)
+	conflicts := make([][]int, len(members))
+	for _, pair := range cannotLink {
+		a, b := groups[pair[0]], groups[pair[1]]
+		if a == b {
+			return nil, fmt.Errorf("contradictory constraints on %v", pair)
+		}
+		conflicts[a] = append(conflicts[a], b)
+		conflicts[b] = append(conflicts[b], a)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCentroids(cfg.centroids, k, len(points[0])); err != nil {
+		return nil, err
+	}
+
+	loss := cfg.lossFunc()
+	centroids := initCentroids(points, k, cfg, rng)
+	labels := make([]int, len(points))
+	groupLabels := make([]int, len(members))
+	iterations, converged := 0, false
+	for range iterationThreshold {
+		iterations++
+
+		// Assignment step: place every group in its best allowed cluster
+		for g := range groupLabels {
+			groupLabels[g] = -1
+		}
+		for g, group := range members {
+			best, bestLoss := -1, math.Inf(1)
+			for j, centroid := range centroids {
+				allowed := true
+				for _, other := range conflicts[g] {
+					if groupLabels[other] == j {
+						allowed = false
+						break
+					}
+				}
+				if !allowed {
+					continue
+				}
+				total := 0.0
+				for _, i := range group {
+					total += loss(points[i], centroid)
+				}
+				if total < bestLoss {
+					best, bestLoss = j, total
+				}
+			}
+			if best == -1 {
+				return nil, fmt.Errorf("cannot satisfy the cannot-link constraints of observation %d", group[0])
+			}
+			groupLabels[g] = best
+			for _, i := range group {
+				labels[i] = best
+			}
+		}
+
+		// Update step: calculate new centroids
+		stats := NewStats(k, len(points[0]))
+		for i, j := range labels {
+			stats.add(points[i], j)
+		}
+		newCentroids := update(points, labels, centroids, stats, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	result := newResult(dataset, points, centroids, labels, nil, loss)
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
+
+// linkGroups returns the group of each of n observations joined by links,
+// directly or transitively, and the members of each group. Groups are
+// numbered in order of their first member.
+func linkGroups(n int, links [][2]int) ([]int, [][]int) {
+	parents := make([]int, n)
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	for _, link := range links {
+		a, b := find(link[0]), find(link[1])
+		parents[max(a, b)] = min(a, b)
+	}
+
+	groups := make([]int, n)
+	var members [][]int
+	ids := make(map[int]int)
+	for i := range n {
+		root := find(i)
+		id, ok := ids[root]
+		if !ok {
+			id = len(members)
+			ids[root] = id
+			members = append(members, nil)
+		}
+		groups[i] = id
+		members[id] = append(members[id], i)
+	}
+	return groups, members
+}
@@ -0,0 +1,98 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestCOPKMeans(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+
+	// Must-links pull 3 to the right and cannot-links push 11 to the left
+	mustLink := [][2]int{{2, 3}, {3, 4}}
+	cannotLink := [][2]int{{1, 2}}
+	result, err := COPKMeans(dataset, 2, mustLink, cannotLink, 0.01, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2}, {3, 11, 12, 13}})
+	if !result.Converged {
+		t.Error("expected convergence")
+	}
+
+	// Every constraint holds on random data
+	rng := rand.New(rand.NewSource(0))
+	blobs := gaussians(rng, []Vector{{0, 0}, {20, 0}, {0, 20}}, 30, 5)
+	mustLink, cannotLink = nil, nil
+	for range 10 {
+		if a, b := rng.Intn(90), rng.Intn(90); a != b {
+			mustLink = append(mustLink, [2]int{a, b})
+		}
+	}
+	groups, _ := linkGroups(90, mustLink)
+	for range 10 {
+		if a, b := rng.Intn(90), rng.Intn(90); groups[a] != groups[b] {
+			cannotLink = append(cannotLink, [2]int{a, b})
+		}
+	}
+	constrained, err := COPKMeans(blobs, 3, mustLink, cannotLink, 0.01, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, pair := range mustLink {
+		if constrained.Labels[pair[0]] != constrained.Labels[pair[1]] {
+			t.Errorf("must-link %v violated", pair)
+		}
+	}
+	for _, pair := range cannotLink {
+		if constrained.Labels[pair[0]] == constrained.Labels[pair[1]] {
+			t.Errorf("cannot-link %v violated", pair)
+		}
+	}
+}
+
+func TestLinkGroups(t *testing.T) {
+	groups, members := linkGroups(6, [][2]int{{4, 1}, {5, 3}, {1, 5}})
+	if !slices.Equal(groups, []int{0, 1, 2, 1, 1, 1}) {
+		t.Errorf("unexpected groups %v", groups)
+	}
+	if !slices.Equal(members[1], []int{1, 3, 4, 5}) {
+		t.Errorf("unexpected members %v", members)
+	}
+}
+
+func TestCOPKMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := COPKMeans([]Numbers{}, 2, nil, nil, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := COPKMeans(dataset, 7, nil, nil, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := COPKMeans(dataset, 2, nil, nil, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := COPKMeans(dataset, 2, nil, nil, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := COPKMeans(dataset, 2, nil, nil, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := COPKMeans(dataset, 2, [][2]int{{0, 6}}, nil, 0.01, 10, rng); err == nil {
+		t.Error("expected error for out of range constraint")
+	}
+	if _, err := COPKMeans(dataset, 2, nil, [][2]int{{1, 1}}, 0.01, 10, rng); err == nil {
+		t.Error("expected error for self constraint")
+	}
+	if _, err := COPKMeans(dataset, 2, [][2]int{{0, 1}, {1, 2}}, [][2]int{{0, 2}}, 0.01, 10, rng); err == nil {
+		t.Error("expected error for contradictory constraints")
+	}
+	if _, err := COPKMeans(dataset, 2, nil, [][2]int{{0, 1}, {1, 2}, {0, 2}}, 0.01, 10, rng); err == nil {
+		t.Error("expected error for unsatisfiable cannot-links")
+	}
+	if _, err := COPKMeans(dataset, 2, nil, nil, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
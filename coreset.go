@@ -0,0 +1,79 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Weighted pairs an observation with a weight. It implements
+// WeightedObservation, so a slice of Weighted observations such as a coreset
+// can be passed to Cluster directly.
+type Weighted[T Observation] struct {
+	// Observation is the original observation.
+	Observation T
+	// W is the weight of the observation.
+	W float64
+}
+
+// Coordinates returns the coordinates of the observation.
+func (w Weighted[T]) Coordinates() []float64 {
+	return w.Observation.Coordinates()
+}
+
+// Weight returns the weight of the observation.
+func (w Weighted[T]) Weight() float64 {
+	return w.W
+}
+
+// Coreset samples size observations of dataset, with replacement, and weights
+// them so that the weighted inertia of any set of centroids on the coreset
+// estimates their inertia on the whole dataset (lightweight coreset).
+// Observations far from the dataset mean are sampled more often and weighted
+// less. Clustering the coreset approximates clustering the full dataset at a
+// fraction of the cost.
+func Coreset[T Observation](dataset []T, size int, rng *rand.Rand) ([]Weighted[T], error) {
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate size
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid coreset size: %d", size)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mix uniform sampling with sampling by squared distance to the mean
+	m := mean(points)
+	dists := make([]float64, len(points))
+	total := 0.0
+	for i, p := range points {
+		dists[i] = squaredDistance(p, m)
+		total += dists[i]
+	}
+	probabilities := make([]float64, len(points))
+	for i := range points {
+		probabilities[i] = 0.5 / float64(len(points))
+		if total > 0 {
+			probabilities[i] += 0.5 * dists[i] / total
+		} else {
+			probabilities[i] *= 2
+		}
+	}
+
+	coreset := make([]Weighted[T], size)
+	for c := range coreset {
+		i := sampleIndex(probabilities, rng)
+		coreset[c] = Weighted[T]{Observation: dataset[i], W: 1 / (float64(size) * probabilities[i])}
+	}
+	return coreset, nil
+}
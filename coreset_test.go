@@ -0,0 +1,55 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCoreset(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20000, 5, 2)
+	coreset, err := Coreset(dataset, 1000, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The weights estimate the size of the dataset
+	total := 0.0
+	for _, w := range coreset {
+		total += w.Weight()
+	}
+	if math.Abs(total-20000) > 2000 {
+		t.Errorf("expected a total weight close to 20000, got %v", total)
+	}
+
+	// Clustering the coreset is close to clustering the whole dataset
+	result, err := ClusterResult(coreset, 5, 1e-6, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inertia := 0.0
+	for _, obs := range dataset {
+		_, d := nearest(obs, result.Centroids, EuclideanDistance)
+		inertia += d * d
+	}
+	if inertia > 1.05*expected.Inertia {
+		t.Errorf("expected inertia close to %v, got %v", expected.Inertia, inertia)
+	}
+}
+
+func TestCoresetValidation(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Coreset([]Numbers{}, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Coreset([]Numbers{1, 2}, 0, rng); err == nil {
+		t.Error("expected error for invalid size")
+	}
+	if _, err := Coreset([]Numbers{1, 2}, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+}
@@ -0,0 +1,115 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// BIC returns the Bayesian Information Criterion of clusters around their
+// centroids, the j-th centroid being the center of the j-th cluster, as in a
+// Result. Each cluster is modelled as a spherical Gaussian around its
+// centroid with a variance shared by all clusters and a prior proportional to
+// its size, as X-means does. The criterion is the log-likelihood of the
+// observations minus half the number of free parameters times the log of the
+// number of observations; higher is better, so solutions with different k
+// compare directly. Empty clusters are ignored.
+func BIC[T Observation](clusters [][]T, centroids [][]float64) (float64, error) {
+	points, kept, labels, err := flattenModel(clusters, centroids)
+	if err != nil {
+		return 0, err
+	}
+	return bic(points, kept, labels), nil
+}
+
+// AIC returns the Akaike Information Criterion of clusters around their
+// centroids under the model of BIC: the log-likelihood of the observations
+// minus the number of free parameters. Higher is better. It penalizes
+// additional clusters less than BIC on large datasets. Empty clusters are
+// ignored.
+func AIC[T Observation](clusters [][]T, centroids [][]float64) (float64, error) {
+	points, kept, labels, err := flattenModel(clusters, centroids)
+	if err != nil {
+		return 0, err
+	}
+	logLikelihood, parameters := sphericalLikelihood(points, kept, labels)
+	return logLikelihood - parameters, nil
+}
+
+// flattenModel returns the coordinates of the observations of clusters, the
+// centroids of the non-empty clusters and the index of the centroid of each
+// observation among them. It fails if there is no observation, if centroids
+// does not hold one centroid per cluster or if dimensions differ.
+func flattenModel[T Observation](clusters [][]T, centroids [][]float64) ([][]float64, [][]float64, []int, error) {
+	// Validate centroids
+	if len(centroids) != len(clusters) {
+		return nil, nil, nil, fmt.Errorf("expected %d centroids, got %d", len(clusters), len(centroids))
+	}
+
+	var dataset []T
+	var kept [][]float64
+	var labels []int
+	for j, cluster := range clusters {
+		if len(cluster) == 0 {
+			continue
+		}
+		for range cluster {
+			labels = append(labels, len(kept))
+		}
+		dataset = append(dataset, cluster...)
+		kept = append(kept, centroids[j])
+	}
+	if len(dataset) == 0 {
+		return nil, nil, nil, fmt.Errorf("dataset is empty")
+	}
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, centroid := range kept {
+		if len(centroid) != len(points[0]) {
+			return nil, nil, nil, fmt.Errorf("inconsistent dimensions")
+		}
+	}
+	return points, kept, labels, nil
+}
+
+// bic returns the Bayesian Information Criterion of a clustering of points
+// under an identical spherical Gaussian model per cluster, as used by
+// X-means: the log-likelihood of the points minus half the number of free
+// parameters times the log of the number of points. Higher is better.
+func bic(points, centroids [][]float64, labels []int) float64 {
+	logLikelihood, parameters := sphericalLikelihood(points, centroids, labels)
+	return logLikelihood - parameters/2*math.Log(float64(len(points)))
+}
+
+// sphericalLikelihood returns the log-likelihood of a clustering of points
+// under an identical spherical Gaussian model per cluster, with the maximum
+// likelihood estimate of the shared variance and cluster priors proportional
+// to their sizes, and the number of free parameters of the model: the priors,
+// the centroids and the variance.
+func sphericalLikelihood(points, centroids [][]float64, labels []int) (float64, float64) {
+	n, k, dim := float64(len(points)), float64(len(centroids)), float64(len(points[0]))
+
+	sizes := make([]float64, len(centroids))
+	sse := 0.0
+	for i, j := range labels {
+		sizes[j]++
+		sse += squaredDistance(points[i], centroids[j])
+	}
+
+	// Maximum likelihood estimate of the shared variance
+	variance := sse / max(1, n-k) / dim
+	if variance <= 0 {
+		variance = math.SmallestNonzeroFloat64
+	}
+
+	logLikelihood := -n*dim/2*math.Log(2*math.Pi*variance) - sse/(2*variance)
+	for _, size := range sizes {
+		if size > 0 {
+			logLikelihood += size * math.Log(size/n)
+		}
+	}
+
+	parameters := (k - 1) + k*dim + 1
+	return logLikelihood, parameters
+}
@@ -0,0 +1,86 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBIC(t *testing.T) {
+	// Two clusters of 2 points at distance 1 from their centroids: sse 4,
+	// variance 4/(4-2) = 2, 1+2+1 = 4 parameters
+	clusters := [][]Numbers{{0, 2}, {10, 12}}
+	centroids := [][]float64{{1}, {11}}
+	logLikelihood := -2*math.Log(2*math.Pi*2) - 1 + 4*math.Log(0.5)
+
+	score, err := BIC(clusters, centroids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := logLikelihood - 2*math.Log(4); math.Abs(score-want) > 1e-9 {
+		t.Errorf("expected BIC %f, got %f", want, score)
+	}
+
+	score, err = AIC(clusters, centroids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := logLikelihood - 4; math.Abs(score-want) > 1e-9 {
+		t.Errorf("expected AIC %f, got %f", want, score)
+	}
+
+	// Empty clusters are ignored
+	again, err := AIC([][]Numbers{{0, 2}, {}, {10, 12}}, [][]float64{{1}, {5}, {11}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != score {
+		t.Errorf("expected AIC %f ignoring the empty cluster, got %f", score, again)
+	}
+}
+
+func TestBICRanksK(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	bestBIC, bestAIC := 0, 0
+	maxBIC, maxAIC := math.Inf(-1), math.Inf(-1)
+	for k := 1; k <= 6; k++ {
+		result, err := ClusterResult(dataset, k, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := BIC(result.Clusters, result.Centroids)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a, err := AIC(result.Clusters, result.Centroids)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b > maxBIC {
+			bestBIC, maxBIC = k, b
+		}
+		if a > maxAIC {
+			bestAIC, maxAIC = k, a
+		}
+	}
+	if bestBIC != len(centers) {
+		t.Errorf("expected the highest BIC with %d clusters, got %d", len(centers), bestBIC)
+	}
+	if bestAIC != len(centers) {
+		t.Errorf("expected the highest AIC with %d clusters, got %d", len(centers), bestAIC)
+	}
+}
+
+func TestBICValidation(t *testing.T) {
+	if _, err := BIC([][]Numbers{{}}, [][]float64{{0}}); err == nil {
+		t.Error("expected error for no observation")
+	}
+	if _, err := BIC([][]Numbers{{1}, {2}}, [][]float64{{1}}); err == nil {
+		t.Error("expected error for missing centroids")
+	}
+	if _, err := AIC([][]Numbers{{1}, {2}}, [][]float64{{1}, {2, 0}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
@@ -0,0 +1,67 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// DaviesBouldin returns the Davies-Bouldin index of clusters around their
+// centroids, the j-th centroid being the center of the j-th cluster, as in a
+// Result. The scatter of a cluster is the mean distance of its observations to
+// its centroid; the index is the mean, over clusters, of the largest ratio of
+// the summed scatters of the cluster and another one to the distance between
+// their centroids. It is 0 or more, lower when clusters are compact and well
+// separated, and infinite when two centroids coincide. Empty clusters are
+// ignored and at least two clusters must remain.
+//
+// Distances are measured with the configured distance.
+func DaviesBouldin[T Observation](clusters [][]T, centroids [][]float64, opts ...Option) (float64, error) {
+	cfg := newConfig(opts)
+	points, labels, err := flattenClusters(clusters)
+	if err != nil {
+		return 0, err
+	}
+
+	// Validate centroids
+	if len(centroids) != len(clusters) {
+		return 0, fmt.Errorf("expected %d centroids, got %d", len(clusters), len(centroids))
+	}
+	for j, centroid := range centroids {
+		if len(clusters[j]) > 0 && len(centroid) != len(points[0]) {
+			return 0, fmt.Errorf("inconsistent dimensions")
+		}
+	}
+
+	// Scatter of every cluster
+	distance := cfg.distanceFunc()
+	scatters := make([]float64, len(clusters))
+	for i, j := range labels {
+		scatters[j] += distance(points[i], centroids[j])
+	}
+	for j, cluster := range clusters {
+		if len(cluster) > 0 {
+			scatters[j] /= float64(len(cluster))
+		}
+	}
+
+	index, nonEmpty := 0.0, 0
+	for i := range clusters {
+		if len(clusters[i]) == 0 {
+			continue
+		}
+		nonEmpty++
+		worst := 0.0
+		for j := range clusters {
+			if j == i || len(clusters[j]) == 0 {
+				continue
+			}
+			separation := distance(centroids[i], centroids[j])
+			if separation == 0 {
+				return math.Inf(1), nil
+			}
+			worst = max(worst, (scatters[i]+scatters[j])/separation)
+		}
+		index += worst
+	}
+	return index / float64(nonEmpty), nil
+}
@@ -0,0 +1,74 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDaviesBouldin(t *testing.T) {
+	clusters := [][]Numbers{{0, 2}, {10, 14}}
+	centroids := [][]float64{{1}, {12}}
+
+	// Scatters 1 and 2, separation 11
+	index, err := DaviesBouldin(clusters, centroids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 3.0 / 11; math.Abs(index-want) > 1e-12 {
+		t.Errorf("expected index %f, got %f", want, index)
+	}
+
+	// Empty clusters are ignored
+	index, err = DaviesBouldin([][]Numbers{{0, 2}, {}, {10, 14}}, [][]float64{{1}, {5}, {12}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 3.0 / 11; math.Abs(index-want) > 1e-12 {
+		t.Errorf("expected index %f ignoring the empty cluster, got %f", want, index)
+	}
+
+	// Coinciding centroids
+	index, err = DaviesBouldin(clusters, [][]float64{{5}, {5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(index, 1) {
+		t.Errorf("expected an infinite index for coinciding centroids, got %f", index)
+	}
+}
+
+func TestDaviesBouldinRanksK(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	best, bestIndex := 0, math.Inf(1)
+	for k := 2; k <= 6; k++ {
+		result, err := ClusterResult(dataset, k, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		index, err := DaviesBouldin(result.Clusters, result.Centroids)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if index < bestIndex {
+			best, bestIndex = k, index
+		}
+	}
+	if best != len(centers) {
+		t.Errorf("expected the lowest index with %d clusters, got %d", len(centers), best)
+	}
+}
+
+func TestDaviesBouldinValidation(t *testing.T) {
+	if _, err := DaviesBouldin([][]Numbers{{1, 2}}, [][]float64{{1.5}}); err == nil {
+		t.Error("expected error for a single cluster")
+	}
+	if _, err := DaviesBouldin([][]Numbers{{1}, {2}}, [][]float64{{1}}); err == nil {
+		t.Error("expected error for missing centroids")
+	}
+	if _, err := DaviesBouldin([][]Numbers{{1}, {2}}, [][]float64{{1}, {2, 0}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
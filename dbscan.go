@@ -0,0 +1,118 @@
+package kmeans
+
+import "fmt"
+
+// Noise is the label of observations that density-based methods leave out of
+// every cluster.
+const Noise = -1
+
+// DensityResult holds the outcome of a density-based clustering run, which
+// finds the number of clusters itself and may leave observations out.
+type DensityResult[T Observation] struct {
+	// Clusters groups the observations by cluster.
+	Clusters [][]T
+	// Noise holds the observations that belong to no cluster.
+	Noise []T
+	// Labels holds the cluster index of each observation, in input order, or
+	// Noise.
+	Labels []int
+	// OutlierScores holds, for each observation in input order, how much of
+	// an outlier it is, for methods scoring them such as HDBSCAN, or nil.
+	OutlierScores []float64
+}
+
+// newDensityResult groups dataset by labels, collecting observations labelled
+// Noise apart.
+func newDensityResult[T Observation](dataset []T, labels []int, k int) *DensityResult[T] {
+	result := &DensityResult[T]{
+		Clusters: make([][]T, k),
+		Labels:   labels,
+	}
+	for i, obs := range dataset {
+		if labels[i] == Noise {
+			result.Noise = append(result.Noise, obs)
+		} else {
+			result.Clusters[labels[i]] = append(result.Clusters[labels[i]], obs)
+		}
+	}
+	return result
+}
+
+// DBSCAN implements Density-Based Spatial Clustering of Applications with
+// Noise. An observation with at least minPts observations, itself included,
+// within distance eps is a core observation. Clusters are the groups of core
+// observations reachable from each other through core observations within
+// eps, together with the observations within eps of them. Other observations
+// are noise. The number of clusters follows from the data and clusters may
+// have any shape.
+//
+// Neighbourhoods are found by comparing every pair of observations with the
+// configured distance, so time grows with the square of the number of
+// observations.
+func DBSCAN[T Observation](dataset []T, eps float64, minPts int, opts ...Option) (*DensityResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate eps and minPts
+	if !(eps > 0) {
+		return nil, fmt.Errorf("invalid eps: %f", eps)
+	}
+	if minPts <= 0 {
+		return nil, fmt.Errorf("invalid minimum number of points: %d", minPts)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	distance := cfg.distanceFunc()
+	neighbors := func(i int) []int {
+		var found []int
+		for j, q := range points {
+			if distance(points[i], q) <= eps {
+				found = append(found, j)
+			}
+		}
+		return found
+	}
+
+	const unvisited = -2
+	labels := make([]int, len(points))
+	for i := range labels {
+		labels[i] = unvisited
+	}
+	k := 0
+	for i := range points {
+		if labels[i] != unvisited {
+			continue
+		}
+		seeds := neighbors(i)
+		if len(seeds) < minPts {
+			labels[i] = Noise
+			continue
+		}
+
+		// Expand a new cluster from the core observation i
+		labels[i] = k
+		for len(seeds) > 0 {
+			j := seeds[len(seeds)-1]
+			seeds = seeds[:len(seeds)-1]
+			switch labels[j] {
+			case Noise:
+				// A border observation of the cluster
+				labels[j] = k
+			case unvisited:
+				labels[j] = k
+				if found := neighbors(j); len(found) >= minPts {
+					seeds = append(seeds, found...)
+				}
+			}
+		}
+		k++
+	}
+	return newDensityResult(dataset, labels, k), nil
+}
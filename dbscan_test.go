@@ -0,0 +1,56 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDBSCAN(t *testing.T) {
+	// Two rings, which k-means cannot separate, and two isolated observations
+	dataset := append(rings(50), Vector{20, 20}, Vector{-20, 0})
+
+	result, err := DBSCAN(dataset, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Clusters) != 2 || len(result.Clusters[0]) != 50 || len(result.Clusters[1]) != 50 {
+		t.Fatalf("expected the two rings as clusters, got %v", result.Labels)
+	}
+	if len(result.Noise) != 2 || result.Labels[100] != Noise || result.Labels[101] != Noise {
+		t.Errorf("expected the isolated observations as noise, got %v", result.Noise)
+	}
+}
+
+func TestDBSCANBorder(t *testing.T) {
+	// 3 is within eps of the core observation 2 but is not a core observation
+	dataset := []Numbers{0, 1, 2, 3}
+	result, err := DBSCAN(dataset, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{0, 1, 2, 3}})
+}
+
+func TestDBSCANNoise(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20, 20, 2)
+	result, err := DBSCAN(dataset, 0.001, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Clusters) != 0 || len(result.Noise) != len(dataset) {
+		t.Errorf("expected only noise, got %d clusters", len(result.Clusters))
+	}
+}
+
+func TestDBSCANValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := DBSCAN([]Numbers{}, 1, 2); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := DBSCAN(dataset, 0, 2); err == nil {
+		t.Error("expected error for invalid eps")
+	}
+	if _, err := DBSCAN(dataset, 1, 0); err == nil {
+		t.Error("expected error for invalid minPts")
+	}
+}
@@ -0,0 +1,193 @@
+package kmeans
+
+import "math"
+
+// DistanceFunc measures the distance between two coordinate slices of the
+// same dimension.
+type DistanceFunc func(a, b []float64) float64
+
+// EuclideanDistance calculates the Euclidean distance between two coordinate slices.
+// It is the default distance.
+func EuclideanDistance(a, b []float64) float64 {
+	return math.Sqrt(squaredDistance(a, b))
+}
+
+// ManhattanDistance calculates the Manhattan (L1) distance between two coordinate slices.
+func ManhattanDistance(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	sum := 0.0
+	for i := range a {
+		sum += math.Abs(a[i] - b[i])
+	}
+	return sum
+}
+
+// ChebyshevDistance calculates the Chebyshev (L∞) distance between two
+// coordinate slices, the largest absolute difference along any dimension.
+func ChebyshevDistance(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	maxDiff := 0.0
+	for i := range a {
+		maxDiff = max(maxDiff, math.Abs(a[i]-b[i]))
+	}
+	return maxDiff
+}
+
+// MinkowskiDistance returns the Minkowski distance of order p, which is the
+// Manhattan distance for p = 1, the Euclidean distance for p = 2 and the
+// Chebyshev distance for p = +Inf. Orders below 1 give fractional distances
+// that can suit high-dimensional data. It panics if p is not positive.
+func MinkowskiDistance(p float64) DistanceFunc {
+	switch {
+	case !(p > 0):
+		panic("invalid Minkowski order")
+	case p == 1:
+		return ManhattanDistance
+	case p == 2:
+		return EuclideanDistance
+	case math.IsInf(p, 1):
+		return ChebyshevDistance
+	}
+	return func(a, b []float64) float64 {
+		if len(a) != len(b) {
+			panic("dimensions mismatch")
+		}
+		sum := 0.0
+		for i := range a {
+			sum += math.Pow(math.Abs(a[i]-b[i]), p)
+		}
+		return math.Pow(sum, 1/p)
+	}
+}
+
+// CosineDistance calculates one minus the cosine similarity of two coordinate
+// slices. It is 1 when either slice is the zero vector.
+func CosineDistance(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 1
+	}
+	return 1 - dot/math.Sqrt(normA*normB)
+}
+
+// CanberraDistance calculates the Canberra distance between two coordinate
+// slices, the sum of |a-b| / (|a|+|b|) over every dimension. Dimensions where
+// both values are zero contribute nothing.
+func CanberraDistance(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	sum := 0.0
+	for i := range a {
+		denominator := math.Abs(a[i]) + math.Abs(b[i])
+		if denominator == 0 {
+			continue
+		}
+		sum += math.Abs(a[i]-b[i]) / denominator
+	}
+	return sum
+}
+
+// HammingDistance counts the dimensions in which two coordinate slices differ.
+// Pair it with MajorityCenter to cluster binary feature vectors.
+func HammingDistance(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	count := 0
+	for i := range a {
+		if a[i] != b[i] {
+			count++
+		}
+	}
+	return float64(count)
+}
+
+// KLDivergence calculates the Kullback-Leibler divergence of a from b, the
+// Bregman divergence of the negative entropy. It expects non-negative
+// coordinates and uses the generalized form sum(a log(a/b) - a + b), which is
+// the usual KL divergence when both slices are probability vectors. Use it
+// with WithDivergence.
+func KLDivergence(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	sum := 0.0
+	for i := range a {
+		if a[i] > 0 {
+			sum += a[i] * math.Log(a[i]/b[i])
+		}
+		sum += b[i] - a[i]
+	}
+	return sum
+}
+
+// ItakuraSaitoDivergence calculates the Itakura-Saito divergence of a from b,
+// sum(a/b - log(a/b) - 1), commonly used to compare power spectra. It expects
+// positive coordinates. Use it with WithDivergence.
+func ItakuraSaitoDivergence(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	sum := 0.0
+	for i := range a {
+		ratio := a[i] / b[i]
+		sum += ratio - math.Log(ratio) - 1
+	}
+	return sum
+}
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0088
+
+// HaversineDistance calculates the great-circle distance in kilometers between
+// two points given as latitude and longitude in degrees. Pair it with
+// HaversineCenter so centroids are updated on the sphere too.
+func HaversineDistance(a, b []float64) float64 {
+	if len(a) != 2 || len(b) != 2 {
+		panic("haversine distance requires latitude and longitude")
+	}
+	lat1, lon1 := a[0]*math.Pi/180, a[1]*math.Pi/180
+	lat2, lon2 := b[0]*math.Pi/180, b[1]*math.Pi/180
+	sinLat := math.Sin((lat2 - lat1) / 2)
+	sinLon := math.Sin((lon2 - lon1) / 2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+	return 2 * earthRadius * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// squaredDistance calculates the squared Euclidean distance between two
+// coordinate slices. Comparing squared distances gives the same nearest
+// centroid as comparing distances, without the square root.
+func squaredDistance(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	return squaredDistanceKernel(a, b)
+}
+
+// nearest returns the index of the centroid closest to point according to
+// distance, and that distance.
+func nearest(point []float64, centroids [][]float64, distance DistanceFunc) (int, float64) {
+	minDist := math.Inf(1) // Positive infinity as initial distance
+	minIndex := -1
+	for j := range centroids {
+		dist := distance(point, centroids[j])
+		if dist < minDist {
+			minDist = dist
+			minIndex = j
+		}
+	}
+	return minIndex, minDist
+}
@@ -0,0 +1,200 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClusterWithDistance(t *testing.T) {
+	dataset := []Coordinates{{0, 0}, {100, 1}, {0, 10}, {100, 11}}
+	initial := [][]float64{{0, 0}, {100, 10}}
+
+	// Euclidean distance is dominated by the first coordinate
+	clusters, err := Cluster(dataset, 2, 0.01, 100, nil, WithCentroids(initial))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, clusters, [][]Coordinates{{{0, 0}, {0, 10}}, {{100, 1}, {100, 11}}})
+
+	// A distance ignoring the first coordinate groups by the second one
+	vertical := func(a, b []float64) float64 {
+		return math.Abs(a[1] - b[1])
+	}
+	clusters, err = Cluster(dataset, 2, 0.01, 100, nil, WithCentroids(initial), WithDistance(vertical))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, clusters, [][]Coordinates{{{0, 0}, {100, 1}}, {{0, 10}, {100, 11}}})
+}
+
+func TestManhattanDistance(t *testing.T) {
+	if d := ManhattanDistance([]float64{1, 2, 3}, []float64{4, 0, 3}); d != 5 {
+		t.Errorf("expected 5, got %v", d)
+	}
+
+	// L1 and L2 disagree on which centroid is closest to the origin
+	centroids := [][]float64{{3, 3}, {0, 5}}
+	if j, _ := nearest([]float64{0, 0}, centroids, EuclideanDistance); j != 0 {
+		t.Errorf("expected Euclidean nearest 0, got %d", j)
+	}
+	if j, _ := nearest([]float64{0, 0}, centroids, ManhattanDistance); j != 1 {
+		t.Errorf("expected Manhattan nearest 1, got %d", j)
+	}
+}
+
+func TestCosineDistance(t *testing.T) {
+	if d := CosineDistance([]float64{1, 0}, []float64{5, 0}); math.Abs(d) > 1e-12 {
+		t.Errorf("expected 0 for parallel vectors, got %v", d)
+	}
+	if d := CosineDistance([]float64{1, 0}, []float64{0, 2}); math.Abs(d-1) > 1e-12 {
+		t.Errorf("expected 1 for orthogonal vectors, got %v", d)
+	}
+	if d := CosineDistance([]float64{0, 0}, []float64{0, 2}); d != 1 {
+		t.Errorf("expected 1 for zero vector, got %v", d)
+	}
+}
+
+func TestClusterSpherical(t *testing.T) {
+	// Directions matter, magnitudes do not
+	dataset := []Coordinates{
+		{1, 0}, {100, 1}, {50, 2},
+		{0, 1}, {1, 100}, {2, 50},
+	}
+	result, err := ClusterResult(dataset, 2, 1e-6, 100, nil, WithSpherical(), WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Coordinates{
+		{{1, 0}, {100, 1}, {50, 2}},
+		{{0, 1}, {1, 100}, {2, 50}},
+	})
+	for j, centroid := range result.Centroids {
+		if norm := math.Hypot(centroid[0], centroid[1]); math.Abs(norm-1) > 1e-9 {
+			t.Errorf("centroid %d is not unit length: %v", j, centroid)
+		}
+	}
+}
+
+func TestChebyshevDistance(t *testing.T) {
+	if d := ChebyshevDistance([]float64{1, 2, 3}, []float64{4, 0, 3}); d != 3 {
+		t.Errorf("expected 3, got %v", d)
+	}
+
+	// The largest deviation decides, however small the others are
+	centroids := [][]float64{{2, 2, 2}, {0, 0, 3}}
+	if j, _ := nearest([]float64{0, 0, 0}, centroids, ChebyshevDistance); j != 0 {
+		t.Errorf("expected Chebyshev nearest 0, got %d", j)
+	}
+}
+
+func TestMinkowskiDistance(t *testing.T) {
+	a, b := []float64{0, 0}, []float64{3, 4}
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{1, 7},
+		{2, 5},
+		{3, math.Cbrt(27 + 64)},
+		{0.5, math.Pow(math.Sqrt(3)+2, 2)},
+		{math.Inf(1), 4},
+	}
+	for _, test := range tests {
+		if d := MinkowskiDistance(test.p)(a, b); math.Abs(d-test.expected) > 1e-9 {
+			t.Errorf("p = %v: expected %v, got %v", test.p, test.expected, d)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-positive order")
+		}
+	}()
+	MinkowskiDistance(0)
+}
+
+func TestHaversineDistance(t *testing.T) {
+	paris := []float64{48.8566, 2.3522}
+	london := []float64{51.5074, -0.1278}
+	if d := HaversineDistance(paris, london); math.Abs(d-343.5) > 1 {
+		t.Errorf("expected about 343.5 km, got %v", d)
+	}
+
+	// Points on both sides of the antimeridian are close
+	if d := HaversineDistance([]float64{0, 179.5}, []float64{0, -179.5}); math.Abs(d-111.2) > 0.1 {
+		t.Errorf("expected about 111.2 km, got %v", d)
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	if d := HammingDistance([]float64{1, 0, 1, 1}, []float64{1, 1, 0, 1}); d != 2 {
+		t.Errorf("expected 2, got %v", d)
+	}
+}
+
+func TestCanberraDistance(t *testing.T) {
+	// 1/3 + 0 + 1 + 1/3, the zero pair being skipped
+	d := CanberraDistance([]float64{1, 0, 2, -1}, []float64{2, 0, 0, -2})
+	if expected := 1.0/3 + 1 + 1.0/3; math.Abs(d-expected) > 1e-12 {
+		t.Errorf("expected %v, got %v", expected, d)
+	}
+
+	// Relative differences matter: small counts far apart outweigh large close ones
+	centroids := [][]float64{{1, 1000}, {10, 1100}}
+	if j, _ := nearest([]float64{10, 1000}, centroids, CanberraDistance); j != 1 {
+		t.Errorf("expected Canberra nearest 1, got %d", j)
+	}
+}
+
+func TestKLDivergence(t *testing.T) {
+	p, q := []float64{0.5, 0.5, 0}, []float64{0.25, 0.5, 0.25}
+	if d := KLDivergence(p, q); math.Abs(d-0.5*math.Log(2)) > 1e-12 {
+		t.Errorf("expected %v, got %v", 0.5*math.Log(2), d)
+	}
+	if d := KLDivergence(q, q); math.Abs(d) > 1e-12 {
+		t.Errorf("expected 0, got %v", d)
+	}
+}
+
+func TestItakuraSaitoDivergence(t *testing.T) {
+	if d := ItakuraSaitoDivergence([]float64{2, 1}, []float64{1, 1}); math.Abs(d-(1-math.Log(2))) > 1e-12 {
+		t.Errorf("expected %v, got %v", 1-math.Log(2), d)
+	}
+}
+
+type Distribution [3]float64
+
+func (d Distribution) Coordinates() []float64 {
+	return d[:]
+}
+
+func TestClusterWithDivergence(t *testing.T) {
+	dataset := []Distribution{
+		{0.8, 0.1, 0.1}, {0.7, 0.2, 0.1}, {0.9, 0.05, 0.05},
+		{0.1, 0.1, 0.8}, {0.1, 0.2, 0.7}, {0.05, 0.05, 0.9},
+	}
+	result, err := ClusterResult(dataset, 2, 1e-9, 100, nil, WithInit(InitMaximin), WithDivergence(KLDivergence))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Distribution{dataset[:3], dataset[3:]})
+
+	// Inertia is the plain sum of divergences to the mean centroids
+	inertia := 0.0
+	for i, obs := range dataset {
+		inertia += KLDivergence(obs.Coordinates(), result.Centroids[result.Labels[i]])
+	}
+	if math.Abs(result.Inertia-inertia) > 1e-12 {
+		t.Errorf("expected inertia %v, got %v", inertia, result.Inertia)
+	}
+}
+
+func TestSquaredDistance(t *testing.T) {
+	a, b := []float64{1, 2, 3}, []float64{4, 6, 3}
+	if d := squaredDistance(a, b); d != 25 {
+		t.Errorf("expected 25, got %v", d)
+	}
+	if d := EuclideanDistance(a, b); d*d != squaredDistance(a, b) {
+		t.Errorf("squared distance disagrees with Euclidean distance %v", d)
+	}
+}
@@ -0,0 +1,90 @@
+package kmeans
+
+import "fmt"
+
+// PartialAssign runs the assignment step of one k-means iteration over a shard
+// of a dataset: it assigns every observation to its nearest centroid and
+// returns the statistics of the shard. A coordinator merges the statistics of
+// all shards with Stats.Merge and computes the next centroids with Finalize,
+// which makes map-reduce style distributed k-means possible.
+//
+// It honours WithDistance, WithDivergence, WithSpherical, WithWorkers and the
+// weights of weighted observations.
+func PartialAssign[T Observation](shard []T, centroids [][]float64, opts ...Option) (*Stats, error) {
+	cfg := newConfig(opts)
+
+	// Validate centroids
+	if len(centroids) == 0 {
+		return nil, fmt.Errorf("invalid number of clusters: 0")
+	}
+	dim := len(centroids[0])
+	if err := validateCentroids(centroids, len(centroids), dim); err != nil {
+		return nil, err
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// An empty shard contributes nothing
+	if len(shard) == 0 {
+		return NewStats(len(centroids), dim), nil
+	}
+
+	points, err := materialize(shard)
+	if err != nil {
+		return nil, err
+	}
+	if len(points[0]) != dim {
+		return nil, fmt.Errorf("inconsistent dimensions")
+	}
+	weights, err := observationWeights(shard)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.spherical {
+		for _, p := range points {
+			normalize(p)
+		}
+	}
+
+	cfg.algorithm = Lloyd
+	labels := make([]int, len(points))
+	stats := newAssigner(points, cfg).assign(centroids, labels)
+	if weights != nil {
+		stats = weightedStats(points, weights, labels, len(centroids))
+	}
+	return stats, nil
+}
+
+// Finalize runs the update step of a k-means iteration from the statistics of
+// the whole dataset: it returns the mean of each cluster, normalized with
+// WithSpherical, and the largest distance a centroid moved, to compare with a
+// delta threshold. A cluster without observations keeps its centroid.
+func Finalize(stats *Stats, centroids [][]float64, opts ...Option) ([][]float64, float64, error) {
+	cfg := newConfig(opts)
+
+	// Validate statistics against the centroids
+	if len(stats.Sums) != len(centroids) || len(stats.Counts) != len(centroids) {
+		return nil, 0, fmt.Errorf("expected statistics of %d clusters, got %d", len(centroids), len(stats.Sums))
+	}
+	for j := range centroids {
+		if len(stats.Sums[j]) != len(centroids[j]) {
+			return nil, 0, fmt.Errorf("inconsistent dimensions")
+		}
+	}
+
+	// Validate center, statistics only give means
+	if cfg.center != nil {
+		return nil, 0, fmt.Errorf("distributed updates require mean centroids")
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, 0, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	newCentroids := update(nil, nil, centroids, stats, cfg)
+	return newCentroids, maxDrift(centroids, newCentroids), nil
+}
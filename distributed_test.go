@@ -0,0 +1,80 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPartialAssignFinalize(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 3000, 4, 3)
+	initial := [][]float64{dataset[0], dataset[1], dataset[2], dataset[3]}
+	expected, err := ClusterResult(dataset, 4, 1e-9, 100, nil, WithCentroids(initial))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Three shards processed separately, merged by a coordinator
+	centroids := initial
+	iterations := 0
+	for range 100 {
+		iterations++
+		total := NewStats(4, 3)
+		for start := 0; start < len(dataset); start += 1000 {
+			partial, err := PartialAssign(dataset[start:start+1000], centroids)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := total.Merge(partial); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		var drift float64
+		centroids, drift, err = Finalize(total, centroids)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if drift < 1e-9 {
+			break
+		}
+	}
+
+	if iterations != expected.Iterations {
+		t.Errorf("expected %d iterations, got %d", expected.Iterations, iterations)
+	}
+	for j := range centroids {
+		if d := EuclideanDistance(centroids[j], expected.Centroids[j]); d > 1e-9 {
+			t.Errorf("expected centroid %v, got %v", expected.Centroids[j], centroids[j])
+		}
+	}
+}
+
+func TestStatsMerge(t *testing.T) {
+	s := NewStats(2, 1)
+	if err := s.Merge(&Stats{Sums: [][]float64{{1}, {2}}, Counts: []float64{1, 2}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Sums[1][0] != 2 || s.Counts[1] != 2 {
+		t.Errorf("unexpected statistics %v", s)
+	}
+	if err := s.Merge(NewStats(3, 1)); err == nil {
+		t.Error("expected error for a different number of clusters")
+	}
+	if err := s.Merge(NewStats(2, 2)); err == nil {
+		t.Error("expected error for a different dimension")
+	}
+}
+
+func TestPartialAssignValidation(t *testing.T) {
+	if _, err := PartialAssign([]Numbers{1}, nil); err == nil {
+		t.Error("expected error for missing centroids")
+	}
+	if _, err := PartialAssign([]Numbers{1}, [][]float64{{1, 2}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, _, err := Finalize(NewStats(2, 1), [][]float64{{1}}); err == nil {
+		t.Error("expected error for statistics of another number of clusters")
+	}
+	if _, _, err := Finalize(NewStats(1, 1), [][]float64{{1}}, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
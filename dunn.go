@@ -0,0 +1,81 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Dunn returns the Dunn index of clusters: the smallest distance between two
+// observations of different clusters over the largest distance between two
+// observations of the same cluster. Higher is better, and it is infinite when
+// every cluster collapses to a point. Empty clusters are ignored and at least
+// two clusters must remain.
+//
+// Every pair of observations is compared with the configured distance, so
+// time grows with the square of the number of observations. With
+// WithSampleSize, the index of a random sample of that many observations
+// approximates it in time quadratic in the sample size; the sample must span
+// at least two clusters. rng may be nil when not sampling. It honours
+// WithWorkers.
+func Dunn[T Observation](clusters [][]T, rng *rand.Rand, opts ...Option) (float64, error) {
+	cfg := newConfig(opts)
+	points, labels, err := flattenClusters(clusters)
+	if err != nil {
+		return 0, err
+	}
+
+	// Validate sample size
+	if cfg.sampleSize < 0 {
+		return 0, fmt.Errorf("invalid sample size: %d", cfg.sampleSize)
+	}
+	sampled := make([]int, len(points))
+	for i := range sampled {
+		sampled[i] = i
+	}
+	if cfg.sampleSize > 0 && cfg.sampleSize < len(points) {
+		if rng == nil {
+			return 0, fmt.Errorf("random number generator is nil")
+		}
+		sampled = randomIndices(len(points), cfg.sampleSize, rng)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return 0, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Closest pair across clusters and farthest pair within one, per shard
+	distance := cfg.distanceFunc()
+	workers := cfg.workerCount()
+	separations := make([]float64, numShards(len(sampled), workers))
+	diameters := make([]float64, len(separations))
+	parallel(len(sampled), workers, func(shard, start, end int) {
+		separation, diameter := math.Inf(1), 0.0
+		for s := start; s < end; s++ {
+			i := sampled[s]
+			for _, o := range sampled[s+1:] {
+				d := distance(points[i], points[o])
+				if labels[i] == labels[o] {
+					diameter = max(diameter, d)
+				} else {
+					separation = min(separation, d)
+				}
+			}
+		}
+		separations[shard], diameters[shard] = separation, diameter
+	})
+
+	separation, diameter := math.Inf(1), 0.0
+	for shard := range separations {
+		separation = min(separation, separations[shard])
+		diameter = max(diameter, diameters[shard])
+	}
+	if math.IsInf(separation, 1) {
+		return 0, fmt.Errorf("sample spans a single cluster")
+	}
+	if diameter == 0 {
+		return math.Inf(1), nil
+	}
+	return separation / diameter, nil
+}
@@ -0,0 +1,77 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDunn(t *testing.T) {
+	// Closest pair 2 and 10, widest cluster 10 to 14
+	index, err := Dunn([][]Numbers{{0, 2}, {10, 14}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 8.0 / 4; math.Abs(index-want) > 1e-12 {
+		t.Errorf("expected index %f, got %f", want, index)
+	}
+
+	// Clusters collapsed to points
+	index, err = Dunn([][]Numbers{{1, 1}, {5}, {}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(index, 1) {
+		t.Errorf("expected an infinite index for collapsed clusters, got %f", index)
+	}
+}
+
+func TestDunnSample(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 200, 3)
+	clusters, err := Cluster(dataset, 3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exact, err := Dunn(clusters, nil, WithWorkers(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serial, err := Dunn(clusters, nil, WithWorkers(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if serial != exact {
+		t.Errorf("expected the same index with any number of workers, got %f and %f", serial, exact)
+	}
+
+	// A sample sees fewer close pairs and narrower clusters, so the
+	// approximation overestimates the index
+	approx, err := Dunn(clusters, rand.New(rand.NewSource(0)), WithSampleSize(100))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approx < exact || approx > 3*exact {
+		t.Errorf("expected an approximation of %f, got %f", exact, approx)
+	}
+}
+
+func TestDunnValidation(t *testing.T) {
+	clusters := [][]Numbers{{1, 2}, {10, 11}}
+	if _, err := Dunn([][]Numbers{{1, 2}}, nil); err == nil {
+		t.Error("expected error for a single cluster")
+	}
+	if _, err := Dunn(clusters, nil, WithSampleSize(-1)); err == nil {
+		t.Error("expected error for invalid sample size")
+	}
+	if _, err := Dunn(clusters, nil, WithSampleSize(2)); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Dunn(clusters, nil, WithWorkers(-1)); err == nil {
+		t.Error("expected error for invalid number of workers")
+	}
+	if _, err := Dunn([][]Numbers{{1, 2, 3, 4, 5}, {10}}, rand.New(rand.NewSource(0)), WithSampleSize(1)); err == nil {
+		t.Error("expected error for a sample spanning a single cluster")
+	}
+}
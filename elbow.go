@@ -0,0 +1,98 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ElbowResult holds the inertia curve of Elbow.
+type ElbowResult struct {
+	// Inertias holds the inertia of the run with kMin+i clusters at index i.
+	Inertias []float64
+	// Knee is the number of clusters at the knee of the curve, beyond which
+	// more clusters barely lower the inertia.
+	Knee int
+}
+
+// Elbow runs ClusterResult with every number of clusters from kMin to kMax
+// and returns the inertia curve with its knee, the elbow method to choose k.
+// The knee is the point of the curve farthest below the chord joining its
+// ends, both axes scaled to [0, 1] (Kneedle).
+//
+// Runs are spread over WithWorkers goroutines, each run using one, and
+// draw their seeds from rng beforehand, so results do not depend on the
+// number of workers. rng may be nil for deterministic runs. Other options
+// are passed to every run.
+func Elbow[T Observation](dataset []T, kMin, kMax int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*ElbowResult, error) {
+	cfg := newConfig(opts)
+
+	// Validate kMin and kMax
+	if kMin <= 0 || kMin > len(dataset) {
+		return nil, fmt.Errorf("invalid minimum number of clusters: %d", kMin)
+	}
+	if kMax < kMin || kMax > len(dataset) {
+		return nil, fmt.Errorf("invalid maximum number of clusters: %d", kMax)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// One seed per run, drawn in order
+	n := kMax - kMin + 1
+	seeds := make([]int64, n)
+	if rng != nil {
+		for i := range seeds {
+			seeds[i] = rng.Int63()
+		}
+	}
+
+	inertias := make([]float64, n)
+	errs := make([]error, n)
+	runOpts := append(opts[:len(opts):len(opts)], WithWorkers(1))
+	parallel(n, cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			var runRng *rand.Rand
+			if rng != nil {
+				runRng = rand.New(rand.NewSource(seeds[i]))
+			}
+			result, err := ClusterResult(dataset, kMin+i, deltaThreshold, iterationThreshold, runRng, runOpts...)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			inertias[i] = result.Inertia
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ElbowResult{Inertias: inertias, Knee: kMin + knee(inertias)}, nil
+}
+
+// knee returns the index of the point of a decreasing curve farthest below
+// the chord joining its ends, both axes scaled to [0, 1], or 0 when no point
+// lies below it.
+func knee(curve []float64) int {
+	n := len(curve)
+	if n < 3 {
+		return 0
+	}
+	first, last := curve[0], curve[n-1]
+	if first == last {
+		return 0
+	}
+	best, bestGap := 0, 0.0
+	for i, y := range curve {
+		x := float64(i) / float64(n-1)
+		// Height of the point scaled to [0, 1], the chord going from 1 to 0
+		scaled := (y - last) / (first - last)
+		if gap := (1 - x) - scaled; gap > bestGap {
+			best, bestGap = i, gap
+		}
+	}
+	return best
+}
@@ -0,0 +1,62 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestElbow(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 3)
+
+	result, err := Elbow(dataset, 1, 10, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Inertias) != 10 {
+		t.Fatalf("expected 10 inertias, got %d", len(result.Inertias))
+	}
+	if result.Knee != len(centers) {
+		t.Errorf("expected the knee at %d clusters, got %d", len(centers), result.Knee)
+	}
+
+	// Results do not depend on the number of workers
+	again, err := Elbow(dataset, 1, 10, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus), WithWorkers(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range result.Inertias {
+		if again.Inertias[i] != result.Inertias[i] {
+			t.Fatalf("inertia with %d clusters differs between runs", i+1)
+		}
+	}
+}
+
+func TestKnee(t *testing.T) {
+	if i := knee([]float64{100, 40, 10, 8, 7, 6}); i != 2 {
+		t.Errorf("expected knee at 2, got %d", i)
+	}
+	if i := knee([]float64{5, 4, 3, 2, 1}); i != 0 {
+		t.Errorf("expected no knee on a straight line, got %d", i)
+	}
+	if i := knee([]float64{5, 5}); i != 0 {
+		t.Errorf("expected no knee on two points, got %d", i)
+	}
+}
+
+func TestElbowValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Elbow(dataset, 0, 3, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid minimum k")
+	}
+	if _, err := Elbow(dataset, 3, 2, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid maximum k")
+	}
+	if _, err := Elbow(dataset, 1, 3, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := Elbow(dataset, 1, 3, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+}
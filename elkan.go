@@ -0,0 +1,153 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+)
+
+// elkan implements Elkan's accelerated assignment step. Each point keeps an
+// upper bound on the distance to its centroid and a lower bound on the
+// distance to every centroid. Bounds are loosened by how far centroids moved
+// and distances are only computed when the bounds cannot rule a centroid out.
+type elkan struct {
+	points  [][]float64
+	workers int
+	upper   []float64
+	lower   [][]float64
+	// previous holds the centroids of the previous call, nil before the first one
+	previous [][]float64
+}
+
+// newElkan returns an Elkan assigner for points.
+func newElkan(points [][]float64, workers int) *elkan {
+	return &elkan{
+		points:  points,
+		workers: workers,
+		upper:   make([]float64, len(points)),
+		lower:   make([][]float64, len(points)),
+	}
+}
+
+func (e *elkan) assign(centroids [][]float64, labels []int) *Stats {
+	k, dim := len(centroids), len(centroids[0])
+
+	// First call: compute every distance to initialize the bounds
+	if e.previous == nil {
+		e.previous = cloneAll(centroids)
+		return shardStats(len(e.points), k, dim, e.workers, func(start, end int, partial *Stats) {
+			for i := start; i < end; i++ {
+				e.lower[i] = make([]float64, k)
+				e.upper[i] = math.Inf(1)
+				for j := range centroids {
+					d := EuclideanDistance(e.points[i], centroids[j])
+					e.lower[i][j] = d
+					if d < e.upper[i] {
+						e.upper[i] = d
+						labels[i] = j
+					}
+				}
+				partial.add(e.points[i], labels[i])
+			}
+		})
+	}
+
+	// How far each centroid moved since the previous call
+	drift := centroidDrift(centroids, e.previous)
+	e.previous = cloneAll(centroids)
+
+	// Half the distance between centroids, and to the closest other centroid
+	half := centroidHalfDistances(centroids)
+	closest := closestHalfDistances(half)
+
+	return shardStats(len(e.points), k, dim, e.workers, func(start, end int, partial *Stats) {
+		for i := start; i < end; i++ {
+			point, lower := e.points[i], e.lower[i]
+
+			// Loosen the bounds by the centroid drift
+			for j := range lower {
+				lower[j] = max(0, lower[j]-drift[j])
+			}
+			e.upper[i] += drift[labels[i]]
+
+			// The current centroid is closer than half the way to any other
+			if e.upper[i] <= closest[labels[i]] {
+				partial.add(point, labels[i])
+				continue
+			}
+
+			tight := false
+			for j := range centroids {
+				if j == labels[i] || e.upper[i] <= lower[j] || e.upper[i] <= half[labels[i]][j] {
+					continue
+				}
+
+				// Tighten the upper bound once before comparing
+				if !tight {
+					e.upper[i] = EuclideanDistance(point, centroids[labels[i]])
+					lower[labels[i]] = e.upper[i]
+					tight = true
+					if e.upper[i] <= lower[j] || e.upper[i] <= half[labels[i]][j] {
+						continue
+					}
+				}
+
+				d := EuclideanDistance(point, centroids[j])
+				lower[j] = d
+				if d < e.upper[i] {
+					labels[i] = j
+					e.upper[i] = d
+				}
+			}
+			partial.add(point, labels[i])
+		}
+	})
+}
+
+// centroidHalfDistances returns half the distance between every pair of centroids.
+func centroidHalfDistances(centroids [][]float64) [][]float64 {
+	half := make([][]float64, len(centroids))
+	for j := range half {
+		half[j] = make([]float64, len(centroids))
+	}
+	for j := range centroids {
+		for other := j + 1; other < len(centroids); other++ {
+			d := EuclideanDistance(centroids[j], centroids[other]) / 2
+			half[j][other] = d
+			half[other][j] = d
+		}
+	}
+	return half
+}
+
+// closestHalfDistances returns, for each centroid, half the distance to its
+// closest other centroid given the pairwise half distances.
+func closestHalfDistances(half [][]float64) []float64 {
+	closest := make([]float64, len(half))
+	for j := range closest {
+		closest[j] = math.Inf(1)
+		for other := range half {
+			if other != j {
+				closest[j] = min(closest[j], half[j][other])
+			}
+		}
+	}
+	return closest
+}
+
+// centroidDrift returns how far each centroid moved from previous.
+func centroidDrift(centroids, previous [][]float64) []float64 {
+	drift := make([]float64, len(centroids))
+	for j := range centroids {
+		drift[j] = EuclideanDistance(centroids[j], previous[j])
+	}
+	return drift
+}
+
+// cloneAll returns a deep copy of points.
+func cloneAll(points [][]float64) [][]float64 {
+	clones := make([][]float64, len(points))
+	for i, p := range points {
+		clones[i] = slices.Clone(p)
+	}
+	return clones
+}
@@ -0,0 +1,164 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// materialize32 is like materialize but stores coordinates as float32.
+func materialize32[T Observation](dataset []T) ([][]float32, error) {
+	first := dataset[0].Coordinates()
+	dim := len(first)
+	flat := make([]float32, len(dataset)*dim)
+	points := make([][]float32, len(dataset))
+	for i, obs := range dataset {
+		coords := first
+		if i > 0 {
+			coords = obs.Coordinates()
+		}
+		if len(coords) != dim {
+			return nil, fmt.Errorf("inconsistent dimensions")
+		}
+		points[i] = flat[i*dim : (i+1)*dim : (i+1)*dim]
+		for d, x := range coords {
+			points[i][d] = float32(x)
+		}
+	}
+	return points, nil
+}
+
+// widen converts a float32 slice to float64.
+func widen(v []float32) []float64 {
+	w := make([]float64, len(v))
+	for d, x := range v {
+		w[d] = float64(x)
+	}
+	return w
+}
+
+// narrow converts a float64 slice to float32.
+func narrow(v []float64) []float32 {
+	n := make([]float32, len(v))
+	for d, x := range v {
+		n[d] = float32(x)
+	}
+	return n
+}
+
+// squaredDistance32 computes the squared Euclidean distance between two
+// float32 slices of equal length, accumulating in float64.
+func squaredDistance32(a, b []float32) float64 {
+	b = b[:len(a)]
+	var s0, s1 float64
+	i := 0
+	for ; i+2 <= len(a); i += 2 {
+		d0 := float64(a[i] - b[i])
+		d1 := float64(a[i+1] - b[i+1])
+		s0 += d0 * d0
+		s1 += d1 * d1
+	}
+	if i < len(a) {
+		d := float64(a[i] - b[i])
+		s0 += d * d
+	}
+	return s0 + s1
+}
+
+// clusterFloat32 runs Lloyd's algorithm on a float32 copy of dataset. The
+// configuration has been validated by ClusterResult.
+func clusterFloat32[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, cfg *config) (*Result[T], error) {
+	points, err := materialize32(dataset)
+	if err != nil {
+		return nil, err
+	}
+	dim := len(points[0])
+	if err := validateCentroids(cfg.centroids, k, dim); err != nil {
+		return nil, err
+	}
+
+	// Initialize centroids, widening only the rows an init strategy needs
+	centroids := make([][]float32, k)
+	switch {
+	case cfg.centroids != nil:
+		for j := range centroids {
+			centroids[j] = narrow(cfg.centroids[j])
+		}
+	case cfg.init == InitRandom:
+		for j, i := range randomIndices(len(points), k, rng) {
+			centroids[j] = append([]float32(nil), points[i]...)
+		}
+	default:
+		wide := make([][]float64, len(points))
+		for i, p := range points {
+			wide[i] = widen(p)
+		}
+		for j, centroid := range initCentroids(wide, k, cfg, rng) {
+			centroids[j] = narrow(centroid)
+		}
+	}
+
+	labels := make([]int, len(points))
+	workers := cfg.workerCount()
+
+	// Main k-means loop
+	iterations := 0
+	converged := false
+	for range iterationThreshold {
+		iterations++
+
+		// Assignment step, with float64 sums merged in shard order
+		stats := shardStats(len(points), k, dim, workers, func(start, end int, partial *Stats) {
+			for i := start; i < end; i++ {
+				best := math.Inf(1)
+				for j, centroid := range centroids {
+					if d := squaredDistance32(points[i], centroid); d < best {
+						best = d
+						labels[i] = j
+					}
+				}
+				for d, x := range points[i] {
+					partial.Sums[labels[i]][d] += float64(x)
+				}
+				partial.Counts[labels[i]]++
+			}
+		})
+
+		// Update step, retaining the old centroid of empty clusters
+		maxMovement := 0.0
+		for j := range k {
+			if stats.Counts[j] == 0 {
+				continue
+			}
+			centroid := make([]float32, dim)
+			for d := range dim {
+				centroid[d] = float32(stats.Sums[j][d] / stats.Counts[j])
+			}
+			maxMovement = max(maxMovement, math.Sqrt(squaredDistance32(centroid, centroids[j])))
+			centroids[j] = centroid
+		}
+
+		// Stop if maximum movement is below the threshold
+		if maxMovement < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	// Form clusters based on final assignments
+	result := &Result[T]{
+		Clusters:   make([][]T, k),
+		Centroids:  make([][]float64, k),
+		Labels:     labels,
+		Iterations: iterations,
+		Converged:  converged,
+	}
+	for j := range centroids {
+		result.Centroids[j] = widen(centroids[j])
+	}
+	for i, obs := range dataset {
+		result.Clusters[labels[i]] = append(result.Clusters[labels[i]], obs)
+		result.Inertia += squaredDistance32(points[i], centroids[labels[i]])
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestClusterFloat32(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 1000, 5, 8)
+
+	for _, init := range []Init{InitRandom, InitKMeansPlusPlus} {
+		expected, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(1)), WithInit(init))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(1)), WithInit(init), WithFloat32())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Well separated blobs are labelled identically at float32 precision
+		if !slices.Equal(result.Labels, expected.Labels) {
+			t.Errorf("init %d: float32 labels differ from float64 labels", init)
+		}
+		if math.Abs(result.Inertia-expected.Inertia) > 1e-4*expected.Inertia {
+			t.Errorf("init %d: expected inertia %v, got %v", init, expected.Inertia, result.Inertia)
+		}
+	}
+}
+
+func TestClusterFloat32Unsupported(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithFloat32(), WithAlgorithm(Elkan)); err == nil {
+		t.Error("expected error for float32 storage with Elkan")
+	}
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithFloat32(), WithDistance(ManhattanDistance)); err == nil {
+		t.Error("expected error for float32 storage with a custom distance")
+	}
+	if _, err := Cluster(dataset, 3, 0.01, 100, rng, WithFloat32(), WithFrozenCentroids([][]float64{{100}})); err == nil {
+		t.Error("expected error for float32 storage with frozen centroids")
+	}
+}
@@ -0,0 +1,34 @@
+package kmeans
+
+import "math"
+
+// FowlkesMallows returns the Fowlkes-Mallows index between two labelings of
+// the same observations, such as the Labels of two runs with different
+// seeds: the geometric mean of the precision and recall of the pairs of
+// observations put together by one labeling against those put together by
+// the other. It ranges from 0 to 1, 1 for identical partitions whatever the
+// label values, and is 0 when either labeling puts no pair together. Labels
+// are compared for equality only.
+func FowlkesMallows(labels, truth []int) (float64, error) {
+	counts, rows, cols, err := contingency(labels, truth)
+	if err != nil {
+		return 0, err
+	}
+
+	together, rowPairs, colPairs := 0.0, 0.0, 0.0
+	for _, row := range counts {
+		for _, c := range row {
+			together += pairs(c)
+		}
+	}
+	for _, r := range rows {
+		rowPairs += pairs(r)
+	}
+	for _, c := range cols {
+		colPairs += pairs(c)
+	}
+	if rowPairs == 0 || colPairs == 0 {
+		return 0, nil
+	}
+	return together / math.Sqrt(rowPairs*colPairs), nil
+}
@@ -0,0 +1,65 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFowlkesMallows(t *testing.T) {
+	// Identical partitions with different label values
+	fmi, err := FowlkesMallows([]int{0, 0, 1, 1, 2, 2}, []int{5, 5, 3, 3, Noise, Noise})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmi != 1 {
+		t.Errorf("expected 1 for identical partitions, got %f", fmi)
+	}
+
+	// 2 pairs together in both, 6 and 3 pairs together in each
+	fmi, err = FowlkesMallows([]int{0, 0, 0, 1, 1, 1}, []int{0, 0, 1, 1, 2, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 2 / math.Sqrt(6*3); math.Abs(fmi-want) > 1e-12 {
+		t.Errorf("expected %f, got %f", want, fmi)
+	}
+
+	// No pair together
+	fmi, err = FowlkesMallows([]int{0, 1, 2}, []int{0, 0, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmi != 0 {
+		t.Errorf("expected 0 for singletons, got %f", fmi)
+	}
+}
+
+func TestFowlkesMallowsSeeds(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+	first, err := ClusterLabels(dataset, 3, 0.001, 100, rand.New(rand.NewSource(1)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := ClusterLabels(dataset, 3, 0.001, 100, rand.New(rand.NewSource(2)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fmi, err := FowlkesMallows(first, second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmi != 1 {
+		t.Errorf("expected runs with different seeds to agree, got %f", fmi)
+	}
+}
+
+func TestFowlkesMallowsValidation(t *testing.T) {
+	if _, err := FowlkesMallows(nil, nil); err == nil {
+		t.Error("expected error for empty labels")
+	}
+	if _, err := FowlkesMallows([]int{0, 1}, []int{0}); err == nil {
+		t.Error("expected error for labels of different lengths")
+	}
+}
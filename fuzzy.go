@@ -0,0 +1,224 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// FuzzyResult holds the outcome of a soft clustering run, where every
+// observation belongs to every cluster to some degree.
+type FuzzyResult[T Observation] struct {
+	// Centroids holds the center of each cluster.
+	Centroids [][]float64
+	// Memberships holds, for each observation in input order, its degree of
+	// membership to each cluster.
+	Memberships [][]float64
+	// Labels holds, for each observation in input order, the cluster it
+	// belongs to the most.
+	Labels []int
+	// Covariances holds the covariance matrix of each cluster, for methods
+	// estimating them such as GustafsonKessel, or nil.
+	Covariances [][][]float64
+	// Objective is the value of the objective function the run minimised.
+	Objective float64
+	// Iterations is the number of iterations executed.
+	Iterations int
+	// Converged reports whether the centroids moved less than the delta
+	// threshold before the iteration threshold was reached.
+	Converged bool
+}
+
+// FuzzyCMeans implements fuzzy c-means: each observation belongs to each of
+// the c clusters with a membership between 0 and 1, memberships of an
+// observation summing to 1. Memberships decrease with the distance to the
+// centroid and centroids are the means of all observations weighted by their
+// memberships raised to the fuzzifier, set with WithFuzzifier. It iterates
+// until no centroid moves by deltaThreshold or more or iterationThreshold
+// iterations ran, minimising the sum of memberships raised to the fuzzifier
+// times squared distances.
+//
+// Initial centroids are chosen as in Cluster. It honours WithDistance,
+// WithWorkers and the weights of weighted observations.
+func FuzzyCMeans[T Observation](dataset []T, c int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*FuzzyResult[T], error) {
+	cfg, points, err := newFuzzyRun(dataset, c, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+	m := cfg.fuzzifierOrDefault()
+	memberships := newMatrix(len(points), c)
+	centroids, iterations, converged := fuzzyCMeans(points, initCentroids(points, c, cfg, rng), memberships, m, deltaThreshold, iterationThreshold, cfg)
+
+	// Objective of the final centroids
+	objective := 0.0
+	distance := cfg.distanceFunc()
+	for i, p := range points {
+		for j, u := range memberships[i] {
+			d := distance(p, centroids[j])
+			objective += cfg.weight(i) * math.Pow(u, m) * d * d
+		}
+	}
+	return &FuzzyResult[T]{
+		Centroids:   centroids,
+		Memberships: memberships,
+		Labels:      hardLabels(memberships),
+		Objective:   objective,
+		Iterations:  iterations,
+		Converged:   converged,
+	}, nil
+}
+
+// fuzzyCMeans alternates fuzzy c-means membership and centroid updates from
+// centroids until no centroid moves by deltaThreshold or more or
+// iterationThreshold iterations ran. It leaves the memberships of the final
+// centroids in memberships and returns the final centroids, the number of
+// iterations and whether the run converged.
+func fuzzyCMeans(points, centroids, memberships [][]float64, m, deltaThreshold float64, iterationThreshold int, cfg *config) ([][]float64, int, bool) {
+	iterations := 0
+	converged := false
+	for range iterationThreshold {
+		iterations++
+		fuzzyMemberships(points, centroids, memberships, m, cfg)
+		newCentroids := fuzzyCentroids(points, memberships, centroids, m, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+	fuzzyMemberships(points, centroids, memberships, m, cfg)
+	return centroids, iterations, converged
+}
+
+// newFuzzyRun validates the arguments shared by soft clustering methods and
+// returns the configuration, with the weights of weighted observations, and
+// the coordinates of the observations.
+func newFuzzyRun[T Observation](dataset []T, c int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts []Option) (*config, [][]float64, error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate c
+	if c <= 0 || c > len(dataset) {
+		return nil, nil, fmt.Errorf("invalid number of clusters: %d", c)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate fuzzifier
+	if cfg.fuzzifier != 0 && !(cfg.fuzzifier > 1) {
+		return nil, nil, fmt.Errorf("invalid fuzzifier: %f", cfg.fuzzifier)
+	}
+
+	// Validate center, soft clustering weights means
+	if cfg.center != nil {
+		return nil, nil, fmt.Errorf("soft clustering requires mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateCentroids(cfg.centroids, c, len(points[0])); err != nil {
+		return nil, nil, err
+	}
+	cfg.weights, err = datasetWeights(dataset, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, points, nil
+}
+
+// fuzzyMemberships sets the fuzzy c-means membership of every point to every
+// centroid: the inverse of the sum over centroids of the distance ratios
+// raised to 2/(m-1). A point on one or more centroids belongs to them equally.
+func fuzzyMemberships(points, centroids, memberships [][]float64, m float64, cfg *config) {
+	distance := cfg.distanceFunc()
+	exponent := 2 / (m - 1)
+	parallel(len(points), cfg.workerCount(), func(_, start, end int) {
+		dists := make([]float64, len(centroids))
+		for i := start; i < end; i++ {
+			u := memberships[i]
+			zeros := 0
+			for j, centroid := range centroids {
+				dists[j] = distance(points[i], centroid)
+				if dists[j] == 0 {
+					zeros++
+				}
+			}
+			for j := range u {
+				switch {
+				case zeros > 0 && dists[j] == 0:
+					u[j] = 1 / float64(zeros)
+				case zeros > 0:
+					u[j] = 0
+				default:
+					sum := 0.0
+					for l := range dists {
+						sum += math.Pow(dists[j]/dists[l], exponent)
+					}
+					u[j] = 1 / sum
+				}
+			}
+		}
+	})
+}
+
+// fuzzyCentroids returns the mean of the points weighted by their memberships
+// raised to m, and by their weights in weighted runs. A centroid without
+// weight keeps its position.
+func fuzzyCentroids(points, memberships, centroids [][]float64, m float64, cfg *config) [][]float64 {
+	stats := NewStats(len(centroids), len(points[0]))
+	for i, p := range points {
+		for j, u := range memberships[i] {
+			stats.addWeighted(p, cfg.weight(i)*math.Pow(u, m), j)
+		}
+	}
+	return update(nil, nil, centroids, stats, &config{})
+}
+
+// hardLabels returns, for each row of memberships, the index of its largest
+// membership.
+func hardLabels(memberships [][]float64) []int {
+	labels := make([]int, len(memberships))
+	for i, u := range memberships {
+		for j := range u {
+			if u[j] > u[labels[i]] {
+				labels[i] = j
+			}
+		}
+	}
+	return labels
+}
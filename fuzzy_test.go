@@ -0,0 +1,71 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFuzzyCMeans(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 12, 17, 21, 22, 23}
+	result, err := FuzzyCMeans(dataset, 2, 1e-9, 300, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Converged {
+		t.Error("expected convergence")
+	}
+
+	// Memberships of each observation sum to one
+	for i, u := range result.Memberships {
+		if s := sum(u); math.Abs(s-1) > 1e-9 {
+			t.Errorf("memberships of observation %d sum to %v", i, s)
+		}
+	}
+
+	// Core observations belong to their cluster, the border one to both
+	low, high := result.Labels[0], result.Labels[7]
+	if low == high || result.Labels[1] != low || result.Labels[6] != high {
+		t.Errorf("unexpected labels %v", result.Labels)
+	}
+	if u := result.Memberships[0][low]; u < 0.9 {
+		t.Errorf("expected a strong membership for 1, got %v", u)
+	}
+	if u := result.Memberships[3][low]; u < 0.2 || u > 0.8 {
+		t.Errorf("expected a shared membership for 12, got %v", u)
+	}
+}
+
+func TestFuzzyMemberships(t *testing.T) {
+	points := [][]float64{{0}, {1}, {3}}
+	memberships := [][]float64{make([]float64, 2), make([]float64, 2), make([]float64, 2)}
+	fuzzyMemberships(points, [][]float64{{0}, {4}}, memberships, 2, &config{})
+
+	// On a centroid: full membership; elsewhere inverse squared distances
+	if memberships[0][0] != 1 || memberships[0][1] != 0 {
+		t.Errorf("expected [1 0], got %v", memberships[0])
+	}
+	if u := memberships[1][0]; math.Abs(u-0.9) > 1e-12 {
+		t.Errorf("expected 0.9, got %v", u)
+	}
+	if u := memberships[2][1]; math.Abs(u-0.9) > 1e-12 {
+		t.Errorf("expected 0.9, got %v", u)
+	}
+}
+
+func TestFuzzyCMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := FuzzyCMeans(dataset, 2, 0.01, 100, rng, WithFuzzifier(1)); err == nil {
+		t.Error("expected error for a fuzzifier of 1")
+	}
+	if _, err := FuzzyCMeans(dataset, 7, 0.01, 100, rng); err == nil {
+		t.Error("expected error for invalid number of clusters")
+	}
+	if _, err := FuzzyCMeans(dataset, 2, 0.01, 100, rng, WithCenter(MedianCenter)); err == nil {
+		t.Error("expected error for a custom center")
+	}
+	if _, err := FuzzyCMeans(dataset, 2, 0.01, 100, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
@@ -0,0 +1,78 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// PartitionCoefficient returns the partition coefficient of Bezdek of a
+// fuzzy partition: the mean over observations of the sum of their squared
+// memberships. It ranges from 1/c for memberships spread evenly over the c
+// clusters to 1 for a crisp partition; higher values mean a clearer
+// structure, so it helps choose c.
+func PartitionCoefficient[T Observation](result *FuzzyResult[T]) float64 {
+	coefficient := 0.0
+	for _, row := range result.Memberships {
+		for _, u := range row {
+			coefficient += u * u
+		}
+	}
+	return coefficient / float64(len(result.Memberships))
+}
+
+// XieBeni returns the Xie-Beni index of the fuzzy partition result of
+// dataset: the sum of memberships raised to the fuzzifier times squared
+// distances of the observations to the centroids, over the number of
+// observations times the smallest squared distance between two centroids.
+// It is 0 or more, lower when clusters are compact and well separated, and
+// infinite when two centroids coincide, so it helps choose c.
+//
+// Distances are measured with the configured distance, and the fuzzifier is
+// the one set with WithFuzzifier, which should be the one of the run.
+func XieBeni[T Observation](dataset []T, result *FuzzyResult[T], opts ...Option) (float64, error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return 0, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate result
+	if len(result.Memberships) != len(dataset) {
+		return 0, fmt.Errorf("expected memberships of %d observations, got %d", len(dataset), len(result.Memberships))
+	}
+	if len(result.Centroids) < 2 {
+		return 0, fmt.Errorf("at least 2 clusters are required, got %d", len(result.Centroids))
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return 0, err
+	}
+	for _, centroid := range result.Centroids {
+		if len(centroid) != len(points[0]) {
+			return 0, fmt.Errorf("inconsistent dimensions")
+		}
+	}
+
+	m := cfg.fuzzifierOrDefault()
+	distance := cfg.distanceFunc()
+	compactness := 0.0
+	for i, p := range points {
+		for j, u := range result.Memberships[i] {
+			d := distance(p, result.Centroids[j])
+			compactness += math.Pow(u, m) * d * d
+		}
+	}
+	separation := math.Inf(1)
+	for j := range result.Centroids {
+		for l := range j {
+			d := distance(result.Centroids[j], result.Centroids[l])
+			separation = min(separation, d*d)
+		}
+	}
+	if separation == 0 {
+		return math.Inf(1), nil
+	}
+	return compactness / (float64(len(points)) * separation), nil
+}
@@ -0,0 +1,91 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPartitionCoefficient(t *testing.T) {
+	result := &FuzzyResult[Numbers]{Memberships: [][]float64{{1, 0}, {0.5, 0.5}}}
+	if pc := PartitionCoefficient(result); math.Abs(pc-0.75) > 1e-12 {
+		t.Errorf("expected 0.75, got %f", pc)
+	}
+}
+
+func TestXieBeni(t *testing.T) {
+	// Crisp memberships: squared distances 1+1+4+4 over 4 times 11²
+	dataset := []Numbers{0, 2, 10, 14}
+	result := &FuzzyResult[Numbers]{
+		Centroids:   [][]float64{{1}, {12}},
+		Memberships: [][]float64{{1, 0}, {1, 0}, {0, 1}, {0, 1}},
+	}
+	xb, err := XieBeni(dataset, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 10.0 / (4 * 121); math.Abs(xb-want) > 1e-12 {
+		t.Errorf("expected %f, got %f", want, xb)
+	}
+
+	// Coinciding centroids
+	result.Centroids = [][]float64{{5}, {5}}
+	xb, err = XieBeni(dataset, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !math.IsInf(xb, 1) {
+		t.Errorf("expected an infinite index for coinciding centroids, got %f", xb)
+	}
+}
+
+func TestFuzzyIndicesRankC(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	bestXB, bestPC := 0, 0
+	minXB, maxPC := math.Inf(1), 0.0
+	for c := 2; c <= 6; c++ {
+		result, err := FuzzyCMeans(dataset, c, 0.001, 200, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		xb, err := XieBeni(dataset, result)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if xb < minXB {
+			bestXB, minXB = c, xb
+		}
+		if pc := PartitionCoefficient(result); pc > maxPC {
+			bestPC, maxPC = c, pc
+		}
+	}
+	if bestXB != len(centers) {
+		t.Errorf("expected the lowest Xie-Beni index with %d clusters, got %d", len(centers), bestXB)
+	}
+	if bestPC != len(centers) {
+		t.Errorf("expected the highest partition coefficient with %d clusters, got %d", len(centers), bestPC)
+	}
+}
+
+func TestXieBeniValidation(t *testing.T) {
+	result := &FuzzyResult[Numbers]{
+		Centroids:   [][]float64{{1}, {12}},
+		Memberships: [][]float64{{1, 0}, {0, 1}},
+	}
+	if _, err := XieBeni([]Numbers{}, result); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := XieBeni([]Numbers{1, 2, 3}, result); err == nil {
+		t.Error("expected error for mismatched memberships")
+	}
+	flat := &FuzzyResult[Vector]{Centroids: result.Centroids, Memberships: result.Memberships}
+	if _, err := XieBeni([]Vector{{1, 0}, {2, 0}}, flat); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	single := &FuzzyResult[Numbers]{Centroids: [][]float64{{1}}, Memberships: [][]float64{{1}, {1}}}
+	if _, err := XieBeni([]Numbers{1, 2}, single); err == nil {
+		t.Error("expected error for a single cluster")
+	}
+}
@@ -0,0 +1,172 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// GapResult holds the gap statistic curve of GapStatistic.
+type GapResult struct {
+	// Gaps holds the gap with kMin+i clusters at index i: the mean log
+	// inertia of the reference datasets minus the log inertia of the data.
+	Gaps []float64
+	// StdErrs holds the standard error of the gap with kMin+i clusters at
+	// index i, the standard deviation of the reference log inertias scaled by
+	// sqrt(1 + 1/references).
+	StdErrs []float64
+	// K is the recommended number of clusters, the smallest k whose gap is at
+	// least the gap of k+1 minus its standard error, or kMax if there is none.
+	K int
+}
+
+// rawPoint is an observation given by its coordinates, such as a point of a
+// reference dataset of GapStatistic.
+type rawPoint []float64
+
+// Coordinates returns the coordinates of the point.
+func (p rawPoint) Coordinates() []float64 {
+	return p
+}
+
+// GapStatistic estimates the number of clusters with the gap statistic of
+// Tibshirani, Walther and Hastie. It runs ClusterResult with every number of
+// clusters from kMin to kMax on the dataset and on references datasets of as
+// many observations drawn uniformly within its bounding box, and compares
+// their inertias: the gap is largest where the data is much more clustered
+// than uniform noise.
+//
+// The reference datasets are drawn from rng once and shared by every k, and
+// every run draws its seed from rng beforehand, so results do not depend on
+// the number of WithWorkers goroutines the runs are spread over, each run
+// using one. Other options are passed to every run.
+func GapStatistic[T Observation](dataset []T, kMin, kMax, references int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*GapResult, error) {
+	cfg := newConfig(opts)
+
+	// Validate kMin and kMax
+	if kMin <= 0 || kMin > len(dataset) {
+		return nil, fmt.Errorf("invalid minimum number of clusters: %d", kMin)
+	}
+	if kMax < kMin || kMax > len(dataset) {
+		return nil, fmt.Errorf("invalid maximum number of clusters: %d", kMax)
+	}
+
+	// Validate references
+	if references <= 0 {
+		return nil, fmt.Errorf("invalid number of references: %d", references)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bounding box of the data
+	lo, hi := boundingBox(points)
+
+	// Reference datasets drawn uniformly within the bounding box
+	refs := make([][]rawPoint, references)
+	for b := range refs {
+		refs[b] = make([]rawPoint, len(points))
+		for i := range refs[b] {
+			p := make(rawPoint, len(lo))
+			for d := range p {
+				p[d] = lo[d] + rng.Float64()*(hi[d]-lo[d])
+			}
+			refs[b][i] = p
+		}
+	}
+
+	// One run per k on the data and on every reference, with seeds drawn in
+	// order
+	n := kMax - kMin + 1
+	runs := n * (references + 1)
+	seeds := make([]int64, runs)
+	for r := range seeds {
+		seeds[r] = rng.Int63()
+	}
+
+	logInertias := make([]float64, runs)
+	errs := make([]error, runs)
+	runOpts := append(opts[:len(opts):len(opts)], WithWorkers(1))
+	parallel(runs, cfg.workerCount(), func(_, start, end int) {
+		for r := start; r < end; r++ {
+			k, b := kMin+r/(references+1), r%(references+1)
+			runRng := rand.New(rand.NewSource(seeds[r]))
+			var inertia float64
+			var err error
+			if b == 0 {
+				inertia, err = runInertia(dataset, k, deltaThreshold, iterationThreshold, runRng, runOpts)
+			} else {
+				inertia, err = runInertia(refs[b-1], k, deltaThreshold, iterationThreshold, runRng, runOpts)
+			}
+			if err != nil {
+				errs[r] = err
+				continue
+			}
+			logInertias[r] = math.Log(max(inertia, math.SmallestNonzeroFloat64))
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &GapResult{Gaps: make([]float64, n), StdErrs: make([]float64, n), K: kMax}
+	for i := range n {
+		logs := logInertias[i*(references+1) : (i+1)*(references+1)]
+		mean := 0.0
+		for _, l := range logs[1:] {
+			mean += l
+		}
+		mean /= float64(references)
+		variance := 0.0
+		for _, l := range logs[1:] {
+			variance += (l - mean) * (l - mean)
+		}
+		variance /= float64(references)
+		result.Gaps[i] = mean - logs[0]
+		result.StdErrs[i] = math.Sqrt(variance) * math.Sqrt(1+1/float64(references))
+	}
+	for i := range n - 1 {
+		if result.Gaps[i] >= result.Gaps[i+1]-result.StdErrs[i+1] {
+			result.K = kMin + i
+			break
+		}
+	}
+	return result, nil
+}
+
+// runInertia runs ClusterResult and returns the inertia of its result.
+func runInertia[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts []Option) (float64, error) {
+	result, err := ClusterResult(dataset, k, deltaThreshold, iterationThreshold, rng, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return result.Inertia, nil
+}
+
+// boundingBox returns the lowest and highest coordinates of points along
+// every dimension.
+func boundingBox(points [][]float64) ([]float64, []float64) {
+	lo, hi := slices.Clone(points[0]), slices.Clone(points[0])
+	for _, p := range points[1:] {
+		for d, v := range p {
+			lo[d] = min(lo[d], v)
+			hi[d] = max(hi[d], v)
+		}
+	}
+	return lo, hi
+}
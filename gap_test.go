@@ -0,0 +1,74 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGapStatistic(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	result, err := GapStatistic(dataset, 1, 6, 10, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Gaps) != 6 || len(result.StdErrs) != 6 {
+		t.Fatalf("expected 6 gaps and standard errors, got %d and %d", len(result.Gaps), len(result.StdErrs))
+	}
+	if result.K != len(centers) {
+		t.Errorf("expected %d clusters, got %d (gaps %v)", len(centers), result.K, result.Gaps)
+	}
+	for i, s := range result.StdErrs {
+		if s <= 0 {
+			t.Errorf("expected a positive standard error with %d clusters, got %f", i+1, s)
+		}
+	}
+
+	// Results do not depend on the number of workers
+	again, err := GapStatistic(dataset, 1, 6, 10, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus), WithWorkers(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range result.Gaps {
+		if again.Gaps[i] != result.Gaps[i] {
+			t.Fatalf("gap with %d clusters differs between runs", i+1)
+		}
+	}
+}
+
+func TestGapStatisticUniform(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dataset := make([]Vector, 200)
+	for i := range dataset {
+		dataset[i] = Vector{rng.Float64(), rng.Float64()}
+	}
+
+	result, err := GapStatistic(dataset, 1, 5, 10, 0.001, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.K != 1 {
+		t.Errorf("expected 1 cluster for uniform data, got %d (gaps %v)", result.K, result.Gaps)
+	}
+}
+
+func TestGapStatisticValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := GapStatistic(dataset, 0, 3, 5, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid minimum k")
+	}
+	if _, err := GapStatistic(dataset, 2, 7, 5, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid maximum k")
+	}
+	if _, err := GapStatistic(dataset, 1, 3, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid number of references")
+	}
+	if _, err := GapStatistic(dataset, 1, 3, 5, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := GapStatistic([]Vector{{1, 2}, {3}}, 1, 2, 5, 0.01, 10, rng); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
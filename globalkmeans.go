@@ -0,0 +1,100 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// GlobalKMeans implements the global k-means algorithm of Likas, Vlassis and
+// Verbeek. It solves the problems with 1 to k clusters in turn: the solution
+// with j+1 clusters is the best of the k-means runs started from the solution
+// with j clusters plus one observation, trying every observation as the new
+// centroid. It is deterministic and usually finds a near-optimal solution,
+// at the cost of one k-means run per observation and cluster.
+//
+// Each k-means run iterates until no centroid moves by deltaThreshold or more
+// or iterationThreshold iterations ran. The result's Iterations is the total
+// number of iterations of the kept runs and Converged reports whether the
+// last kept run converged. It requires the Euclidean distance and mean
+// centroids and honours WithAlgorithm and WithWorkers.
+func GlobalKMeans[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate algorithm, the search relies on full k-means runs
+	if !cfg.algorithm.valid() || cfg.algorithm.stochastic() || cfg.algorithm == MacQueen {
+		return nil, fmt.Errorf("invalid algorithm: %d", cfg.algorithm)
+	}
+
+	// Validate distance, the search minimises the sum of squared errors
+	if !cfg.euclidean() {
+		return nil, fmt.Errorf("global k-means requires the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// The optimal single cluster is centred on the mean
+	centroids := [][]float64{mean(points)}
+	labels := make([]int, len(points))
+	iterations, converged := 0, true
+	candidateLabels := make([]int, len(points))
+	for len(centroids) < k {
+		best, bestInertia := [][]float64(nil), math.Inf(1)
+		bestIterations, bestConverged := 0, false
+		for _, p := range points {
+			start := append(cloneAll(centroids), slices.Clone(p))
+			candidate, runIterations, runConverged := lloydLoop(points, start, candidateLabels, deltaThreshold, iterationThreshold, cfg)
+			inertia := 0.0
+			for i, j := range candidateLabels {
+				inertia += squaredDistance(points[i], candidate[j])
+			}
+			if inertia < bestInertia {
+				best, bestInertia = candidate, inertia
+				bestIterations, bestConverged = runIterations, runConverged
+			}
+		}
+		centroids = best
+		iterations += bestIterations
+		converged = bestConverged
+	}
+
+	// Labels of the final centroids, which moved after the last assignment
+	newAssigner(points, cfg).assign(centroids, labels)
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGlobalKMeans(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}, {100, 100}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 30, 3)
+
+	result, err := GlobalKMeans(dataset, len(centers), 0.001, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Clusters) != len(centers) || !result.Converged {
+		t.Fatal("unexpected result")
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/30*30] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+
+	// No random restart of Lloyd's algorithm does better
+	for seed := range int64(10) {
+		lloyd, err := ClusterResult(dataset, len(centers), 0.001, 100, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if lloyd.Inertia < result.Inertia-1e-9 {
+			t.Fatalf("seed %d: Lloyd's inertia %f below global inertia %f", seed, lloyd.Inertia, result.Inertia)
+		}
+	}
+
+	// The search is deterministic
+	again, err := GlobalKMeans(dataset, len(centers), 0.001, 100, WithAlgorithm(Elkan))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Inertia != result.Inertia {
+		t.Fatalf("expected inertia %f, got %f", result.Inertia, again.Inertia)
+	}
+}
+
+func TestGlobalKMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := GlobalKMeans([]Numbers{}, 2, 0.01, 10); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := GlobalKMeans(dataset, 7, 0.01, 10); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := GlobalKMeans(dataset, 2, 0, 10); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := GlobalKMeans(dataset, 2, 0.01, 0); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := GlobalKMeans(dataset, 2, 0.01, 10, WithAlgorithm(MiniBatch)); err == nil {
+		t.Error("expected error for stochastic algorithm")
+	}
+	if _, err := GlobalKMeans(dataset, 2, 0.01, 10, WithMedians()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+	if _, err := GlobalKMeans(dataset, 2, 0.01, 10, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
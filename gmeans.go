@@ -0,0 +1,149 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// gaussianCritical is the critical value of the Anderson-Darling statistic at
+// the 0.0001 significance level used by G-means, which keeps clusters from
+// being split on mild departures from normality.
+const gaussianCritical = 1.8692
+
+// GMeans implements G-means, which selects the number of clusters
+// automatically. Starting from kMin clusters, it splits every cluster in two
+// along its principal component with a local 2-means run, projects the points
+// of the cluster onto the line joining the two children and keeps the split
+// when an Anderson-Darling test rejects that the projections are Gaussian.
+// Centroids are then refined by k-means over the whole dataset, and rounds
+// continue until every cluster looks Gaussian or kMax clusters are reached.
+// The chosen k is the number of centroids of the result.
+//
+// The arguments, options and the result's Iterations and Converged are as in
+// XMeans.
+func GMeans[T Observation](dataset []T, kMin, kMax int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg, points, err := newSearchRun(dataset, kMin, kMax, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from kMin clusters
+	labels := make([]int, len(points))
+	centroids, _, _ := lloydLoop(points, initCentroids(points, kMin, cfg, rng), labels, deltaThreshold, iterationThreshold, cfg)
+
+	rounds, converged := 0, false
+	for len(centroids) < kMax {
+		rounds++
+
+		// Split clusters whose points are not Gaussian
+		var next [][]float64
+		for j, members := range groupPoints(points, labels, len(centroids)) {
+			if len(members) < 2 || len(centroids)+len(next)-j >= kMax {
+				next = append(next, centroids[j])
+				continue
+			}
+			children, _, _ := lloydLoop(members, splitPrincipal(members, centroids[j]), make([]int, len(members)), deltaThreshold, iterationThreshold, cfg)
+			if andersonDarling(projectOnto(members, children[0], children[1])) > gaussianCritical {
+				next = append(next, children...)
+			} else {
+				next = append(next, centroids[j])
+			}
+		}
+		if len(next) == len(centroids) {
+			converged = true
+			break
+		}
+
+		// Refine every centroid over the whole dataset
+		centroids, _, _ = lloydLoop(points, next, labels, deltaThreshold, iterationThreshold, cfg)
+	}
+
+	// Labels of the final centroids
+	newAssigner(points, cfg).assign(centroids, labels)
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = rounds
+	result.Converged = converged
+	return result, nil
+}
+
+// splitPrincipal returns two centroids on either side of centroid along the
+// principal component of points, at a distance of sqrt(2λ/π) where λ is the
+// variance along that component.
+func splitPrincipal(points [][]float64, centroid []float64) [][]float64 {
+	m := mean(points)
+	axis := principalComponent(covariance(points, m))
+	variance := 0.0
+	for _, p := range points {
+		projection := 0.0
+		for d := range axis {
+			projection += (p[d] - m[d]) * axis[d]
+		}
+		variance += projection * projection
+	}
+	offset := math.Sqrt(2 * variance / float64(len(points)) / math.Pi)
+
+	a, b := slices.Clone(centroid), slices.Clone(centroid)
+	for d := range axis {
+		a[d] += offset * axis[d]
+		b[d] -= offset * axis[d]
+	}
+	return [][]float64{a, b}
+}
+
+// projectOnto returns the projections of points onto the line through a and
+// b, as multiples of b-a.
+func projectOnto(points [][]float64, a, b []float64) []float64 {
+	v := make([]float64, len(a))
+	for d := range v {
+		v[d] = b[d] - a[d]
+	}
+	norm := dot(v, v)
+	projections := make([]float64, len(points))
+	if norm == 0 {
+		return projections
+	}
+	for i, p := range points {
+		projections[i] = dot(p, v) / norm
+	}
+	return projections
+}
+
+// andersonDarling returns the Anderson-Darling statistic of values for the
+// hypothesis that they are drawn from a normal distribution with unknown mean
+// and variance, including the small sample correction. Constant values score
+// zero.
+func andersonDarling(values []float64) float64 {
+	n := float64(len(values))
+
+	// Standardize the values
+	m := 0.0
+	for _, x := range values {
+		m += x
+	}
+	m /= n
+	variance := 0.0
+	for _, x := range values {
+		variance += (x - m) * (x - m)
+	}
+	stddev := math.Sqrt(variance / max(1, n-1))
+	if stddev == 0 {
+		return 0
+	}
+	z := make([]float64, len(values))
+	for i, x := range values {
+		z[i] = (x - m) / stddev
+	}
+	slices.Sort(z)
+
+	// Compare the empirical distribution to the standard normal one
+	cdf := func(x float64) float64 {
+		return min(max(0.5*math.Erfc(-x/math.Sqrt2), 1e-300), 1-1e-16)
+	}
+	sum := 0.0
+	for i := range z {
+		sum += float64(2*i+1) * (math.Log(cdf(z[i])) + math.Log(1-cdf(z[len(z)-1-i])))
+	}
+	a2 := -n - sum/n
+	return a2 * (1 + 4/n - 25/(n*n))
+}
@@ -0,0 +1,76 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGMeans(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}, {100, 100}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	result, err := GMeans(dataset, 1, 20, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d clusters, got %d", len(centers), len(result.Centroids))
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/100*100] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+}
+
+func TestAndersonDarling(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	gaussian := make([]float64, 500)
+	uniform := make([]float64, 500)
+	for i := range gaussian {
+		gaussian[i] = rng.NormFloat64()
+		uniform[i] = rng.Float64()
+	}
+	if a := andersonDarling(gaussian); a > gaussianCritical {
+		t.Errorf("expected Gaussian values to pass, got %v", a)
+	}
+	if a := andersonDarling(uniform); a <= gaussianCritical {
+		t.Errorf("expected uniform values to fail, got %v", a)
+	}
+	if a := andersonDarling([]float64{1, 1, 1}); a != 0 {
+		t.Errorf("expected constant values to score 0, got %v", a)
+	}
+}
+
+func TestGMeansAccelerated(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	// The search starts from a single centroid, which has no runner-up
+	for _, algorithm := range []Algorithm{Hamerly, Annulus} {
+		result, err := GMeans(dataset, 1, 10, 1e-6, 100, rand.New(rand.NewSource(1)), WithAlgorithm(algorithm))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Centroids) != len(centers) {
+			t.Errorf("algorithm %d: expected %d clusters, got %d", algorithm, len(centers), len(result.Centroids))
+		}
+	}
+}
+
+func TestGMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := GMeans(dataset, 0, 3, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid minimum k")
+	}
+	if _, err := GMeans(dataset, 1, 7, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid maximum k")
+	}
+	if _, err := GMeans(dataset, 1, 3, 1e-6, 100, rng, WithSpherical()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+	if _, err := GMeans(dataset, 1, 3, 1e-6, 100, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
@@ -0,0 +1,244 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Covariance selects the shape of the covariance matrices of a Gaussian
+// mixture.
+type Covariance int
+
+const (
+	// FullCovariance gives each component its own unrestricted covariance
+	// matrix, so components can be ellipsoids of any orientation.
+	FullCovariance Covariance = iota
+	// DiagonalCovariance restricts covariance matrices to be diagonal, so
+	// components are ellipsoids aligned with the axes. It needs fewer
+	// observations per component to estimate.
+	DiagonalCovariance
+
+	// numCovariances is the number of supported covariance types.
+	numCovariances
+)
+
+// valid reports whether c is a supported covariance type.
+func (c Covariance) valid() bool {
+	return c >= 0 && c < numCovariances
+}
+
+// MixtureResult holds the outcome of fitting a Gaussian mixture.
+type MixtureResult[T Observation] struct {
+	// Centroids holds the mean of each component.
+	Centroids [][]float64
+	// Covariances holds the covariance matrix of each component.
+	Covariances [][][]float64
+	// Weights holds the mixing weight of each component, summing to 1.
+	Weights []float64
+	// Responsibilities holds, for each observation in input order, the
+	// posterior probability that each component generated it.
+	Responsibilities [][]float64
+	// Labels holds, for each observation in input order, the component most
+	// likely to have generated it.
+	Labels []int
+	// LogLikelihood is the log-likelihood of the observations under the
+	// fitted mixture.
+	LogLikelihood float64
+	// Iterations is the number of EM iterations executed.
+	Iterations int
+	// Converged reports whether the average log-likelihood per observation
+	// improved by less than the delta threshold before the iteration
+	// threshold was reached.
+	Converged bool
+}
+
+// GaussianMixture fits a mixture of k Gaussian components with the
+// expectation-maximisation algorithm. The components start from the clusters
+// found by k-means, then each iteration computes the responsibilities of the
+// components for every observation (E step) and re-estimates their weights,
+// means and covariances from them (M step), until the average log-likelihood
+// per observation improves by less than deltaThreshold or iterationThreshold
+// iterations ran.
+//
+// The shape of the covariance matrices is set with WithCovariance (default
+// FullCovariance). A small multiple of their trace is added to their diagonal
+// so clusters lying in a subspace stay well defined. Initial centroids are
+// chosen as in Cluster; it honours WithWorkers and the weights of weighted
+// observations and ignores WithDistance.
+func GaussianMixture[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*MixtureResult[T], error) {
+	cfg, points, err := newFuzzyRun(dataset, k, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate covariance type
+	if !cfg.covariance.valid() {
+		return nil, fmt.Errorf("invalid covariance type: %d", cfg.covariance)
+	}
+	cfg.distance = nil
+
+	// Start from the k-means clusters
+	labels := make([]int, len(points))
+	lloydLoop(points, initCentroids(points, k, cfg, rng), labels, deltaThreshold, iterationThreshold, cfg)
+	responsibilities := newMatrix(len(points), k)
+	for i, j := range labels {
+		responsibilities[i][j] = 1
+	}
+	total := 0.0
+	for i := range points {
+		total += cfg.weight(i)
+	}
+
+	result := &MixtureResult[T]{Responsibilities: responsibilities}
+	components := maximization(points, responsibilities, nil, cfg)
+	previous := math.Inf(-1)
+	for range iterationThreshold {
+		result.Iterations++
+		logLikelihood := components.expectation(points, responsibilities, cfg)
+		components = maximization(points, responsibilities, components, cfg)
+		if (logLikelihood-previous)/total < deltaThreshold {
+			result.Converged = true
+			break
+		}
+		previous = logLikelihood
+	}
+
+	// Responsibilities of the final components
+	result.LogLikelihood = components.expectation(points, responsibilities, cfg)
+	result.Centroids = components.means
+	result.Covariances = components.covariances
+	result.Weights = components.weights
+	result.Labels = hardLabels(responsibilities)
+	return result, nil
+}
+
+// mixture holds the parameters of Gaussian components, with the inverse and
+// the log-determinant of each covariance matrix.
+type mixture struct {
+	weights     []float64
+	means       [][]float64
+	covariances [][][]float64
+	inverses    [][][]float64
+	logDets     []float64
+}
+
+// maximization estimates the components from the responsibilities of the
+// points, weighted by their weights in weighted runs. A component without
+// responsibility keeps its previous parameters, or is left out of the
+// mixture when there are none.
+func maximization(points, responsibilities [][]float64, previous *mixture, cfg *config) *mixture {
+	k, dim := len(responsibilities[0]), len(points[0])
+	m := &mixture{
+		weights:     make([]float64, k),
+		means:       newMatrix(k, dim),
+		covariances: make([][][]float64, k),
+		inverses:    make([][][]float64, k),
+		logDets:     make([]float64, k),
+	}
+
+	// Weights and means
+	total := 0.0
+	for i, p := range points {
+		w := cfg.weight(i)
+		total += w
+		for j, r := range responsibilities[i] {
+			m.weights[j] += w * r
+			for d, x := range p {
+				m.means[j][d] += w * r * x
+			}
+		}
+	}
+
+	for j := range k {
+		if !(m.weights[j] > 0) {
+			if previous != nil {
+				m.means[j] = previous.means[j]
+				m.covariances[j] = previous.covariances[j]
+				m.inverses[j] = previous.inverses[j]
+				m.logDets[j] = previous.logDets[j]
+			}
+			continue
+		}
+		for d := range dim {
+			m.means[j][d] /= m.weights[j]
+		}
+
+		// Covariance around the new mean
+		cov := newMatrix(dim, dim)
+		for i, p := range points {
+			r := cfg.weight(i) * responsibilities[i][j]
+			if r == 0 {
+				continue
+			}
+			for a := range dim {
+				da := p[a] - m.means[j][a]
+				if cfg.covariance == DiagonalCovariance {
+					cov[a][a] += r * da * da
+					continue
+				}
+				for b := a; b < dim; b++ {
+					cov[a][b] += r * da * (p[b] - m.means[j][b])
+				}
+			}
+		}
+		trace := 0.0
+		for a := range dim {
+			for b := a; b < dim; b++ {
+				cov[a][b] /= m.weights[j]
+				cov[b][a] = cov[a][b]
+			}
+			trace += cov[a][a]
+		}
+		for a := range dim {
+			cov[a][a] += max(1e-6*trace/float64(dim), 1e-12)
+		}
+		m.covariances[j] = cov
+		inverse, det := invert(cov)
+		m.inverses[j] = inverse
+		m.logDets[j] = math.Log(det)
+	}
+	for j := range k {
+		m.weights[j] /= total
+	}
+	return m
+}
+
+// expectation sets the responsibility of every component for every point and
+// returns the log-likelihood of the points, weighted by their weights in
+// weighted runs.
+func (m *mixture) expectation(points, responsibilities [][]float64, cfg *config) float64 {
+	dim := float64(len(points[0]))
+	logLikelihoods := make([]float64, len(points))
+	parallel(len(points), cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			r := responsibilities[i]
+
+			// Log of the weighted density of each component
+			best := math.Inf(-1)
+			for j := range r {
+				r[j] = math.Inf(-1)
+				if m.weights[j] > 0 && m.inverses[j] != nil {
+					r[j] = math.Log(m.weights[j]) - (dim*math.Log(2*math.Pi)+m.logDets[j]+quadraticForm(points[i], m.means[j], m.inverses[j]))/2
+				}
+				best = max(best, r[j])
+			}
+
+			// Normalise with the log-sum-exp trick
+			sum := 0.0
+			for j := range r {
+				r[j] = math.Exp(r[j] - best)
+				sum += r[j]
+			}
+			for j := range r {
+				r[j] /= sum
+			}
+			logLikelihoods[i] = best + math.Log(sum)
+		}
+	})
+	logLikelihood := 0.0
+	for i, l := range logLikelihoods {
+		logLikelihood += cfg.weight(i) * l
+	}
+	return logLikelihood
+}
@@ -0,0 +1,105 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// ellipses returns n observations drawn around each of centers, stretched by
+// scales along the diagonal directions.
+func ellipses(rng *rand.Rand, centers []Vector, n int, major, minor float64) []Vector {
+	var dataset []Vector
+	for j, center := range centers {
+		// Alternate the orientation of the long axis between clusters
+		sign := float64(1 - 2*(j%2))
+		for range n {
+			a, b := rng.NormFloat64()*major, rng.NormFloat64()*minor
+			dataset = append(dataset, Vector{
+				center[0] + (a-b)/math.Sqrt2,
+				center[1] + sign*(a+b)/math.Sqrt2,
+			})
+		}
+	}
+	return dataset
+}
+
+func TestGaussianMixture(t *testing.T) {
+	dataset := ellipses(rand.New(rand.NewSource(0)), []Vector{{0, 0}, {20, 0}}, 200, 5, 0.5)
+
+	result, err := GaussianMixture(dataset, 2, 1e-9, 200, rand.New(rand.NewSource(1)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Converged {
+		t.Error("expected the run to converge")
+	}
+	errors := 0
+	for i, label := range result.Labels {
+		if label != result.Labels[i/200*200] {
+			errors++
+		}
+	}
+	if result.Labels[0] == result.Labels[200] || errors > 4 {
+		t.Errorf("expected the two ellipses as components, got %d misplaced observations", errors)
+	}
+	for j, w := range result.Weights {
+		if math.Abs(w-0.5) > 0.02 {
+			t.Errorf("expected weight 0.5 for component %d, got %v", j, w)
+		}
+	}
+
+	// The covariance of the first component is that of the first ellipse
+	points, _ := materialize(dataset[:200])
+	expected := covariance(points, mean(points))
+	cov := result.Covariances[result.Labels[0]]
+	if math.Abs(cov[0][1]-expected[0][1]) > 0.5 || cov[0][1] < 10 {
+		t.Errorf("expected covariance %v, got %v", expected[0][1], cov[0][1])
+	}
+
+	// Responsibilities are probabilities
+	for i, r := range result.Responsibilities {
+		if math.Abs(r[0]+r[1]-1) > 1e-9 {
+			t.Fatalf("responsibilities of observation %d sum to %v", i, r[0]+r[1])
+		}
+	}
+}
+
+func TestGaussianMixtureDiagonal(t *testing.T) {
+	dataset := ellipses(rand.New(rand.NewSource(0)), []Vector{{0, 0}, {20, 0}}, 200, 5, 0.5)
+	rng := rand.New(rand.NewSource(1))
+
+	full, err := GaussianMixture(dataset, 2, 1e-9, 200, rng, WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diagonal, err := GaussianMixture(dataset, 2, 1e-9, 200, rng, WithInit(InitKMeansPlusPlus), WithCovariance(DiagonalCovariance))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cov := range diagonal.Covariances {
+		if cov[0][1] != 0 || cov[1][0] != 0 {
+			t.Errorf("expected a diagonal covariance, got %v", cov)
+		}
+	}
+	if diagonal.LogLikelihood >= full.LogLikelihood {
+		t.Errorf("expected diagonal log-likelihood %v below full %v", diagonal.LogLikelihood, full.LogLikelihood)
+	}
+}
+
+func TestGaussianMixtureValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := GaussianMixture(dataset, 0, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := GaussianMixture(dataset, 2, 1e-6, 100, rng, WithCovariance(Covariance(-1))); err == nil {
+		t.Error("expected error for invalid covariance type")
+	}
+	if _, err := GaussianMixture(dataset, 2, 1e-6, 100, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := GaussianMixture(dataset, 2, 1e-6, 100, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
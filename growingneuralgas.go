@@ -0,0 +1,291 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Learning rates and error factors of growing neural gas, the values proposed
+// by Fritzke.
+const (
+	gngWinnerRate     = 0.2
+	gngNeighborRate   = 0.006
+	gngInsertionDecay = 0.5
+	gngErrorDecay     = 0.995
+)
+
+// GrowingNeuralGas learns the topology of a stream of observations with
+// Fritzke's growing neural gas: a graph of units that follows the data. Each
+// observation moves its nearest unit, and the graph neighbours of that unit,
+// towards it and connects the two nearest units. Edges not refreshed within
+// maxAge observations of their endpoint are removed, along with the units left
+// without edges, and every interval observations a unit is inserted where the
+// accumulated quantisation error is the largest, up to maxUnits units.
+//
+// Connected components of the graph form clusters of any shape, and the
+// distance of an observation to its nearest unit scores how anomalous it is.
+// A GrowingNeuralGas is safe for concurrent use.
+type GrowingNeuralGas[T Observation] struct {
+	mu       sync.Mutex
+	maxUnits int
+	interval int
+	maxAge   int
+	seen     int
+	units    [][]float64
+	errors   []float64
+	ages     map[[2]int]int
+}
+
+// NewGrowingNeuralGas returns an empty GrowingNeuralGas growing up to
+// maxUnits units, at least 2, inserting a unit every interval observations
+// and removing edges older than maxAge observations.
+func NewGrowingNeuralGas[T Observation](maxUnits, interval, maxAge int) (*GrowingNeuralGas[T], error) {
+	// Validate maxUnits, interval and maxAge
+	if maxUnits < 2 {
+		return nil, fmt.Errorf("invalid maximum number of units: %d", maxUnits)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid insertion interval: %d", interval)
+	}
+	if maxAge <= 0 {
+		return nil, fmt.Errorf("invalid maximum edge age: %d", maxAge)
+	}
+	return &GrowingNeuralGas[T]{
+		maxUnits: maxUnits,
+		interval: interval,
+		maxAge:   maxAge,
+		ages:     map[[2]int]int{},
+	}, nil
+}
+
+// Add absorbs observations in order. The first two observations become the
+// first two units. It fails if an observation does not have the dimension of
+// the previous ones, in which case the observations before it have already
+// been absorbed.
+func (g *GrowingNeuralGas[T]) Add(observations ...T) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, obs := range observations {
+		point := slices.Clone(obs.Coordinates())
+		if len(g.units) > 0 && len(point) != len(g.units[0]) {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+		g.seen++
+
+		// Seed the graph with the first observations
+		if len(g.units) < 2 {
+			g.units = append(g.units, point)
+			g.errors = append(g.errors, 0)
+			continue
+		}
+		g.adapt(point)
+		if g.seen%g.interval == 0 && len(g.units) < g.maxUnits {
+			g.insert()
+		}
+		for u := range g.errors {
+			g.errors[u] *= gngErrorDecay
+		}
+	}
+	return nil
+}
+
+// Consume absorbs observations one at a time until the channel is closed.
+// It stops at the first error returned by Add.
+func (g *GrowingNeuralGas[T]) Consume(observations <-chan T) error {
+	for obs := range observations {
+		if err := g.Add(obs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Units returns a copy of the weights of the units.
+func (g *GrowingNeuralGas[T]) Units() [][]float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return cloneAll(g.units)
+}
+
+// Edges returns the edges of the graph as pairs of indices in Units, the
+// smaller first, in increasing order.
+func (g *GrowingNeuralGas[T]) Edges() [][2]int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	edges := make([][2]int, 0, len(g.ages))
+	for e := range g.ages {
+		edges = append(edges, e)
+	}
+	slices.SortFunc(edges, func(a, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	return edges
+}
+
+// Components returns, for each unit, the index of the connected component of
+// the graph it belongs to, numbered by their first unit. Components are the
+// clusters learnt by the graph.
+func (g *GrowingNeuralGas[T]) Components() []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	labels := make([]int, len(g.units))
+	for u := range labels {
+		labels[u] = -1
+	}
+	k := 0
+	for u := range labels {
+		if labels[u] != -1 {
+			continue
+		}
+		labels[u] = k
+		stack := []int{u}
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range g.neighbors(v) {
+				if labels[w] == -1 {
+					labels[w] = k
+					stack = append(stack, w)
+				}
+			}
+		}
+		k++
+	}
+	return labels
+}
+
+// Predict returns the index in Units of the unit nearest to obs and its
+// Euclidean distance to obs, which is large for anomalous observations.
+func (g *GrowingNeuralGas[T]) Predict(obs T) (int, float64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.units) == 0 {
+		return 0, 0, fmt.Errorf("no observation seen")
+	}
+	point := obs.Coordinates()
+	if len(point) != len(g.units[0]) {
+		return 0, 0, fmt.Errorf("inconsistent dimensions")
+	}
+	u, d := nearest(point, g.units, EuclideanDistance)
+	return u, d, nil
+}
+
+// adapt moves the nearest unit to point and its neighbours towards it, ages
+// and prunes the edges of the nearest unit and connects it to the second
+// nearest.
+func (g *GrowingNeuralGas[T]) adapt(point []float64) {
+	first, second := -1, -1
+	d1, d2 := math.Inf(1), math.Inf(1)
+	for u, unit := range g.units {
+		switch d := squaredDistance(point, unit); {
+		case d < d1:
+			first, second, d1, d2 = u, first, d, d1
+		case d < d2:
+			second, d2 = u, d
+		}
+	}
+
+	g.errors[first] += d1
+	move := func(unit []float64, rate float64) {
+		for d := range unit {
+			unit[d] += rate * (point[d] - unit[d])
+		}
+	}
+	move(g.units[first], gngWinnerRate)
+	for _, u := range g.neighbors(first) {
+		move(g.units[u], gngNeighborRate)
+		g.ages[edge(first, u)]++
+	}
+	g.ages[edge(first, second)] = 0
+
+	// Remove old edges, then the units they leave isolated
+	for e, age := range g.ages {
+		if age > g.maxAge {
+			delete(g.ages, e)
+		}
+	}
+	degrees := make([]int, len(g.units))
+	for e := range g.ages {
+		degrees[e[0]]++
+		degrees[e[1]]++
+	}
+	for u := len(g.units) - 1; u >= 0; u-- {
+		if degrees[u] == 0 {
+			g.remove(u)
+			degrees[u] = degrees[len(degrees)-1]
+			degrees = degrees[:len(degrees)-1]
+		}
+	}
+}
+
+// insert adds a unit halfway between the unit with the largest error and its
+// neighbour with the largest error, splitting the edge between them.
+func (g *GrowingNeuralGas[T]) insert() {
+	q := 0
+	for u := range g.errors {
+		if g.errors[u] > g.errors[q] {
+			q = u
+		}
+	}
+	f := -1
+	for _, u := range g.neighbors(q) {
+		if f == -1 || g.errors[u] > g.errors[f] {
+			f = u
+		}
+	}
+	if f == -1 {
+		return
+	}
+
+	r := len(g.units)
+	unit := make([]float64, len(g.units[q]))
+	for d := range unit {
+		unit[d] = (g.units[q][d] + g.units[f][d]) / 2
+	}
+	g.units = append(g.units, unit)
+	delete(g.ages, edge(q, f))
+	g.ages[edge(q, r)] = 0
+	g.ages[edge(r, f)] = 0
+	g.errors[q] *= gngInsertionDecay
+	g.errors[f] *= gngInsertionDecay
+	g.errors = append(g.errors, g.errors[q])
+}
+
+// remove deletes unit u, which has no edges, moving the last unit to its
+// index.
+func (g *GrowingNeuralGas[T]) remove(u int) {
+	last := len(g.units) - 1
+	if u != last {
+		g.units[u], g.errors[u] = g.units[last], g.errors[last]
+		for _, v := range g.neighbors(last) {
+			g.ages[edge(u, v)] = g.ages[edge(last, v)]
+			delete(g.ages, edge(last, v))
+		}
+	}
+	g.units = g.units[:last]
+	g.errors = g.errors[:last]
+}
+
+// neighbors returns the units connected to u.
+func (g *GrowingNeuralGas[T]) neighbors(u int) []int {
+	var found []int
+	for e := range g.ages {
+		switch u {
+		case e[0]:
+			found = append(found, e[1])
+		case e[1]:
+			found = append(found, e[0])
+		}
+	}
+	return found
+}
+
+// edge returns the key of the edge between units a and b.
+func edge(a, b int) [2]int {
+	return [2]int{min(a, b), max(a, b)}
+}
@@ -0,0 +1,90 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGrowingNeuralGas(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	blobs := gaussians(rng, []Vector{{0, 0}, {100, 100}}, 500, 3)
+	g, err := NewGrowingNeuralGas[Vector](20, 50, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Stream the blobs interleaved, in several passes
+	observations := make(chan Vector)
+	go func() {
+		defer close(observations)
+		for range 5 {
+			for _, i := range rng.Perm(len(blobs)) {
+				observations <- blobs[i]
+			}
+		}
+	}()
+	if err := g.Consume(observations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	units := g.Units()
+	if len(units) != 20 {
+		t.Fatalf("expected 20 units, got %d", len(units))
+	}
+	for _, e := range g.Edges() {
+		if e[0] >= e[1] || e[1] >= len(units) {
+			t.Fatalf("invalid edge %v", e)
+		}
+	}
+
+	// The graph learns one component per blob. Units inserted between the
+	// blobs early on may survive in neither.
+	components := g.Components()
+	first, _, _ := g.Predict(Vector{0, 0})
+	second, _, _ := g.Predict(Vector{100, 100})
+	if components[first] == components[second] {
+		t.Fatal("expected the blobs in different components")
+	}
+	for u, unit := range units {
+		switch {
+		case EuclideanDistance(unit, []float64{0, 0}) < 15 && components[u] != components[first],
+			EuclideanDistance(unit, []float64{100, 100}) < 15 && components[u] != components[second]:
+			t.Fatalf("unit %v in the wrong component", unit)
+		}
+	}
+
+	// Observations away from the data are farther from their nearest unit
+	_, inlier, err := g.Predict(Vector{1, -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, outlier, _ := g.Predict(Vector{0, 100})
+	if outlier < 5*inlier {
+		t.Fatalf("expected outlier distance %f well above inlier distance %f", outlier, inlier)
+	}
+}
+
+func TestGrowingNeuralGasValidation(t *testing.T) {
+	if _, err := NewGrowingNeuralGas[Vector](1, 10, 10); err == nil {
+		t.Error("expected error for invalid maximum number of units")
+	}
+	if _, err := NewGrowingNeuralGas[Vector](10, 0, 10); err == nil {
+		t.Error("expected error for invalid insertion interval")
+	}
+	if _, err := NewGrowingNeuralGas[Vector](10, 10, 0); err == nil {
+		t.Error("expected error for invalid maximum edge age")
+	}
+	g, err := NewGrowingNeuralGas[Vector](10, 10, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := g.Predict(Vector{0, 0}); err == nil {
+		t.Error("expected error before any observation")
+	}
+	if err := g.Add(Vector{0, 0}, Vector{1, 1, 1}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, _, err := g.Predict(Vector{0, 0, 0}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
@@ -0,0 +1,167 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+)
+
+// GustafsonKessel implements Gustafson-Kessel clustering, a fuzzy c-means
+// where each cluster measures distances with its own norm derived from its
+// fuzzy covariance matrix F, scaled to a unit volume: (x-v)ᵀ det(F)^(1/d) F⁻¹
+// (x-v). Clusters adapt to ellipsoids of different orientations and
+// elongations instead of spheres. A nearly singular covariance, such as that
+// of a flat cluster, is regularised towards a sphere.
+//
+// It takes the same options as FuzzyCMeans except WithDistance, and fills the
+// Covariances of the result.
+func GustafsonKessel[T Observation](dataset []T, c int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*FuzzyResult[T], error) {
+	cfg, points, err := newFuzzyRun(dataset, c, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+	cfg.distance = nil
+	m := cfg.fuzzifierOrDefault()
+	dim := len(points[0])
+
+	// Start from Euclidean memberships to the initial centroids
+	centroids := initCentroids(points, c, cfg, rng)
+	memberships := newMatrix(len(points), c)
+	fuzzyMemberships(points, centroids, memberships, m, cfg)
+
+	result := &FuzzyResult[T]{Memberships: memberships}
+	var covariances [][][]float64
+	squared := newMatrix(len(points), c)
+	for range iterationThreshold {
+		result.Iterations++
+
+		// Centroids, covariances and norms from the memberships
+		newCentroids := fuzzyCentroids(points, memberships, centroids, m, cfg)
+		covariances = fuzzyCovariances(points, memberships, newCentroids, m, cfg)
+		norms := make([][][]float64, c)
+		for j, cov := range covariances {
+			norms[j] = clusterNorm(cov, dim)
+		}
+
+		// Memberships from the squared distances in each cluster's norm
+		parallel(len(points), cfg.workerCount(), func(_, start, end int) {
+			for i := start; i < end; i++ {
+				for j := range newCentroids {
+					squared[i][j] = max(0, quadraticForm(points[i], newCentroids[j], norms[j]))
+				}
+				membershipsFromSquared(squared[i], memberships[i], m)
+			}
+		})
+
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			result.Converged = true
+			break
+		}
+	}
+
+	for i := range points {
+		for j, u := range memberships[i] {
+			result.Objective += cfg.weight(i) * math.Pow(u, m) * squared[i][j]
+		}
+	}
+	result.Centroids = centroids
+	result.Covariances = covariances
+	result.Labels = hardLabels(memberships)
+	return result, nil
+}
+
+// fuzzyCovariances returns the covariance matrix of the points around each
+// centroid, weighted by their memberships raised to m and by their weights in
+// weighted runs.
+func fuzzyCovariances(points, memberships, centroids [][]float64, m float64, cfg *config) [][][]float64 {
+	dim := len(points[0])
+	covariances := make([][][]float64, len(centroids))
+	for j, v := range centroids {
+		cov := newMatrix(dim, dim)
+		total := 0.0
+		diff := make([]float64, dim)
+		for i, p := range points {
+			w := cfg.weight(i) * math.Pow(memberships[i][j], m)
+			if w == 0 {
+				continue
+			}
+			total += w
+			for a := range dim {
+				diff[a] = p[a] - v[a]
+			}
+			for a := range dim {
+				for b := a; b < dim; b++ {
+					cov[a][b] += w * diff[a] * diff[b]
+				}
+			}
+		}
+		for a := range dim {
+			for b := a; b < dim; b++ {
+				if total > 0 {
+					cov[a][b] /= total
+				}
+				cov[b][a] = cov[a][b]
+			}
+		}
+		covariances[j] = cov
+	}
+	return covariances
+}
+
+// clusterNorm returns the Gustafson-Kessel norm matrix det(F)^(1/d) F⁻¹ of a
+// cluster of covariance F in dim dimensions. The condition of F is bounded by
+// raising its diagonal, and a degenerate F yields the identity.
+func clusterNorm(cov [][]float64, dim int) [][]float64 {
+	trace := 0.0
+	for a := range dim {
+		trace += cov[a][a]
+	}
+	regularised := newMatrix(dim, dim)
+	for a := range dim {
+		copy(regularised[a], cov[a])
+		regularised[a][a] += 1e-6 * trace / float64(dim)
+	}
+	inverse, det := invert(regularised)
+	if inverse == nil || !(det > 0) {
+		identity := newMatrix(dim, dim)
+		for a := range dim {
+			identity[a][a] = 1
+		}
+		return identity
+	}
+	scale := math.Pow(det, 1/float64(dim))
+	for a := range dim {
+		for b := range dim {
+			inverse[a][b] *= scale
+		}
+	}
+	return inverse
+}
+
+// membershipsFromSquared sets the fuzzy c-means memberships u of a point from
+// its squared distances to every centroid. A point on one or more centroids
+// belongs to them equally.
+func membershipsFromSquared(squared, u []float64, m float64) {
+	exponent := 1 / (m - 1)
+	zeros := 0
+	for _, d := range squared {
+		if d == 0 {
+			zeros++
+		}
+	}
+	for j := range u {
+		switch {
+		case zeros > 0 && squared[j] == 0:
+			u[j] = 1 / float64(zeros)
+		case zeros > 0:
+			u[j] = 0
+		default:
+			sum := 0.0
+			for _, d := range squared {
+				sum += math.Pow(squared[j]/d, exponent)
+			}
+			u[j] = 1 / sum
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGustafsonKessel(t *testing.T) {
+	// Two long parallel clusters, closer to each other than they are long
+	rng := rand.New(rand.NewSource(0))
+	var dataset []Vector
+	for i := range 100 {
+		x := float64(i)/5 - 10
+		dataset = append(dataset, Vector{x, rng.NormFloat64() * 0.2})
+	}
+	for i := range 100 {
+		x := float64(i)/5 - 10
+		dataset = append(dataset, Vector{x, 3 + rng.NormFloat64()*0.2})
+	}
+
+	result, err := GustafsonKessel(dataset, 2, 1e-6, 300, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each elongated cluster is recovered, where k-means would cut them across
+	for i, label := range result.Labels {
+		if label != result.Labels[i/100*100] {
+			t.Fatalf("observation %v not with its line: %v", dataset[i], result.Labels)
+		}
+	}
+	if result.Labels[0] == result.Labels[100] {
+		t.Error("expected the two lines in different clusters")
+	}
+	if len(result.Covariances) != 2 {
+		t.Errorf("expected 2 covariance matrices, got %d", len(result.Covariances))
+	}
+}
+
+func TestClusterNorm(t *testing.T) {
+	// The norm of an elongated cluster has a unit determinant and stretches
+	// distances across the cluster
+	norm := clusterNorm([][]float64{{4, 0}, {0, 1}}, 2)
+	if _, det := invert(norm); det < 0.999 || det > 1.001 {
+		t.Errorf("expected a unit determinant, got %v", det)
+	}
+	if norm[1][1] <= norm[0][0] {
+		t.Errorf("expected a larger weight across the cluster, got %v", norm)
+	}
+}
@@ -0,0 +1,105 @@
+package kmeans
+
+import "math"
+
+// hamerly implements Hamerly's accelerated assignment step. Each point keeps
+// an upper bound on the distance to its centroid and a single lower bound on
+// the distance to the second closest centroid, so memory stays linear in the
+// number of points whatever the number of clusters.
+type hamerly struct {
+	points  [][]float64
+	workers int
+	upper   []float64
+	lower   []float64
+	// previous holds the centroids of the previous call, nil before the first one
+	previous [][]float64
+}
+
+// newHamerly returns a Hamerly assigner for points.
+func newHamerly(points [][]float64, workers int) *hamerly {
+	return &hamerly{
+		points:  points,
+		workers: workers,
+		upper:   make([]float64, len(points)),
+		lower:   make([]float64, len(points)),
+	}
+}
+
+func (h *hamerly) assign(centroids [][]float64, labels []int) *Stats {
+	k, dim := len(centroids), len(centroids[0])
+
+	// First call: compute every distance to initialize the bounds
+	if h.previous == nil {
+		h.previous = cloneAll(centroids)
+		return shardStats(len(h.points), k, dim, h.workers, func(start, end int, partial *Stats) {
+			for i := start; i < end; i++ {
+				labels[i], h.upper[i], h.lower[i] = twoNearest(h.points[i], centroids)
+				partial.add(h.points[i], labels[i])
+			}
+		})
+	}
+
+	// How far each centroid moved, and the two largest moves
+	drift := centroidDrift(centroids, h.previous)
+	largest, second := twoLargest(drift)
+	h.previous = cloneAll(centroids)
+
+	// Half the distance from each centroid to its closest other centroid
+	closest := closestHalfDistances(centroidHalfDistances(centroids))
+
+	return shardStats(len(h.points), k, dim, h.workers, func(start, end int, partial *Stats) {
+		for i := start; i < end; i++ {
+			point := h.points[i]
+
+			// Loosen the bounds by the centroid drift
+			h.upper[i] += drift[labels[i]]
+			switch {
+			case k == 1:
+				// A single centroid has no runner-up: the lower bound stays infinite
+			case labels[i] == largest:
+				h.lower[i] -= drift[second]
+			default:
+				h.lower[i] -= drift[largest]
+			}
+
+			bound := max(closest[labels[i]], h.lower[i])
+			if h.upper[i] > bound {
+				// Tighten the upper bound, then fall back to a full search
+				h.upper[i] = EuclideanDistance(point, centroids[labels[i]])
+				if h.upper[i] > bound {
+					labels[i], h.upper[i], h.lower[i] = twoNearest(point, centroids)
+				}
+			}
+			partial.add(point, labels[i])
+		}
+	})
+}
+
+// twoLargest returns the indices of the largest and second largest values.
+func twoLargest(values []float64) (int, int) {
+	largest, second := -1, -1
+	for j := range values {
+		switch {
+		case largest < 0 || values[j] > values[largest]:
+			largest, second = j, largest
+		case second < 0 || values[j] > values[second]:
+			second = j
+		}
+	}
+	return largest, second
+}
+
+// twoNearest returns the index of the centroid closest to point, its distance
+// and the distance to the second closest centroid.
+func twoNearest(point []float64, centroids [][]float64) (int, float64, float64) {
+	best, first, second := -1, math.Inf(1), math.Inf(1)
+	for j := range centroids {
+		d := EuclideanDistance(point, centroids[j])
+		if d < first {
+			best, first, second = j, d, first
+		} else if d < second {
+			second = d
+		}
+	}
+	return best, first, second
+}
@@ -0,0 +1,98 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// harmonicPower is the power of the distances averaged by k-harmonic means,
+// the value Zhang found to work best in practice.
+const harmonicPower = 3.5
+
+// KHarmonicMeans implements Zhang's k-harmonic means, which minimises the
+// sum over observations of the harmonic average of their distances to the
+// centroids raised to the power 3.5. The harmonic average is dominated by the
+// nearest centroid, like the k-means objective, but every observation pulls
+// on every centroid, weighted more for centroids it is close to and for
+// observations no centroid is close to. A poor initial centroid is therefore
+// drawn towards the data it should cover, which makes the result much less
+// sensitive to initialization than Lloyd's algorithm. It iterates until no
+// centroid moves by deltaThreshold or more or iterationThreshold iterations
+// ran, then labels every observation with its nearest centroid.
+//
+// Initial centroids are chosen as in Cluster. The result's Inertia is the
+// k-means inertia of the labels. It honours WithDistance, WithWorkers and the
+// weights of weighted observations.
+func KHarmonicMeans[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg, points, err := newFuzzyRun(dataset, k, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	centroids := initCentroids(points, k, cfg, rng)
+	iterations, converged := 0, false
+	for range iterationThreshold {
+		iterations++
+		newCentroids := harmonicCentroids(points, centroids, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	labels := make([]int, len(points))
+	newAssigner(points, cfg).assign(centroids, labels)
+	result := newResult(dataset, points, centroids, labels, cfg.weights, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
+
+// harmonicCentroids returns the k-harmonic means update of centroids: the
+// means of all points weighted by d⁻ᵖ⁻² / (Σₗ dₗ⁻ᵖ)², d being the distance of
+// the point to the centroid. Distances are divided by the distance of the
+// point to its nearest centroid to avoid overflows, and a centroid that no
+// point pulls keeps its position.
+func harmonicCentroids(points, centroids [][]float64, cfg *config) [][]float64 {
+	distance := cfg.distanceFunc()
+	sums := newMatrix(len(centroids), len(points[0]))
+	totals := make([]float64, len(centroids))
+	ratios := make([]float64, len(centroids))
+	for i, p := range points {
+		// Floor distances so that points on a centroid stay finite
+		nearestDist := math.Inf(1)
+		for j, centroid := range centroids {
+			ratios[j] = max(distance(p, centroid), 1e-12)
+			nearestDist = min(nearestDist, ratios[j])
+		}
+		harmonic := 0.0
+		for j := range ratios {
+			ratios[j] = nearestDist / ratios[j]
+			harmonic += math.Pow(ratios[j], harmonicPower)
+		}
+		scale := cfg.weight(i) * math.Pow(nearestDist, harmonicPower-2) / (harmonic * harmonic)
+		for j, r := range ratios {
+			q := scale * math.Pow(r, harmonicPower+2)
+			totals[j] += q
+			for d, x := range p {
+				sums[j][d] += q * x
+			}
+		}
+	}
+
+	newCentroids := make([][]float64, len(centroids))
+	for j := range centroids {
+		if totals[j] == 0 {
+			newCentroids[j] = slices.Clone(centroids[j])
+			continue
+		}
+		newCentroids[j] = sums[j]
+		for d := range sums[j] {
+			newCentroids[j][d] /= totals[j]
+		}
+	}
+	return newCentroids
+}
@@ -0,0 +1,64 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKHarmonicMeans(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 3)
+
+	// Two initial centroids share the first blob and one lies between the top
+	// blobs, a local minimum of the k-means objective
+	start := WithCentroids([][]float64{{-1, 0}, {1, 0}, {50, 0}, {25, 50}})
+	result, err := KHarmonicMeans(dataset, len(centers), 0.001, 100, nil, start)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/50*50] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+	for j, cluster := range result.Clusters {
+		if len(cluster) != 50 {
+			t.Fatalf("expected 50 observations in cluster %d, got %d", j, len(cluster))
+		}
+	}
+
+	// Lloyd's algorithm stays stuck from the same centroids
+	lloyd, err := ClusterResult(dataset, len(centers), 0.001, 100, nil, start)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lloyd.Inertia <= result.Inertia {
+		t.Fatalf("expected Lloyd's inertia %f above %f", lloyd.Inertia, result.Inertia)
+	}
+}
+
+func TestKHarmonicMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := KHarmonicMeans([]Numbers{}, 2, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := KHarmonicMeans(dataset, 7, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := KHarmonicMeans(dataset, 2, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := KHarmonicMeans(dataset, 2, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := KHarmonicMeans(dataset, 2, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := KHarmonicMeans(dataset, 2, 0.01, 10, rng, WithMedians()); err == nil {
+		t.Error("expected error for custom center")
+	}
+	if _, err := KHarmonicMeans(dataset, 2, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
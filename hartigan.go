@@ -0,0 +1,65 @@
+package kmeans
+
+// hartiganWong runs Hartigan and Wong's k-means from centroids. Points are
+// first assigned to their nearest centroid, then each pass visits the points
+// in order and transfers a point of weight w from its cluster a, of total
+// weight Wa, to the cluster b minimising Wb·w/(Wb+w)·‖x-cb‖² when that is
+// below Wa·w/(Wa-w)·‖x-ca‖², the exact inertia decrease of removing it from
+// a. Both centroids are updated immediately. It stops once a pass moves no
+// centroid by deltaThreshold or more, or after iterationThreshold passes, and
+// leaves the final assignment, which every transfer improved, in labels. It
+// returns the final centroids, the number of passes and whether the run
+// converged.
+func hartiganWong(points, centroids [][]float64, labels []int, deltaThreshold float64, iterationThreshold int, cfg *config) ([][]float64, int, bool) {
+	// Start from the means of a nearest-centroid assignment
+	stats := newAssigner(points, cfg).assign(centroids, labels)
+	if cfg.weights != nil {
+		stats = weightedStats(points, cfg.weights, labels, len(centroids))
+	}
+	centroids = update(points, labels, centroids, stats, cfg)
+	totals := stats.Counts
+
+	iterations := 0
+	converged := false
+	for range iterationThreshold {
+		iterations++
+		previous := cloneAll(centroids)
+		for i, point := range points {
+			a, w := labels[i], cfg.weight(i)
+			if w == 0 || totals[a] <= w {
+				// Emptying a cluster never reduces the inertia
+				continue
+			}
+			removal := totals[a] * w / (totals[a] - w) * squaredDistance(point, centroids[a])
+			b, cost := a, removal
+			for j, centroid := range centroids {
+				if j == a {
+					continue
+				}
+				if c := totals[j] * w / (totals[j] + w) * squaredDistance(point, centroid); c < cost {
+					b, cost = j, c
+				}
+			}
+			if b == a {
+				continue
+			}
+
+			// Transfer the point, keeping both centroids the weighted means
+			// of their points
+			for d, x := range point {
+				centroids[a][d] -= w / (totals[a] - w) * (x - centroids[a][d])
+				centroids[b][d] += w / (totals[b] + w) * (x - centroids[b][d])
+			}
+			totals[a] -= w
+			totals[b] += w
+			labels[i] = b
+		}
+
+		// Stop if maximum movement over the pass is below the threshold
+		if maxDrift(previous, centroids) < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+	return centroids, iterations, converged
+}
@@ -0,0 +1,64 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestClusterHartiganWong(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 1000, 20, 2)
+
+	improved := false
+	for seed := range int64(10) {
+		lloyd, err := ClusterResult(dataset, 20, 1e-9, 300, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Transfers from a Lloyd fixed point only lower the inertia
+		result, err := ClusterResult(dataset, 20, 1e-9, 300, nil, WithAlgorithm(HartiganWong), WithCentroids(lloyd.Centroids))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Converged || result.Inertia > lloyd.Inertia+1e-6 {
+			t.Fatalf("seed %d: expected inertia at most %f, got %f", seed, lloyd.Inertia, result.Inertia)
+		}
+		improved = improved || result.Inertia < lloyd.Inertia-1e-6
+
+		// Centroids are the means of their clusters
+		for j, cluster := range result.Clusters {
+			for d := range 2 {
+				sum := 0.0
+				for _, obs := range cluster {
+					sum += obs[d]
+				}
+				if math.Abs(sum/float64(len(cluster))-result.Centroids[j][d]) > 1e-6 {
+					t.Fatalf("seed %d: centroid %d is not the mean of its cluster", seed, j)
+				}
+			}
+		}
+	}
+	if !improved {
+		t.Error("expected Hartigan-Wong to improve on some Lloyd solution")
+	}
+}
+
+func TestClusterHartiganWongWeighted(t *testing.T) {
+	dataset := []Weighted[Numbers]{{0, 1}, {1, 1}, {10, 3}, {11, 1}}
+	result, err := ClusterResult(dataset, 2, 0.01, 10, nil, WithAlgorithm(HartiganWong), WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(result.Centroids[result.Labels[2]][0]-10.25) > 1e-9 {
+		t.Errorf("expected weighted centroid 10.25, got %v", result.Centroids)
+	}
+}
+
+func TestClusterHartiganWongValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithAlgorithm(HartiganWong), WithDistance(ManhattanDistance)); err == nil {
+		t.Error("expected error for Hartigan-Wong with a custom distance")
+	}
+}
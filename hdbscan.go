@@ -0,0 +1,306 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// HDBSCAN implements Hierarchical DBSCAN. It measures distances between
+// observations as their mutual reachability: the largest of their distance
+// and of their core distances, the distance to their minPts-th nearest
+// observation, itself included. The single-linkage hierarchy of this distance
+// is condensed by treating groups smaller than minClusterSize as observations
+// falling out of their parent cluster, and the clusters that persist the most
+// across density levels, the most stable, are returned. Unlike DBSCAN it needs
+// no eps and finds clusters of different densities. The cluster of all
+// observations is never returned, so a dataset that never splits into two
+// groups of minClusterSize observations is all noise.
+//
+// The result's OutlierScores hold the GLOSH score of every observation, from
+// 0 for observations in the densest part of their cluster to nearly 1 for
+// outliers. Observations are compared with the configured distance, and time
+// and memory grow with the square of the number of observations.
+func HDBSCAN[T Observation](dataset []T, minClusterSize, minPts int, opts ...Option) (*DensityResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate minClusterSize and minPts
+	if minClusterSize < 2 {
+		return nil, fmt.Errorf("invalid minimum cluster size: %d", minClusterSize)
+	}
+	if minPts <= 0 || minPts > len(dataset) {
+		return nil, fmt.Errorf("invalid minimum number of points: %d", minPts)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	n := len(points)
+	distance := cfg.distanceFunc()
+
+	// Pairwise distances and core distances
+	dists := newMatrix(n, n)
+	parallel(n, cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				dists[i][j] = distance(points[i], points[j])
+			}
+		}
+	})
+	cores := make([]float64, n)
+	sorted := make([]float64, n)
+	for i := range n {
+		copy(sorted, dists[i])
+		slices.Sort(sorted)
+		cores[i] = sorted[minPts-1]
+	}
+
+	tree := condense(singleLinkage(dists, cores), n, minClusterSize)
+	labels, scores := tree.extract(n)
+	k := 0
+	for _, label := range labels {
+		k = max(k, label+1)
+	}
+	result := newDensityResult(dataset, labels, k)
+	result.OutlierScores = scores
+	return result, nil
+}
+
+// singleLinkage returns the single-linkage hierarchy of the points under
+// mutual reachability, from their pairwise distances and core distances, by
+// merging the edges of its minimum spanning tree in increasing order.
+func singleLinkage(dists [][]float64, cores []float64) []Merge {
+	n := len(dists)
+	reachability := func(i, j int) float64 {
+		return max(dists[i][j], cores[i], cores[j])
+	}
+
+	// Prim's algorithm over the complete graph
+	type edge struct {
+		a, b     int
+		distance float64
+	}
+	edges := make([]edge, 0, n-1)
+	inTree := make([]bool, n)
+	best := make([]float64, n)
+	from := make([]int, n)
+	for i := range best {
+		best[i] = math.Inf(1)
+	}
+	current := 0
+	for range n - 1 {
+		inTree[current] = true
+		next := -1
+		for i := range n {
+			if inTree[i] {
+				continue
+			}
+			if d := reachability(current, i); d < best[i] {
+				best[i], from[i] = d, current
+			}
+			if next == -1 || best[i] < best[next] {
+				next = i
+			}
+		}
+		edges = append(edges, edge{from[next], next, best[next]})
+		current = next
+	}
+	slices.SortStableFunc(edges, func(x, y edge) int {
+		return cmp.Compare(x.distance, y.distance)
+	})
+
+	// Merge the components joined by each edge
+	parents := make([]int, n)
+	nodes := make([]int, n)
+	sizes := make([]int, n)
+	for i := range n {
+		parents[i], nodes[i], sizes[i] = i, i, 1
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	merges := make([]Merge, 0, n-1)
+	for _, e := range edges {
+		a, b := find(e.a), find(e.b)
+		merges = append(merges, Merge{nodes[a], nodes[b], e.distance, sizes[a] + sizes[b]})
+		parents[b] = a
+		sizes[a] += sizes[b]
+		nodes[a] = n + len(merges) - 1
+	}
+	return merges
+}
+
+// condensedTree is a single-linkage hierarchy where groups smaller than the
+// minimum cluster size are replaced by points falling out of their cluster.
+// Cluster 0 is the root and every cluster comes after its parent.
+type condensedTree struct {
+	// parents holds the parent of each cluster, -1 for the root.
+	parents []int
+	// births holds the density λ, the inverse of the distance, at which each
+	// cluster appears.
+	births []float64
+	// pointClusters and pointLambdas hold the cluster each point falls out
+	// of and the density at which it does.
+	pointClusters []int
+	pointLambdas  []float64
+}
+
+// lambda returns the density level of distance, its inverse, kept finite for
+// duplicate observations.
+func lambda(distance float64) float64 {
+	return 1 / max(distance, 1e-300)
+}
+
+// condense builds the condensed tree of the single-linkage hierarchy merges
+// over n points.
+func condense(merges []Merge, n, minClusterSize int) *condensedTree {
+	tree := &condensedTree{
+		parents:       []int{-1},
+		births:        []float64{0},
+		pointClusters: make([]int, n),
+		pointLambdas:  make([]float64, n),
+	}
+	size := func(node int) int {
+		if node < n {
+			return 1
+		}
+		return merges[node-n].Size
+	}
+
+	// fallOut records that every point below node leaves cluster at level
+	fallOut := func(node, cluster int, level float64) {
+		stack := []int{node}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if node < n {
+				tree.pointClusters[node], tree.pointLambdas[node] = cluster, level
+				continue
+			}
+			stack = append(stack, merges[node-n].Left, merges[node-n].Right)
+		}
+	}
+
+	// Walk the hierarchy from the root with the cluster each node belongs to
+	if n == 1 {
+		return tree
+	}
+	type visit struct{ node, cluster int }
+	stack := []visit{{n + len(merges) - 1, 0}}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		m := merges[v.node-n]
+		level := lambda(m.Distance)
+		leftBig, rightBig := size(m.Left) >= minClusterSize, size(m.Right) >= minClusterSize
+		switch {
+		case leftBig && rightBig:
+			// A true split: both sides become new clusters
+			for _, child := range []int{m.Left, m.Right} {
+				tree.parents = append(tree.parents, v.cluster)
+				tree.births = append(tree.births, level)
+				stack = append(stack, visit{child, len(tree.parents) - 1})
+			}
+		case leftBig:
+			fallOut(m.Right, v.cluster, level)
+			stack = append(stack, visit{m.Left, v.cluster})
+		case rightBig:
+			fallOut(m.Left, v.cluster, level)
+			stack = append(stack, visit{m.Right, v.cluster})
+		default:
+			fallOut(m.Left, v.cluster, level)
+			fallOut(m.Right, v.cluster, level)
+		}
+	}
+	return tree
+}
+
+// extract selects the clusters of the condensed tree maximising the total
+// stability, excluding the root, and returns the label of every point, Noise
+// outside the selected clusters, and its GLOSH outlier score.
+func (t *condensedTree) extract(n int) ([]int, []float64) {
+	clusters := len(t.parents)
+
+	// Stability of each cluster: the sum over its points, and over the points
+	// of its children, of how long past its birth they remain in it
+	stabilities := make([]float64, clusters)
+	deaths := make([]float64, clusters)
+	for i := range n {
+		c := t.pointClusters[i]
+		stabilities[c] += t.pointLambdas[i] - t.births[c]
+		deaths[c] = max(deaths[c], t.pointLambdas[i])
+	}
+	sizes := make([]int, clusters)
+	for i := range n {
+		for c := t.pointClusters[i]; c > 0; c = t.parents[c] {
+			sizes[c]++
+		}
+	}
+	for c := 1; c < clusters; c++ {
+		p := t.parents[c]
+		stabilities[p] += float64(sizes[c]) * (t.births[c] - t.births[p])
+	}
+
+	// Highest density reached within each cluster and its descendants
+	for c := clusters - 1; c > 0; c-- {
+		deaths[t.parents[c]] = max(deaths[t.parents[c]], deaths[c])
+	}
+
+	// Keep a cluster unless its children are together more stable
+	selected := make([]bool, clusters)
+	children := make([]float64, clusters)
+	for c := clusters - 1; c > 0; c-- {
+		if children[c] > stabilities[c] {
+			stabilities[c] = children[c]
+		} else {
+			selected[c] = true
+		}
+		children[t.parents[c]] += stabilities[c]
+	}
+	for c := 1; c < clusters; c++ {
+		// A selected ancestor takes precedence over its descendants
+		for p := t.parents[c]; p > 0; p = t.parents[p] {
+			if selected[p] {
+				selected[c] = false
+				break
+			}
+		}
+	}
+
+	// Number the selected clusters and label the points
+	numbers := make([]int, clusters)
+	k := 0
+	for c := range clusters {
+		numbers[c] = Noise
+		if selected[c] {
+			numbers[c] = k
+			k++
+		}
+	}
+	labels := make([]int, n)
+	scores := make([]float64, n)
+	for i := range n {
+		labels[i] = Noise
+		for c := t.pointClusters[i]; c > 0; c = t.parents[c] {
+			if selected[c] {
+				labels[i] = numbers[c]
+				break
+			}
+		}
+		if death := deaths[t.pointClusters[i]]; death > 0 {
+			scores[i] = (death - t.pointLambdas[i]) / death
+		}
+	}
+	return labels, scores
+}
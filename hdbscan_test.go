@@ -0,0 +1,66 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHDBSCAN(t *testing.T) {
+	// Clusters of different densities and two outliers
+	rng := rand.New(rand.NewSource(0))
+	dataset := append(gaussians(rng, []Vector{{0, 0}, {2, 2}}, 50, 0.2), gaussians(rng, []Vector{{30, 30}}, 50, 3)...)
+	dataset = append(dataset, Vector{-40, 40}, Vector{40, -40})
+
+	result, err := HDBSCAN(dataset, 10, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Clusters) != 3 {
+		t.Fatalf("expected 3 clusters, got %d: %v", len(result.Clusters), result.Labels)
+	}
+	for i, label := range result.Labels[:150] {
+		if label != result.Labels[i/50*50] && label != Noise {
+			t.Fatalf("observation %v in the wrong cluster: %v", dataset[i], result.Labels)
+		}
+	}
+	if result.Labels[0] == result.Labels[50] || result.Labels[50] == result.Labels[100] {
+		t.Errorf("expected the three clusters apart, got %v", result.Labels)
+	}
+	if result.Labels[150] != Noise || result.Labels[151] != Noise {
+		t.Errorf("expected the outliers as noise, got %v", result.Labels[150:])
+	}
+
+	// Outliers score higher than every observation of the clusters
+	for i, score := range result.OutlierScores {
+		if score < 0 || score > 1 {
+			t.Fatalf("expected scores between 0 and 1, got %v", score)
+		}
+		if i < 150 && score >= min(result.OutlierScores[150], result.OutlierScores[151]) {
+			t.Errorf("observation %v scores %v, above the outliers", dataset[i], score)
+		}
+	}
+}
+
+func TestHDBSCANSingleCluster(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 4, 5}
+	result, err := HDBSCAN(dataset, 3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Clusters) != 0 || len(result.Noise) != len(dataset) {
+		t.Errorf("expected only noise, got %v", result.Labels)
+	}
+}
+
+func TestHDBSCANValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := HDBSCAN([]Numbers{}, 2, 2); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := HDBSCAN(dataset, 1, 2); err == nil {
+		t.Error("expected error for invalid minimum cluster size")
+	}
+	if _, err := HDBSCAN(dataset, 2, 7); err == nil {
+		t.Error("expected error for invalid minPts")
+	}
+}
@@ -0,0 +1,79 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Hopkins returns the Hopkins statistic of the dataset, which tells whether
+// it has a cluster structure worth looking for before tuning k. It draws m
+// points uniformly within the bounding box of the observations and m
+// observations at random, and compares the sum u of the distances from the
+// uniform points to their nearest observation with the sum w of the distances
+// from the sampled observations to their nearest other observation, returning
+// u/(u+w). It is close to 0.5 for uniformly spread observations and close to
+// 1 for clustered ones; values above 0.75 are commonly taken as a sign of
+// clusters.
+//
+// m must be less than the number of observations; 5% to 10% of them is a
+// typical choice. Distances are measured with the configured distance. It
+// honours WithWorkers.
+func Hopkins[T Observation](dataset []T, m int, rng *rand.Rand, opts ...Option) (float64, error) {
+	cfg := newConfig(opts)
+
+	// Validate m
+	if m <= 0 || m >= len(dataset) {
+		return 0, fmt.Errorf("invalid sample size: %d", m)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return 0, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return 0, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	// Uniform points within the bounding box and sampled observations
+	lo, hi := boundingBox(points)
+	uniform := make([][]float64, m)
+	for i := range uniform {
+		uniform[i] = make([]float64, len(lo))
+		for d := range lo {
+			uniform[i][d] = lo[d] + rng.Float64()*(hi[d]-lo[d])
+		}
+	}
+	sampled := randomIndices(len(points), m, rng)
+
+	distance := cfg.distanceFunc()
+	u, w := make([]float64, m), make([]float64, m)
+	parallel(m, cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			u[i], w[i] = math.Inf(1), math.Inf(1)
+			for o, p := range points {
+				u[i] = min(u[i], distance(uniform[i], p))
+				if o != sampled[i] {
+					w[i] = min(w[i], distance(points[sampled[i]], p))
+				}
+			}
+		}
+	})
+
+	sumU, sumW := 0.0, 0.0
+	for i := range m {
+		sumU += u[i]
+		sumW += w[i]
+	}
+	if sumU+sumW == 0 {
+		return 0.5, nil
+	}
+	return sumU / (sumU + sumW), nil
+}
@@ -0,0 +1,63 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHopkins(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+
+	// Clustered observations
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	clustered := gaussians(rng, centers, 100, 1)
+	h, err := Hopkins(clustered, 30, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h < 0.9 {
+		t.Errorf("expected a statistic close to 1 for clustered observations, got %f", h)
+	}
+
+	// Uniformly spread observations
+	uniform := make([]Vector, 300)
+	for i := range uniform {
+		uniform[i] = Vector{rng.Float64(), rng.Float64()}
+	}
+	h, err = Hopkins(uniform, 30, rng, WithWorkers(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h < 0.35 || h > 0.65 {
+		t.Errorf("expected a statistic close to 0.5 for uniform observations, got %f", h)
+	}
+
+	// Identical observations
+	h, err = Hopkins([]Numbers{3, 3, 3, 3}, 2, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h != 0.5 {
+		t.Errorf("expected 0.5 for identical observations, got %f", h)
+	}
+}
+
+func TestHopkinsValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Hopkins(dataset, 0, rng); err == nil {
+		t.Error("expected error for invalid sample size")
+	}
+	if _, err := Hopkins(dataset, 6, rng); err == nil {
+		t.Error("expected error for a sample as large as the dataset")
+	}
+	if _, err := Hopkins(dataset, 2, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Hopkins(dataset, 2, rng, WithWorkers(-1)); err == nil {
+		t.Error("expected error for invalid number of workers")
+	}
+	if _, err := Hopkins([]Vector{{1, 2}, {3}, {4, 5}}, 1, rng); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
@@ -0,0 +1,384 @@
+package kmeans
+
+import (
+	"cmp"
+	"math"
+	"math/bits"
+	"math/rand"
+	"slices"
+)
+
+// Init selects how the initial centroids are chosen.
+type Init int
+
+const (
+	// InitRandom picks k distinct observations uniformly at random.
+	InitRandom Init = iota
+	// InitKMeansPlusPlus picks observations with D² weighted sampling (k-means++).
+	InitKMeansPlusPlus
+	// InitKMeansParallel oversamples candidates in a few passes and reduces
+	// them to k centroids (k-means||), suited to very large datasets.
+	InitKMeansParallel
+	// InitGreedyKMeansPlusPlus samples several candidates at each k-means++
+	// step and keeps the one that most reduces the potential. The number of
+	// candidates is set with WithLocalTrials.
+	InitGreedyKMeansPlusPlus
+	// InitMaximin starts from the observation closest to the dataset mean and
+	// repeatedly adds the observation farthest from all chosen centroids. It is
+	// deterministic and does not use the random number generator.
+	InitMaximin
+	// InitPCAPartition projects the observations onto their first principal
+	// component, splits them into k contiguous groups of equal size and uses
+	// the group means as centroids. It is deterministic and does not use the
+	// random number generator.
+	InitPCAPartition
+	// InitRandomPartition assigns every observation to a random cluster and
+	// uses the cluster means as centroids.
+	InitRandomPartition
+
+	// numInits is the number of supported strategies.
+	numInits
+)
+
+// valid reports whether i is a supported strategy.
+func (i Init) valid() bool {
+	return i >= 0 && i < numInits
+}
+
+// deterministic reports whether i chooses centroids without randomness.
+func (i Init) deterministic() bool {
+	return i == InitMaximin || i == InitPCAPartition
+}
+
+// initCentroids chooses k initial centroids from points, either the ones
+// supplied with WithCentroids or using the configured strategy. The k-means++
+// strategies sample proportionally to the weights of weighted runs.
+func initCentroids(points [][]float64, k int, cfg *config, rng *rand.Rand) [][]float64 {
+	if cfg.centroids != nil {
+		centroids := make([][]float64, k)
+		for j := range centroids {
+			centroids[j] = slices.Clone(cfg.centroids[j])
+		}
+		freeze(centroids, cfg)
+		return centroids
+	}
+	if cfg.frozen != nil {
+		return extendCentroids(points, k, cfg, rng)
+	}
+	switch cfg.init {
+	case InitKMeansPlusPlus:
+		return seedPlusPlus(points, cfg.weights, k, 1, rng)
+	case InitKMeansParallel:
+		return initKMeansParallel(points, k, rng)
+	case InitGreedyKMeansPlusPlus:
+		trials := cfg.localTrials
+		if trials == 0 {
+			// Same default as scikit-learn
+			trials = 2 + int(math.Log(float64(k)))
+		}
+		return seedPlusPlus(points, cfg.weights, k, trials, rng)
+	case InitMaximin:
+		return initMaximin(points, k)
+	case InitPCAPartition:
+		return initPCAPartition(points, k)
+	case InitRandomPartition:
+		return initRandomPartition(points, k, rng)
+	default:
+		return initRandom(points, k, rng)
+	}
+}
+
+// extendCentroids completes the frozen centroids to k centroids, each new
+// one being the point farthest from the centroids chosen so far for
+// deterministic strategies, or a point drawn with probability proportional
+// to its weighted squared distance to them otherwise, as in k-means++.
+func extendCentroids(points [][]float64, k int, cfg *config, rng *rand.Rand) [][]float64 {
+	centroids := cloneAll(cfg.frozen)
+	dists := make([]float64, len(points))
+	scores := make([]float64, len(points))
+	for i, p := range points {
+		dists[i] = math.Inf(1)
+		for _, centroid := range centroids {
+			dists[i] = min(dists[i], squaredDistance(p, centroid))
+		}
+	}
+	for len(centroids) < k {
+		next := 0
+		if cfg.init.deterministic() {
+			for i := range dists {
+				if dists[i] > dists[next] {
+					next = i
+				}
+			}
+		} else {
+			for i := range points {
+				scores[i] = cfg.weight(i) * dists[i]
+				if math.IsInf(scores[i], 1) {
+					scores[i] = cfg.weight(i)
+				}
+			}
+			next = sampleIndex(scores, rng)
+		}
+		centroid := slices.Clone(points[next])
+		centroids = append(centroids, centroid)
+		for i, p := range points {
+			dists[i] = min(dists[i], squaredDistance(p, centroid))
+		}
+	}
+	return centroids
+}
+
+// initRandom selects k distinct points uniformly at random.
+func initRandom(points [][]float64, k int, rng *rand.Rand) [][]float64 {
+	indices := randomIndices(len(points), k, rng)
+	centroids := make([][]float64, k)
+	for j := range k {
+		centroids[j] = slices.Clone(points[indices[j]])
+	}
+	return centroids
+}
+
+// randomIndices returns k distinct indices below n drawn uniformly at random.
+func randomIndices(n, k int, rng *rand.Rand) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	rng.Shuffle(len(indices), func(i, j int) {
+		indices[i], indices[j] = indices[j], indices[i]
+	})
+	return indices[:k]
+}
+
+// seedPlusPlus runs k-means++ seeding over points: the first centroid is drawn
+// uniformly and each following one with probability proportional to its
+// squared distance to the nearest centroid already chosen. When weights is not
+// nil, each point's sampling probability is scaled by its weight. With more
+// than one trial, that many candidates are drawn at each step and the one
+// yielding the lowest potential is kept (greedy k-means++).
+func seedPlusPlus(points [][]float64, weights []float64, k, trials int, rng *rand.Rand) [][]float64 {
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+
+	// The first centroid is drawn proportionally to the weights alone
+	scores := make([]float64, len(points))
+	for i := range points {
+		scores[i] = weight(i)
+	}
+	centroids := make([][]float64, 0, k)
+	centroids = append(centroids, slices.Clone(points[sampleIndex(scores, rng)]))
+
+	// Squared distance from each point to its nearest chosen centroid
+	dists := make([]float64, len(points))
+	for i := range points {
+		dists[i] = squaredDistance(points[i], centroids[0])
+	}
+
+	candidateDists := make([]float64, len(points))
+	bestDists := make([]float64, len(points))
+	for len(centroids) < k {
+		for i := range points {
+			scores[i] = weight(i) * dists[i]
+		}
+
+		// Keep the candidate that minimises the potential
+		best := -1
+		bestPotential := math.Inf(1)
+		for range trials {
+			candidate := sampleIndex(scores, rng)
+			potential := 0.0
+			for i := range points {
+				candidateDists[i] = min(dists[i], squaredDistance(points[i], points[candidate]))
+				potential += weight(i) * candidateDists[i]
+			}
+			if potential < bestPotential {
+				best = candidate
+				bestPotential = potential
+				candidateDists, bestDists = bestDists, candidateDists
+			}
+		}
+
+		centroids = append(centroids, slices.Clone(points[best]))
+		dists, bestDists = bestDists, dists
+	}
+	return centroids
+}
+
+// initKMeansParallel implements k-means|| seeding. Starting from one random
+// observation, each pass samples every observation independently with
+// probability proportional to its squared distance to the current candidates,
+// oversampling by a factor of 2k. After O(log k) passes the candidates are
+// weighted by the number of observations closest to them and reduced to k
+// centroids with weighted k-means++.
+func initKMeansParallel(points [][]float64, k int, rng *rand.Rand) [][]float64 {
+	oversampling := 2 * float64(k)
+
+	candidates := [][]float64{points[rng.Intn(len(points))]}
+	dists := make([]float64, len(points))
+	for i := range points {
+		dists[i] = squaredDistance(points[i], candidates[0])
+	}
+
+	for range bits.Len(uint(k)) {
+		cost := 0.0
+		for _, d := range dists {
+			cost += d
+		}
+		if cost == 0 {
+			break
+		}
+
+		// Sample new candidates independently of each other
+		start := len(candidates)
+		for i := range points {
+			if rng.Float64() < oversampling*dists[i]/cost {
+				candidates = append(candidates, points[i])
+			}
+		}
+
+		// Refresh distances against the new candidates only
+		for i := range points {
+			for _, c := range candidates[start:] {
+				dists[i] = min(dists[i], squaredDistance(points[i], c))
+			}
+		}
+	}
+
+	// Too few candidates: continue with plain D² sampling over the dataset
+	for len(candidates) < k {
+		candidate := points[sampleIndex(dists, rng)]
+		candidates = append(candidates, candidate)
+		for i := range points {
+			dists[i] = min(dists[i], squaredDistance(points[i], candidate))
+		}
+	}
+
+	// Weight each candidate by the number of observations closest to it
+	weights := make([]float64, len(candidates))
+	for _, p := range points {
+		j, _ := nearest(p, candidates, EuclideanDistance)
+		weights[j]++
+	}
+
+	return seedPlusPlus(candidates, weights, k, 1, rng)
+}
+
+// initMaximin implements farthest-point seeding: the first centroid is the
+// point closest to the mean and each following one is the point whose
+// distance to its nearest chosen centroid is the largest.
+func initMaximin(points [][]float64, k int) [][]float64 {
+	centroids := make([][]float64, k)
+	for j, i := range farthestFirst(points, k, squaredDistance) {
+		centroids[j] = slices.Clone(points[i])
+	}
+	return centroids
+}
+
+// farthestFirst returns the indices of k points chosen by farthest-first
+// traversal: the point nearest to the mean of points, then repeatedly the
+// point whose distance to its nearest chosen point is the largest.
+func farthestFirst(points [][]float64, k int, distance DistanceFunc) []int {
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	first, _ := nearest(mean(points), points, EuclideanDistance)
+	order[0], order[first] = order[first], order[0]
+
+	// Distance from each point to its nearest chosen point
+	dists := make([]float64, len(points))
+	for i := range dists {
+		dists[i] = math.Inf(1)
+	}
+	for r := 1; r < k; r++ {
+		farthest := r
+		for s := r; s < len(order); s++ {
+			dists[order[s]] = min(dists[order[s]], distance(points[order[s]], points[order[r-1]]))
+			if dists[order[s]] > dists[order[farthest]] {
+				farthest = s
+			}
+		}
+		order[r], order[farthest] = order[farthest], order[r]
+	}
+	return order[:k]
+}
+
+// initPCAPartition sorts points along their first principal component and
+// uses the means of k contiguous quantile groups as centroids.
+func initPCAPartition(points [][]float64, k int) [][]float64 {
+	m := mean(points)
+	axis := principalComponent(covariance(points, m))
+
+	// Order points by their projection on the principal axis
+	projections := make([]float64, len(points))
+	order := make([]int, len(points))
+	for i, p := range points {
+		for d := range axis {
+			projections[i] += (p[d] - m[d]) * axis[d]
+		}
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(projections[a], projections[b])
+	})
+
+	centroids := make([][]float64, k)
+	for j := range k {
+		group := make([][]float64, 0, len(points)/k+1)
+		for _, i := range order[j*len(points)/k : (j+1)*len(points)/k] {
+			group = append(group, points[i])
+		}
+		centroids[j] = mean(group)
+	}
+	return centroids
+}
+
+// initRandomPartition assigns each point to a uniformly random cluster and
+// returns the cluster means. A cluster left empty by the draw is seeded with
+// a random point instead.
+func initRandomPartition(points [][]float64, k int, rng *rand.Rand) [][]float64 {
+	groups := make([][][]float64, k)
+	for _, p := range points {
+		j := rng.Intn(k)
+		groups[j] = append(groups[j], p)
+	}
+	centroids := make([][]float64, k)
+	for j, group := range groups {
+		if len(group) == 0 {
+			centroids[j] = slices.Clone(points[rng.Intn(len(points))])
+			continue
+		}
+		centroids[j] = mean(group)
+	}
+	return centroids
+}
+
+// sampleIndex draws an index with probability proportional to its weight.
+// It falls back to a uniform draw when all weights are zero.
+func sampleIndex(weights []float64, rng *rand.Rand) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return rng.Intn(len(weights))
+	}
+	target := rng.Float64() * total
+	last := 0
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		last = i
+		target -= w
+		if target < 0 {
+			return i
+		}
+	}
+	// Rounding errors can leave a tiny remainder: use the last candidate
+	return last
+}
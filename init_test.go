@@ -0,0 +1,200 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestClusterKMeansPlusPlus(t *testing.T) {
+	dataset := []Numbers{
+		1, 2, 3,
+		1001, 1002, 1003,
+		2001, 2002, 2003,
+		10000,
+	}
+	expectedClusters := [][]Numbers{
+		{1, 2, 3},
+		{1001, 1002, 1003},
+		{2001, 2002, 2003},
+		{10000},
+	}
+
+	// D² sampling spreads the seeds over every group regardless of the seed
+	for seed := range int64(10) {
+		rng := rand.New(rand.NewSource(seed))
+		clusters, err := Cluster(dataset, 4, 0.01, 100, rng, WithInit(InitKMeansPlusPlus))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertClusters(t, clusters, expectedClusters)
+	}
+}
+
+func TestClusterInvalidInit(t *testing.T) {
+	dataset := []Numbers{1, 2, 3}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithInit(Init(-1))); err == nil {
+		t.Fatal("expected error for invalid init strategy")
+	}
+}
+
+func TestClusterKMeansParallel(t *testing.T) {
+	dataset := []Coordinates{
+		{1, 2}, {2, 3}, {3, 4},
+		{1001, 1002}, {1002, 1003}, {1003, 1004},
+		{2001, 2002}, {2002, 2003}, {2003, 2004},
+		{10000, 20000},
+	}
+	expectedClusters := [][]Coordinates{
+		{{1, 2}, {2, 3}, {3, 4}},
+		{{1001, 1002}, {1002, 1003}, {1003, 1004}},
+		{{2001, 2002}, {2002, 2003}, {2003, 2004}},
+		{{10000, 20000}},
+	}
+
+	for seed := range int64(10) {
+		rng := rand.New(rand.NewSource(seed))
+		clusters, err := Cluster(dataset, 4, 0.01, 100, rng, WithInit(InitKMeansParallel))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertClusters(t, clusters, expectedClusters)
+	}
+}
+
+func TestClusterGreedyKMeansPlusPlus(t *testing.T) {
+	dataset := []Numbers{
+		1, 2, 3,
+		1001, 1002, 1003,
+		2001, 2002, 2003,
+		10000,
+	}
+	expectedClusters := [][]Numbers{
+		{1, 2, 3},
+		{1001, 1002, 1003},
+		{2001, 2002, 2003},
+		{10000},
+	}
+
+	for _, trials := range []int{0, 1, 5} {
+		rng := rand.New(rand.NewSource(0))
+		clusters, err := Cluster(dataset, 4, 0.01, 100, rng, WithInit(InitGreedyKMeansPlusPlus), WithLocalTrials(trials))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertClusters(t, clusters, expectedClusters)
+	}
+
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 4, 0.01, 100, rng, WithInit(InitGreedyKMeansPlusPlus), WithLocalTrials(-1)); err == nil {
+		t.Fatal("expected error for negative local trials")
+	}
+}
+
+func TestClusterMaximin(t *testing.T) {
+	dataset := []Coordinates{
+		{1, 2}, {2, 3}, {3, 4},
+		{11, 12}, {12, 13}, {13, 14},
+		{21, 22}, {22, 23}, {23, 24},
+		{100, 200},
+	}
+	expectedClusters := [][]Coordinates{
+		{{1, 2}, {2, 3}, {3, 4}},
+		{{11, 12}, {12, 13}, {13, 14}},
+		{{21, 22}, {22, 23}, {23, 24}},
+		{{100, 200}},
+	}
+
+	// No random number generator is needed
+	clusters, err := Cluster(dataset, 4, 0.01, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, clusters, expectedClusters)
+}
+
+func TestInitMaximin(t *testing.T) {
+	points := [][]float64{{0}, {1}, {2}, {10}, {-5}}
+	centroids := initMaximin(points, 3)
+	expected := [][]float64{{2}, {10}, {-5}}
+	for j := range expected {
+		if !slices.Equal(centroids[j], expected[j]) {
+			t.Errorf("centroid %d: expected %v, got %v", j, expected[j], centroids[j])
+		}
+	}
+}
+
+func TestClusterPCAPartition(t *testing.T) {
+	// Three elongated groups laid out along the diagonal
+	dataset := []Coordinates{
+		{0, 1}, {1, 0}, {2, 3}, {3, 2},
+		{20, 21}, {21, 20}, {22, 23}, {23, 22},
+		{40, 41}, {41, 40}, {42, 43}, {43, 42},
+	}
+	expectedClusters := [][]Coordinates{
+		{{0, 1}, {1, 0}, {2, 3}, {3, 2}},
+		{{20, 21}, {21, 20}, {22, 23}, {23, 22}},
+		{{40, 41}, {41, 40}, {42, 43}, {43, 42}},
+	}
+
+	clusters, err := Cluster(dataset, 3, 0.01, 100, nil, WithInit(InitPCAPartition))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, clusters, expectedClusters)
+}
+
+func TestInitRandomPartition(t *testing.T) {
+	points := [][]float64{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}}
+	rng := rand.New(rand.NewSource(0))
+	centroids := initRandomPartition(points, 3, rng)
+	if len(centroids) != 3 {
+		t.Fatalf("expected 3 centroids, got %d", len(centroids))
+	}
+
+	// Every centroid is the mean of some subset and lies within the data range
+	for j, c := range centroids {
+		if c[0] < 1 || c[0] > 8 {
+			t.Errorf("centroid %d out of range: %v", j, c)
+		}
+	}
+}
+
+func TestClusterRandomPartition(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 101, 102, 103}
+	rng := rand.New(rand.NewSource(0))
+	clusters, err := Cluster(dataset, 2, 0.01, 100, rng, WithInit(InitRandomPartition))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, clusters, [][]Numbers{{1, 2, 3}, {101, 102, 103}})
+}
+
+func TestClusterWarmStart(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13, 21, 22, 23}
+	initial := [][]float64{{0}, {10}, {20}}
+
+	result, err := ClusterResult(dataset, 3, 0.01, 100, nil, WithCentroids(initial))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Centroids are refined in place, keeping the order they were given in
+	expected := [][]float64{{2}, {12}, {22}}
+	for j := range expected {
+		if !slices.Equal(result.Centroids[j], expected[j]) {
+			t.Errorf("centroid %d: expected %v, got %v", j, expected[j], result.Centroids[j])
+		}
+	}
+	if initial[0][0] != 0 {
+		t.Error("initial centroids were modified")
+	}
+
+	if _, err := Cluster(dataset, 2, 0.01, 100, nil, WithCentroids(initial)); err == nil {
+		t.Error("expected error for wrong number of centroids")
+	}
+	if _, err := Cluster(dataset, 3, 0.01, 100, nil, WithCentroids([][]float64{{0, 0}, {1, 1}, {2, 2}})); err == nil {
+		t.Error("expected error for wrong centroid dimension")
+	}
+}
@@ -0,0 +1,246 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// ISODATA implements the Iterative Self-Organizing Data Analysis Technique,
+// which adapts the number of clusters while it iterates. Starting from k
+// clusters, the desired number, each iteration assigns the observations,
+// discards clusters with fewer than minSize members, recomputes the centroids
+// and then either splits or merges clusters:
+//
+//   - a cluster whose standard deviation along some axis exceeds maxSpread is
+//     split in two along that axis when there are at most k/2 clusters, or
+//     when it has more than 2(minSize+1) members and a larger mean distance
+//     to its centroid than the average cluster;
+//   - clusters whose centroids are closer than minDistance are merged
+//     pairwise, each cluster at most once per iteration.
+//
+// Splits are tried on odd iterations and whenever there are at most k/2
+// clusters, and merges on even iterations, when there are 2k clusters or
+// more, or when no cluster was split. It stops when an iteration neither
+// split nor merged clusters and no centroid moved by deltaThreshold or more,
+// or after iterationThreshold iterations. It requires the Euclidean distance
+// and mean centroids and honours WithInit, WithCentroids and WithWorkers.
+func ISODATA[T Observation](dataset []T, k, minSize int, maxSpread, minDistance, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate thresholds
+	if minSize < 0 {
+		return nil, fmt.Errorf("invalid minimum cluster size: %d", minSize)
+	}
+	if maxSpread <= 0 {
+		return nil, fmt.Errorf("invalid maximum spread: %f", maxSpread)
+	}
+	if minDistance < 0 {
+		return nil, fmt.Errorf("invalid minimum distance: %f", minDistance)
+	}
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate distance, splitting relies on per-axis spreads around means
+	if !cfg.euclidean() {
+		return nil, fmt.Errorf("ISODATA requires the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCentroids(cfg.centroids, k, len(points[0])); err != nil {
+		return nil, err
+	}
+
+	centroids := initCentroids(points, k, cfg, rng)
+	labels := make([]int, len(points))
+	iterations, converged := 0, false
+	for iterations < iterationThreshold {
+		iterations++
+
+		// Assign observations and drop clusters that are too small, keeping
+		// the non-empty ones when none is large enough
+		newAssigner(points, cfg).assign(centroids, labels)
+		groups := groupPoints(points, labels, len(centroids))
+		kept := discardSmall(groups, minSize)
+		if len(kept) == 0 {
+			kept = discardSmall(groups, 1)
+		}
+		groups = kept
+
+		// Recompute the centroids of the remaining clusters
+		previous := centroids
+		centroids = make([][]float64, len(groups))
+		for j, group := range groups {
+			centroids[j] = mean(group)
+		}
+		moved := len(centroids) != len(previous) || maxDrift(previous, centroids) >= deltaThreshold
+
+		// Leave the last centroids as the means of their clusters
+		if iterations == iterationThreshold {
+			break
+		}
+
+		// Split or merge clusters
+		changed := false
+		if len(centroids) <= k/2 || (iterations%2 == 1 && len(centroids) < 2*k) {
+			centroids, changed = isodataSplit(groups, centroids, k, minSize, maxSpread)
+		}
+		if !changed && len(centroids) > k/2 {
+			centroids, changed = isodataMerge(groups, centroids, minDistance)
+		}
+		if !changed && !moved {
+			converged = true
+			break
+		}
+	}
+
+	// Labels of the final centroids
+	newAssigner(points, cfg).assign(centroids, labels)
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
+
+// discardSmall returns the groups with at least minSize points.
+func discardSmall(groups [][][]float64, minSize int) [][][]float64 {
+	kept := make([][][]float64, 0, len(groups))
+	for _, group := range groups {
+		if len(group) >= max(minSize, 1) {
+			kept = append(kept, group)
+		}
+	}
+	return kept
+}
+
+// isodataSplit splits the clusters given by groups and their centroids whose
+// spread along some axis exceeds maxSpread, moving the two children half a
+// standard deviation away from the centroid along that axis. It returns the
+// new centroids and whether any cluster was split.
+func isodataSplit(groups [][][]float64, centroids [][]float64, k, minSize int, maxSpread float64) ([][]float64, bool) {
+	// Mean distance of the members of each cluster to its centroid, and the
+	// average over all observations
+	spreads := make([]float64, len(groups))
+	overall, total := 0.0, 0
+	for j, group := range groups {
+		for _, p := range group {
+			spreads[j] += EuclideanDistance(p, centroids[j])
+		}
+		overall += spreads[j]
+		total += len(group)
+		spreads[j] /= float64(len(group))
+	}
+	overall /= float64(total)
+
+	var next [][]float64
+	split := false
+	for j, group := range groups {
+		// Axis with the largest standard deviation
+		axis, deviation := 0, 0.0
+		for d := range centroids[j] {
+			variance := 0.0
+			for _, p := range group {
+				variance += (p[d] - centroids[j][d]) * (p[d] - centroids[j][d])
+			}
+			if sd := math.Sqrt(variance / float64(len(group))); sd > deviation {
+				axis, deviation = d, sd
+			}
+		}
+
+		if deviation > maxSpread && (len(groups) <= k/2 || (len(group) > 2*(minSize+1) && spreads[j] > overall)) {
+			a, b := slices.Clone(centroids[j]), slices.Clone(centroids[j])
+			a[axis] += deviation / 2
+			b[axis] -= deviation / 2
+			next = append(next, a, b)
+			split = true
+		} else {
+			next = append(next, centroids[j])
+		}
+	}
+	return next, split
+}
+
+// isodataMerge merges the pairs of clusters whose centroids are closer than
+// minDistance, closest pairs first and each cluster at most once, replacing
+// them with their size-weighted mean. It returns the new centroids and whether
+// any clusters were merged.
+func isodataMerge(groups [][][]float64, centroids [][]float64, minDistance float64) ([][]float64, bool) {
+	type pair struct {
+		a, b     int
+		distance float64
+	}
+	var pairs []pair
+	for a := range centroids {
+		for b := a + 1; b < len(centroids); b++ {
+			if d := EuclideanDistance(centroids[a], centroids[b]); d < minDistance {
+				pairs = append(pairs, pair{a, b, d})
+			}
+		}
+	}
+	if len(pairs) == 0 {
+		return centroids, false
+	}
+	slices.SortFunc(pairs, func(x, y pair) int {
+		return cmp.Compare(x.distance, y.distance)
+	})
+
+	merged := make([]bool, len(centroids))
+	var next [][]float64
+	for _, p := range pairs {
+		if merged[p.a] || merged[p.b] {
+			continue
+		}
+		merged[p.a], merged[p.b] = true, true
+		na, nb := float64(len(groups[p.a])), float64(len(groups[p.b]))
+		centroid := make([]float64, len(centroids[p.a]))
+		for d := range centroid {
+			centroid[d] = (na*centroids[p.a][d] + nb*centroids[p.b][d]) / (na + nb)
+		}
+		next = append(next, centroid)
+	}
+	for j, centroid := range centroids {
+		if !merged[j] {
+			next = append(next, centroid)
+		}
+	}
+	return next, true
+}
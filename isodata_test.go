@@ -0,0 +1,73 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestISODATASplit(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	result, err := ISODATA(dataset, 2, 10, 6, 10, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d clusters, got %d", len(centers), len(result.Centroids))
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/100*100] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+}
+
+func TestISODATAMerge(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	// Two of the initial clusters share the first blob
+	result, err := ISODATA(dataset, 3, 10, 6, 10, 1e-6, 100, nil, WithCentroids([][]float64{{-2, 0}, {2, 0}, {50, 50}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d clusters, got %d", len(centers), len(result.Centroids))
+	}
+}
+
+func TestISODATASmallClusters(t *testing.T) {
+	// Every cluster is smaller than the minimum size and one of them is empty
+	dataset := []Numbers{1, 1, 1}
+	result, err := ISODATA(dataset, 2, 5, 1, 0.1, 0.01, 10, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != 1 || result.Centroids[0][0] != 1 {
+		t.Errorf("expected a single centroid at 1, got %v", result.Centroids)
+	}
+}
+
+func TestISODATAValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := ISODATA(dataset, 0, 1, 1, 1, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := ISODATA(dataset, 2, -1, 1, 1, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid minimum size")
+	}
+	if _, err := ISODATA(dataset, 2, 1, 0, 1, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid maximum spread")
+	}
+	if _, err := ISODATA(dataset, 2, 1, 1, -1, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid minimum distance")
+	}
+	if _, err := ISODATA(dataset, 2, 1, 1, 1, 1e-6, 100, rng, WithMedians()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+	if _, err := ISODATA(dataset, 2, 1, 1, 1, 1e-6, 100, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
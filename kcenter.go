@@ -0,0 +1,62 @@
+package kmeans
+
+import "fmt"
+
+// CenterResult holds the outcome of a k-center run.
+type CenterResult[T Observation] struct {
+	Result[T]
+	// Centers holds the index in the dataset of the center of each cluster.
+	Centers []int
+	// Radius is the largest distance of an observation to its center.
+	Radius float64
+}
+
+// KCenter implements Gonzalez's greedy k-center algorithm, which minimises
+// the largest distance of an observation to its center rather than the sum
+// of squared distances, e.g. to cover every customer within a worst-case
+// delay. The first center is the observation nearest to the mean and each
+// following one the observation farthest from the centers chosen so far.
+// The radius is at most twice the optimal radius, the best guarantee a
+// polynomial algorithm can offer.
+//
+// Centers are observations and the result's Centroids their coordinates.
+// Observations are compared with the configured distance, which should be a
+// metric for the guarantee to hold. It is deterministic and Converged is
+// always true. The same traversal seeds k-means with InitMaximin.
+func KCenter[T Observation](dataset []T, k int, opts ...Option) (*CenterResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	distance := cfg.distanceFunc()
+	centers := farthestFirst(points, k, distance)
+	centroids := make([][]float64, k)
+	for j, i := range centers {
+		centroids[j] = points[i]
+	}
+	labels := make([]int, len(points))
+	radius := 0.0
+	for i, p := range points {
+		var d float64
+		labels[i], d = nearest(p, centroids, distance)
+		radius = max(radius, d)
+	}
+	centroids = cloneAll(centroids)
+
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Converged = true
+	return &CenterResult[T]{Result: *result, Centers: centers, Radius: radius}, nil
+}
@@ -0,0 +1,64 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestKCenter(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13, 21, 22, 23}
+	result, err := KCenter(dataset, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 3}, {11, 12, 13}, {21, 22, 23}})
+	if !slices.Equal(result.Centers, []int{4, 0, 8}) || result.Radius != 2 {
+		t.Errorf("unexpected centers %v and radius %v", result.Centers, result.Radius)
+	}
+}
+
+func TestKCenterApproximation(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for range 10 {
+		dataset := make([]Vector, 12)
+		for i := range dataset {
+			dataset[i] = Vector{rng.Float64() * 10, rng.Float64() * 10}
+		}
+		result, err := KCenter(dataset, 3, WithDistance(ManhattanDistance))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Try every choice of 3 centers
+		optimal := math.Inf(1)
+		for a := range dataset {
+			for b := a + 1; b < len(dataset); b++ {
+				for c := b + 1; c < len(dataset); c++ {
+					radius := 0.0
+					for _, p := range dataset {
+						d := min(ManhattanDistance(p, dataset[a]), ManhattanDistance(p, dataset[b]), ManhattanDistance(p, dataset[c]))
+						radius = max(radius, d)
+					}
+					optimal = min(optimal, radius)
+				}
+			}
+		}
+		if result.Radius > 2*optimal {
+			t.Fatalf("radius %f exceeds twice the optimal radius %f", result.Radius, optimal)
+		}
+	}
+}
+
+func TestKCenterValidation(t *testing.T) {
+	if _, err := KCenter([]Numbers{}, 2); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := KCenter([]Numbers{1, 2}, 3); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := KCenter([]Vector{{1, 2}, {3}}, 2); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
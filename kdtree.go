@@ -0,0 +1,96 @@
+package kmeans
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// kdNode is a node of a kd-tree over centroids, splitting space along axis at
+// the centroid it holds.
+type kdNode struct {
+	index       int
+	axis        int
+	left, right *kdNode
+}
+
+// buildKDTree builds a balanced kd-tree over the given centroid indices,
+// splitting each node along the axis with the largest spread.
+func buildKDTree(centroids [][]float64, indices []int) *kdNode {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// Split along the widest axis
+	axis, widest := 0, -1.0
+	for d := range centroids[indices[0]] {
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, j := range indices {
+			low = min(low, centroids[j][d])
+			high = max(high, centroids[j][d])
+		}
+		if high-low > widest {
+			axis, widest = d, high-low
+		}
+	}
+
+	slices.SortFunc(indices, func(a, b int) int {
+		return cmp.Or(cmp.Compare(centroids[a][axis], centroids[b][axis]), cmp.Compare(a, b))
+	})
+	median := len(indices) / 2
+	return &kdNode{
+		index: indices[median],
+		axis:  axis,
+		left:  buildKDTree(centroids, indices[:median]),
+		right: buildKDTree(centroids, indices[median+1:]),
+	}
+}
+
+// nearest updates best and bestDist with the centroid of the subtree closest
+// to point, bestDist holding the squared distance. Ties are resolved towards
+// the lowest index, like a linear scan.
+func (n *kdNode) nearest(point []float64, centroids [][]float64, best *int, bestDist *float64) {
+	if n == nil {
+		return
+	}
+	d := squaredDistance(point, centroids[n.index])
+	if d < *bestDist || (d == *bestDist && n.index < *best) {
+		*best, *bestDist = n.index, d
+	}
+
+	// Search the side containing the point first, the other one only if the
+	// splitting plane is within the best distance
+	diff := point[n.axis] - centroids[n.index][n.axis]
+	near, far := n.left, n.right
+	if diff >= 0 {
+		near, far = n.right, n.left
+	}
+	near.nearest(point, centroids, best, bestDist)
+	if diff*diff <= *bestDist {
+		far.nearest(point, centroids, best, bestDist)
+	}
+}
+
+// kdTree is an assignment step answering nearest-centroid queries with a
+// kd-tree rebuilt over the centroids at each call.
+type kdTree struct {
+	points  [][]float64
+	workers int
+}
+
+func (t *kdTree) assign(centroids [][]float64, labels []int) *Stats {
+	indices := make([]int, len(centroids))
+	for j := range indices {
+		indices[j] = j
+	}
+	root := buildKDTree(centroids, indices)
+
+	return shardStats(len(t.points), len(centroids), len(centroids[0]), t.workers, func(start, end int, partial *Stats) {
+		for i := start; i < end; i++ {
+			best, bestDist := -1, math.Inf(1)
+			root.nearest(t.points[i], centroids, &best, &bestDist)
+			labels[i] = best
+			partial.add(t.points[i], best)
+		}
+	})
+}
@@ -0,0 +1,234 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Kernel computes the inner product of two observations in an implicit
+// feature space. It must be symmetric and positive semi-definite.
+type Kernel func(a, b []float64) float64
+
+// LinearKernel returns the dot product of a and b, for which kernel k-means
+// reduces to k-means.
+func LinearKernel(a, b []float64) float64 {
+	if len(a) != len(b) {
+		panic("dimensions mismatch")
+	}
+	return dot(a, b)
+}
+
+// RBFKernel returns the Gaussian radial basis function kernel
+// exp(-gamma‖a-b‖²). It panics if gamma is not positive.
+func RBFKernel(gamma float64) Kernel {
+	if !(gamma > 0) {
+		panic("invalid RBF gamma")
+	}
+	return func(a, b []float64) float64 {
+		return math.Exp(-gamma * squaredDistance(a, b))
+	}
+}
+
+// PolynomialKernel returns the polynomial kernel (a·b + coef0)^degree. It
+// panics if degree is not positive.
+func PolynomialKernel(degree int, coef0 float64) Kernel {
+	if degree <= 0 {
+		panic("invalid polynomial degree")
+	}
+	return func(a, b []float64) float64 {
+		if len(a) != len(b) {
+			panic("dimensions mismatch")
+		}
+		return math.Pow(dot(a, b)+coef0, float64(degree))
+	}
+}
+
+// KernelKMeans implements kernel k-means: observations are clustered in the
+// feature space of kernel, where non-linearly separable clusters such as
+// rings may become separable, using only kernel evaluations and never the
+// features themselves. The kernel matrix of the dataset is computed once, so
+// memory grows with the square of the number of observations.
+//
+// Seeds are chosen with k-means++ in feature space, then observations move to
+// the cluster whose feature-space mean is nearest until no observation
+// changes cluster or iterationThreshold iterations ran. The result's Inertia
+// is the within-cluster sum of squared distances in feature space and its
+// Centroids are the input-space means of the clusters, for reference only. It
+// honours WithWorkers.
+func KernelKMeans[T Observation](dataset []T, k int, kernel Kernel, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate kernel
+	if kernel == nil {
+		return nil, fmt.Errorf("kernel is nil")
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	n := len(points)
+	workers := cfg.workerCount()
+
+	// Kernel matrix, filled in parallel by rows
+	gram := newMatrix(n, n)
+	parallel(n, workers, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			for j := range i + 1 {
+				gram[i][j] = kernel(points[i], points[j])
+			}
+		}
+	})
+	for i := range n {
+		for j := range i {
+			gram[j][i] = gram[i][j]
+		}
+	}
+
+	labels := kernelSeeds(gram, k, rng)
+	iterations, converged := 0, false
+	dists := make([]float64, n)
+	for range iterationThreshold {
+		iterations++
+		next := kernelAssign(gram, labels, k, dists, workers)
+		changed := !slices.Equal(next, labels)
+		labels = next
+		if !changed {
+			converged = true
+			break
+		}
+	}
+
+	// Input-space means of the clusters, for reference
+	stats := NewStats(k, len(points[0]))
+	for i, j := range labels {
+		stats.add(points[i], j)
+	}
+	centroids := make([][]float64, k)
+	for j := range centroids {
+		centroids[j] = make([]float64, len(points[0]))
+	}
+	centroids = update(nil, nil, centroids, stats, &config{})
+
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Inertia = 0
+	kernelAssign(gram, labels, k, dists, workers)
+	for _, d := range dists {
+		result.Inertia += d
+	}
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
+
+// kernelSeeds chooses k seeds with k-means++ using feature-space squared
+// distances K(i,i) + K(j,j) - 2K(i,j) and labels every point with its
+// nearest seed.
+func kernelSeeds(gram [][]float64, k int, rng *rand.Rand) []int {
+	n := len(gram)
+	distance := func(i, j int) float64 {
+		return max(0, gram[i][i]+gram[j][j]-2*gram[i][j])
+	}
+	seeds := []int{rng.Intn(n)}
+	dists := make([]float64, n)
+	for i := range n {
+		dists[i] = distance(i, seeds[0])
+	}
+	for len(seeds) < k {
+		seed := sampleIndex(dists, rng)
+		seeds = append(seeds, seed)
+		for i := range n {
+			dists[i] = min(dists[i], distance(i, seed))
+		}
+	}
+
+	labels := make([]int, n)
+	for i := range n {
+		best := math.Inf(1)
+		for j, seed := range seeds {
+			if d := distance(i, seed); d < best {
+				best, labels[i] = d, j
+			}
+		}
+	}
+	return labels
+}
+
+// kernelAssign returns the label of the cluster whose feature-space mean is
+// nearest to each point given the current labels, and sets dists to the
+// squared distance of each point to the mean of its current cluster. Empty
+// clusters attract no point.
+func kernelAssign(gram [][]float64, labels []int, k int, dists []float64, workers int) []int {
+	n := len(gram)
+	sizes := make([]float64, k)
+	for _, j := range labels {
+		sizes[j]++
+	}
+
+	// Squared norm of each cluster mean: the mean kernel value over its pairs
+	self := make([]float64, k)
+	for a := range n {
+		for b := range n {
+			if labels[a] == labels[b] {
+				self[labels[a]] += gram[a][b]
+			}
+		}
+	}
+	for j := range k {
+		if sizes[j] > 0 {
+			self[j] /= sizes[j] * sizes[j]
+		}
+	}
+
+	next := make([]int, n)
+	parallel(n, workers, func(_, start, end int) {
+		cross := make([]float64, k)
+		for i := start; i < end; i++ {
+			clear(cross)
+			for b, j := range labels {
+				cross[j] += gram[i][b]
+			}
+			best := math.Inf(1)
+			for j := range k {
+				if sizes[j] == 0 {
+					continue
+				}
+				d := gram[i][i] - 2*cross[j]/sizes[j] + self[j]
+				if j == labels[i] {
+					dists[i] = max(0, d)
+				}
+				if d < best {
+					best, next[i] = d, j
+				}
+			}
+		}
+	})
+	return next
+}
@@ -0,0 +1,71 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestKernelKMeans(t *testing.T) {
+	// Two concentric rings, which no partition by input-space means separates
+	var dataset []Vector
+	for _, radius := range []float64{1, 5} {
+		for i := range 50 {
+			angle := 2 * math.Pi * float64(i) / 50
+			dataset = append(dataset, Vector{radius * math.Cos(angle), radius * math.Sin(angle)})
+		}
+	}
+
+	result, err := KernelKMeans(dataset, 2, RBFKernel(0.5), 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/50*50] {
+			t.Fatalf("observation %v not with its ring: %v", dataset[i], result.Labels)
+		}
+	}
+	if result.Labels[0] == result.Labels[50] || !result.Converged {
+		t.Errorf("expected the two rings in different clusters, got %v", result.Labels)
+	}
+}
+
+func TestKernels(t *testing.T) {
+	a, b := []float64{1, 2}, []float64{3, 1}
+	if k := LinearKernel(a, b); k != 5 {
+		t.Errorf("expected linear kernel 5, got %v", k)
+	}
+	if k := PolynomialKernel(2, 1)(a, b); k != 36 {
+		t.Errorf("expected polynomial kernel 36, got %v", k)
+	}
+	if k := RBFKernel(0.1)(a, b); math.Abs(k-math.Exp(-0.5)) > 1e-12 {
+		t.Errorf("expected RBF kernel %v, got %v", math.Exp(-0.5), k)
+	}
+}
+
+func TestKernelKMeansLinear(t *testing.T) {
+	// With the linear kernel, the inertia is the plain k-means inertia
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	result, err := KernelKMeans(dataset, 2, LinearKernel, 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 3}, {11, 12, 13}})
+	if math.Abs(result.Inertia-4) > 1e-9 {
+		t.Errorf("expected inertia 4, got %v", result.Inertia)
+	}
+}
+
+func TestKernelKMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := KernelKMeans(dataset, 2, nil, 100, rng); err == nil {
+		t.Error("expected error for nil kernel")
+	}
+	if _, err := KernelKMeans(dataset, 2, LinearKernel, 100, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := KernelKMeans(dataset, 0, LinearKernel, 100, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+}
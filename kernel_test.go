@@ -0,0 +1,61 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// randomPoints returns n points of dimension dim with uniform coordinates.
+func randomPoints(rng *rand.Rand, n, dim int) [][]float64 {
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, dim)
+		for d := range dim {
+			points[i][d] = rng.Float64()
+		}
+	}
+	return points
+}
+
+func TestSquaredDistanceKernel(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for dim := range 10 {
+		a, b := randomPoints(rng, 1, dim)[0], randomPoints(rng, 1, dim)[0]
+		expected := 0.0
+		for d := range dim {
+			expected += (a[d] - b[d]) * (a[d] - b[d])
+		}
+		if got := squaredDistanceKernel(a, b); math.Abs(got-expected) > 1e-12 {
+			t.Errorf("dim %d: expected %v, got %v", dim, expected, got)
+		}
+	}
+}
+
+func TestNearestPanel(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	points := randomPoints(rng, 3*panelSize+5, 7)
+	centroids := randomPoints(rng, 13, 7)
+
+	labels := make([]int, len(points))
+	nearestPanel(points, centroids, labels)
+
+	expected := make([]int, len(points))
+	for i := range points {
+		expected[i], _ = nearest(points[i], centroids, squaredDistance)
+	}
+	if !slices.Equal(labels, expected) {
+		t.Error("panel labels differ from a linear scan")
+	}
+}
+
+func BenchmarkNearestPanel(b *testing.B) {
+	rng := rand.New(rand.NewSource(0))
+	points := randomPoints(rng, 1024, 768)
+	centroids := randomPoints(rng, 16, 768)
+	labels := make([]int, len(points))
+	for b.Loop() {
+		nearestPanel(points, centroids, labels)
+	}
+}
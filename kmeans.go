@@ -2,7 +2,6 @@ package kmeans
 
 import (
 	"fmt"
-	"math"
 	"math/rand"
 	"slices"
 )
@@ -12,21 +11,174 @@ type Observation interface {
 	Coordinates() []float64
 }
 
-// euclideanDistance calculates the Euclidean distance between two coordinate slices.
-func euclideanDistance(a, b []float64) float64 {
-	if len(a) != len(b) {
-		panic("dimensions mismatch")
-	}
-	sum := 0.0
-	for i := range a {
-		diff := a[i] - b[i]
-		sum += diff * diff
+// materialize copies the coordinates of every observation into rows sharing
+// one contiguous backing array, calling Coordinates once per observation so
+// the algorithm never calls it again. It fails if dimensions differ.
+func materialize[T Observation](dataset []T) ([][]float64, error) {
+	first := dataset[0].Coordinates()
+	dim := len(first)
+	flat := make([]float64, len(dataset)*dim)
+	points := make([][]float64, len(dataset))
+	for i, obs := range dataset {
+		coords := first
+		if i > 0 {
+			coords = obs.Coordinates()
+		}
+		if len(coords) != dim {
+			return nil, fmt.Errorf("inconsistent dimensions")
+		}
+		points[i] = flat[i*dim : (i+1)*dim : (i+1)*dim]
+		copy(points[i], coords)
 	}
-	return math.Sqrt(sum)
+	return points, nil
 }
 
 // Cluster implements the k-means clustering algorithm.
-func Cluster[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand) ([][]T, error) {
+// Optional behaviour such as the initialization strategy is set with opts.
+func Cluster[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) ([][]T, error) {
+	result, err := ClusterResult(dataset, k, deltaThreshold, iterationThreshold, rng, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return result.Clusters, nil
+}
+
+// ClusterWithCentroids is like Cluster but also returns the final centroids.
+// The j-th centroid is the center of the j-th cluster.
+func ClusterWithCentroids[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) ([][]T, [][]float64, error) {
+	result, err := ClusterResult(dataset, k, deltaThreshold, iterationThreshold, rng, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Clusters, result.Centroids, nil
+}
+
+// ClusterLabels is like Cluster but returns, for each observation in input
+// order, the index of the cluster it belongs to.
+func ClusterLabels[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) ([]int, error) {
+	result, err := ClusterResult(dataset, k, deltaThreshold, iterationThreshold, rng, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return result.Labels, nil
+}
+
+// lloydLoop alternates assignment and update steps starting from centroids,
+// until no centroid moves by deltaThreshold or more or iterationThreshold
+// iterations ran. It fills labels with the final assignment and returns the
+// final centroids, the number of iterations and whether the run converged.
+func lloydLoop(points, centroids [][]float64, labels []int, deltaThreshold float64, iterationThreshold int, cfg *config) ([][]float64, int, bool) {
+	// Engine running the assignment step
+	engine := newAssigner(points, cfg)
+
+	iterations := 0
+	for range iterationThreshold {
+		iterations++
+
+		// Assignment step: assign each observation to the nearest centroid
+		stats := engine.assign(centroids, labels)
+		if cfg.weights != nil {
+			stats = weightedStats(points, cfg.weights, labels, len(centroids))
+		}
+
+		// Update step: calculate new centroids
+		newCentroids := update(points, labels, centroids, stats, cfg)
+
+		// Check convergence by calculating the maximum centroid movement
+		maxMovement := maxDrift(centroids, newCentroids)
+
+		// Update centroids for the next iteration
+		centroids = newCentroids
+
+		// Stop if maximum movement is below the threshold
+		if maxMovement < deltaThreshold {
+			return centroids, iterations, true
+		}
+	}
+	return centroids, iterations, false
+}
+
+// maxDrift returns the largest Euclidean distance between a centroid in
+// previous and its counterpart in centroids.
+func maxDrift(previous, centroids [][]float64) float64 {
+	maxMovement := 0.0
+	for j := range centroids {
+		maxMovement = max(maxMovement, EuclideanDistance(previous[j], centroids[j]))
+	}
+	return maxMovement
+}
+
+// validateCentroids checks that initial centroids, when given, are k
+// centroids of dimension dim.
+func validateCentroids(centroids [][]float64, k, dim int) error {
+	if centroids == nil {
+		return nil
+	}
+	if len(centroids) != k {
+		return fmt.Errorf("expected %d initial centroids, got %d", k, len(centroids))
+	}
+	for _, centroid := range centroids {
+		if len(centroid) != dim {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+	}
+	return nil
+}
+
+// update computes the centroid of each cluster from the points assigned to it.
+// A cluster left empty retains its previous centroid.
+func update(points [][]float64, assignment []int, centroids [][]float64, stats *Stats, cfg *config) [][]float64 {
+	k := len(centroids)
+	newCentroids := make([][]float64, k)
+
+	// Custom centers need the members of each cluster
+	if cfg.center != nil {
+		members := make([][][]float64, k)
+		for i, j := range assignment {
+			members[j] = append(members[j], points[i])
+		}
+		for j := range k {
+			if len(members[j]) > 0 {
+				newCentroids[j] = cfg.center(members[j])
+			} else {
+				newCentroids[j] = slices.Clone(centroids[j])
+			}
+		}
+		freeze(newCentroids, cfg)
+		return newCentroids
+	}
+
+	// Update centroids as the mean of assigned points
+	for j := range k {
+		if stats.Counts[j] > 0 {
+			newCentroids[j] = make([]float64, len(stats.Sums[j]))
+			for d := range newCentroids[j] {
+				newCentroids[j][d] = stats.Sums[j][d] / stats.Counts[j]
+			}
+			if cfg.spherical {
+				normalize(newCentroids[j])
+			}
+		} else {
+			// If cluster is empty, retain the old centroid
+			newCentroids[j] = slices.Clone(centroids[j])
+		}
+	}
+	freeze(newCentroids, cfg)
+	return newCentroids
+}
+
+// freeze resets the centroids frozen with WithFrozenCentroids.
+func freeze(centroids [][]float64, cfg *config) {
+	for j, centroid := range cfg.frozen {
+		centroids[j] = slices.Clone(centroid)
+	}
+}
+
+// ClusterResult is like Cluster but returns a Result describing the clusters,
+// their centroids, the labels, the inertia and how the run terminated.
+func ClusterResult[T Observation](dataset []T, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
 	// Validate empty dataset
 	if len(dataset) == 0 {
 		return nil, fmt.Errorf("dataset is empty")
@@ -47,121 +199,158 @@ func Cluster[T Observation](dataset []T, k int, deltaThreshold float64, iteratio
 		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
 	}
 
-	// Validate rng
-	if rng == nil {
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs and fully frozen runs do not need
+	if rng == nil && !cfg.deterministic() && (len(cfg.frozen) < k || cfg.sampleSize != 0) {
 		return nil, fmt.Errorf("random number generator is nil")
 	}
 
-	// Validate all observations have the same dimension
-	dim := len(dataset[0].Coordinates())
-	for _, obs := range dataset {
-		if len(obs.Coordinates()) != dim {
-			return nil, fmt.Errorf("inconsistent dimensions")
-		}
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
 	}
 
-	// Handle the case where k is equal to the number of observations
-	if k == len(dataset) {
-		clusters := make([][]T, k)
-		for i, obs := range dataset {
-			clusters[i] = []T{obs}
-		}
-		return clusters, nil
+	// Validate algorithm
+	if !cfg.algorithm.valid() {
+		return nil, fmt.Errorf("invalid algorithm: %d", cfg.algorithm)
+	}
+	if (cfg.algorithm.accelerated() || cfg.algorithm == HartiganWong) && !cfg.euclidean() {
+		return nil, fmt.Errorf("accelerated and Hartigan-Wong algorithms require the Euclidean distance and mean centroids")
 	}
 
-	// Handle the case where k is one
-	if k == 1 {
-		return [][]T{dataset}, nil
+	// Validate batch size
+	if cfg.batchSize < 0 {
+		return nil, fmt.Errorf("invalid batch size: %d", cfg.batchSize)
+	}
+	if (cfg.algorithm == MiniBatch || cfg.algorithm == MacQueen) && cfg.center != nil {
+		return nil, fmt.Errorf("online updates require mean centroids")
 	}
 
-	// Initialize centroids by randomly selecting k observations
-	indices := make([]int, len(dataset))
-	for i := range indices {
-		indices[i] = i
+	// Validate local trials
+	if cfg.localTrials < 0 {
+		return nil, fmt.Errorf("invalid number of local trials: %d", cfg.localTrials)
 	}
-	rng.Shuffle(len(indices), func(i, j int) {
-		indices[i], indices[j] = indices[j], indices[i]
-	})
-	centroids := make([][]float64, k)
-	for j := range k {
-		centroids[j] = slices.Clone(dataset[indices[j]].Coordinates())
+
+	// Validate sample size
+	if cfg.sampleSize < 0 || (cfg.sampleSize > 0 && cfg.sampleSize < k) {
+		return nil, fmt.Errorf("invalid sample size: %d", cfg.sampleSize)
 	}
 
-	// Assignment array to track which cluster each observation belongs to
-	assignment := make([]int, len(dataset))
+	// Validate weights of weighted observations
+	weights, err := datasetWeights(dataset, cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.weights = weights
+	if cfg.weights != nil && (cfg.center != nil || cfg.float32) {
+		return nil, fmt.Errorf("weighted observations require mean centroids and float64 storage")
+	}
 
-	// Main k-means loop
-	for range iterationThreshold {
-		// Assignment step: assign each observation to the nearest centroid
-		for i := range dataset {
-			minDist := math.Inf(1) // Positive infinity as initial distance
-			minIndex := -1
-			for j := range centroids {
-				dist := euclideanDistance(dataset[i].Coordinates(), centroids[j])
-				if dist < minDist {
-					minDist = dist
-					minIndex = j
-				}
-			}
-			assignment[i] = minIndex
+	// Validate float32 storage, which only supports the plain Lloyd loop
+	if cfg.float32 {
+		if cfg.algorithm != Lloyd || !cfg.euclidean() || cfg.sampleSize != 0 || cfg.frozen != nil {
+			return nil, fmt.Errorf("float32 storage requires the Lloyd algorithm, the Euclidean distance, mean centroids, no sampling and no frozen centroids")
 		}
+		return clusterFloat32(dataset, k, deltaThreshold, iterationThreshold, rng, cfg)
+	}
 
-		// Update step: calculate new centroids
-		newCentroids := make([][]float64, k)
-		for j := range newCentroids {
-			newCentroids[j] = make([]float64, dim)
+	// Validate all observations have the same dimension while copying their
+	// coordinates into a contiguous matrix
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate initial centroids
+	if err := validateCentroids(cfg.centroids, k, len(points[0])); err != nil {
+		return nil, err
+	}
+
+	// Validate frozen centroids
+	if cfg.frozen != nil {
+		if len(cfg.frozen) > k {
+			return nil, fmt.Errorf("expected at most %d frozen centroids, got %d", k, len(cfg.frozen))
 		}
-		sums := make([][]float64, k)
-		for j := range sums {
-			sums[j] = make([]float64, dim)
+		if err := validateCentroids(cfg.frozen, len(cfg.frozen), len(points[0])); err != nil {
+			return nil, err
 		}
-		counts := make([]int, k)
-
-		// Compute sums and counts for each cluster
-		for i, j := range assignment {
-			coords := dataset[i].Coordinates()
-			for d := range dim {
-				sums[j][d] += coords[d]
-			}
-			counts[j]++
+		if cfg.algorithm == MiniBatch || cfg.algorithm == MacQueen || cfg.algorithm == HartiganWong {
+			return nil, fmt.Errorf("frozen centroids require batch updates")
 		}
+	}
 
-		// Update centroids as the mean of assigned points
-		for j := range k {
-			if counts[j] > 0 {
-				for d := range dim {
-					newCentroids[j][d] = sums[j][d] / float64(counts[j])
-				}
-			} else {
-				// If cluster is empty, retain the old centroid
-				newCentroids[j] = slices.Clone(centroids[j])
-			}
+	// Project observations on the unit sphere in spherical mode
+	if cfg.spherical {
+		for _, p := range points {
+			normalize(p)
 		}
+	}
 
-		// Check convergence by calculating the maximum centroid movement
-		maxMovement := 0.0
-		for j := range k {
-			movement := euclideanDistance(centroids[j], newCentroids[j])
-			if movement > maxMovement {
-				maxMovement = movement
-			}
+	// Handle the case where k is equal to the number of observations
+	if k == len(dataset) && cfg.frozen == nil {
+		centroids := make([][]float64, k)
+		labels := make([]int, k)
+		for i := range points {
+			centroids[i] = slices.Clone(points[i])
+			labels[i] = i
 		}
+		result := newResult(dataset, points, centroids, labels, cfg.weights, cfg.lossFunc())
+		result.Converged = true
+		return result, nil
+	}
 
-		// Update centroids for the next iteration
-		centroids = newCentroids
+	// Handle the case where k is one
+	if k == 1 && cfg.frozen == nil {
+		centroid := cfg.centerOf(points)
+		result := newResult(dataset, points, [][]float64{centroid}, make([]int, len(dataset)), cfg.weights, cfg.lossFunc())
+		result.Converged = true
+		return result, nil
+	}
 
-		// Stop if maximum movement is below the threshold
-		if maxMovement < deltaThreshold {
-			break
+	// Fit the centroids on a random sample when sampling
+	fitPoints, fitCfg := points, cfg
+	if cfg.sampleSize > 0 && cfg.sampleSize < len(points) {
+		fitPoints, fitCfg = sample(points, cfg, rng)
+	}
+
+	// Initialize centroids using the configured strategy
+	centroids := initCentroids(fitPoints, k, fitCfg, rng)
+	if cfg.spherical {
+		for _, centroid := range centroids {
+			normalize(centroid)
 		}
 	}
 
-	// Form clusters based on final assignments
-	clusters := make([][]T, k)
-	for i, obs := range dataset {
-		j := assignment[i]
-		clusters[j] = append(clusters[j], obs)
+	// Assignment array to track which cluster each observation belongs to
+	assignment := make([]int, len(fitPoints))
+
+	// Main k-means loop, run by the configured algorithm
+	var iterations int
+	var converged bool
+	switch cfg.algorithm {
+	case MiniBatch:
+		centroids, iterations, converged = miniBatch(fitPoints, centroids, assignment, deltaThreshold, iterationThreshold, rng, fitCfg)
+	case MacQueen:
+		centroids, iterations, converged = macQueen(fitPoints, centroids, assignment, deltaThreshold, iterationThreshold, fitCfg)
+	case HartiganWong:
+		centroids, iterations, converged = hartiganWong(fitPoints, centroids, assignment, deltaThreshold, iterationThreshold, fitCfg)
+	default:
+		centroids, iterations, converged = lloydLoop(fitPoints, centroids, assignment, deltaThreshold, iterationThreshold, fitCfg)
 	}
 
-	return clusters, nil
+	// Label every observation in a single pass after fitting on a sample
+	if len(fitPoints) < len(points) {
+		assignment = make([]int, len(points))
+		newAssigner(points, cfg).assign(centroids, assignment)
+	}
+
+	// Form clusters based on final assignments
+	result := newResult(dataset, points, centroids, assignment, cfg.weights, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
 }
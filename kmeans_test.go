@@ -119,3 +119,188 @@ func TestClusterCoordinates(t *testing.T) {
 		}
 	}
 }
+
+// assertClusters checks that clusters match expected, ignoring cluster order.
+func assertClusters[T comparable](t *testing.T, clusters, expected [][]T) {
+	t.Helper()
+	if len(clusters) != len(expected) {
+		t.Fatalf("expected %d clusters, got %d", len(expected), len(clusters))
+	}
+	matched := make([]bool, len(expected))
+	for _, cluster := range clusters {
+		found := false
+		for i := range expected {
+			if !matched[i] && slices.Equal(cluster, expected[i]) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("unexpected cluster: %v", cluster)
+		}
+	}
+	for i, matched := range matched {
+		if !matched {
+			t.Errorf("expected cluster %v not found", expected[i])
+		}
+	}
+}
+
+func TestClusterWithCentroids(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+
+	clusters, centroids, err := ClusterWithCentroids(dataset, 2, 0.01, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, clusters, [][]Numbers{{1, 2, 3}, {11, 12, 13}})
+
+	// Each centroid is the mean of its cluster
+	for j, cluster := range clusters {
+		sum := 0.0
+		for _, obs := range cluster {
+			sum += float64(obs)
+		}
+		if expected := sum / float64(len(cluster)); centroids[j][0] != expected {
+			t.Errorf("centroid %d: expected %v, got %v", j, expected, centroids[j])
+		}
+	}
+
+	// Shortcuts for k = 1 and k = n also report centroids
+	_, centroids, err = ClusterWithCentroids(dataset, 1, 0.01, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(centroids) != 1 || centroids[0][0] != 7 {
+		t.Errorf("unexpected centroids for k = 1: %v", centroids)
+	}
+	_, centroids, err = ClusterWithCentroids(dataset, len(dataset), 0.01, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(centroids) != len(dataset) || centroids[3][0] != 11 {
+		t.Errorf("unexpected centroids for k = n: %v", centroids)
+	}
+}
+
+func TestClusterLabels(t *testing.T) {
+	dataset := []Numbers{1, 11, 2, 12, 3, 13}
+	rng := rand.New(rand.NewSource(0))
+
+	labels, err := ClusterLabels(dataset, 2, 0.01, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != len(dataset) {
+		t.Fatalf("expected %d labels, got %d", len(dataset), len(labels))
+	}
+
+	// Labels follow input order: even positions share a cluster, odd ones the other
+	for i := range labels {
+		if labels[i] != labels[i%2] {
+			t.Errorf("observation %d: expected label %d, got %d", i, labels[i%2], labels[i])
+		}
+	}
+	if labels[0] == labels[1] {
+		t.Errorf("expected distinct labels, got %v", labels)
+	}
+}
+
+// Counted allocates fresh coordinates and counts how often they are requested.
+type Counted struct {
+	value float64
+	calls *int
+}
+
+func (c Counted) Coordinates() []float64 {
+	*c.calls++
+	return []float64{c.value}
+}
+
+func TestClusterMaterializesCoordinatesOnce(t *testing.T) {
+	calls := 0
+	dataset := make([]Counted, 100)
+	for i := range dataset {
+		dataset[i] = Counted{value: float64(i % 10 * 10), calls: &calls}
+	}
+	rng := rand.New(rand.NewSource(0))
+
+	if _, err := ClusterResult(dataset, 10, 1e-9, 100, rng, WithInit(InitKMeansPlusPlus)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != len(dataset) {
+		t.Errorf("expected %d calls to Coordinates, got %d", len(dataset), calls)
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	points, err := materialize([]Coordinates{{1, 2}, {3, 4}, {5, 6}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Rows cannot grow into each other
+	if cap(points[0]) != 2 {
+		t.Errorf("unexpected row capacity %d", cap(points[0]))
+	}
+	if !slices.Equal(points[2], []float64{5, 6}) {
+		t.Errorf("unexpected row %v", points[2])
+	}
+
+	if _, err := materialize([]Vector{{1, 2}, {3}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
+
+func TestClusterFrozenCentroids(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 3)
+
+	// Two existing sites stay put while two new ones find the other blobs
+	frozen := [][]float64{{0, 0}, {50, 0}}
+	for _, opts := range [][]Option{
+		{WithFrozenCentroids(frozen), WithInit(InitMaximin)},
+		{WithFrozenCentroids(frozen), WithInit(InitKMeansPlusPlus), WithAlgorithm(Elkan)},
+		{WithFrozenCentroids(frozen), WithCentroids([][]float64{{1, 1}, {2, 2}, {30, 30}, {10, 40}}), WithMedians()},
+	} {
+		result, err := ClusterResult(dataset, 4, 0.001, 100, rand.New(rand.NewSource(0)), opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for j, centroid := range frozen {
+			if !slices.Equal(result.Centroids[j], centroid) {
+				t.Fatalf("frozen centroid %d moved to %v", j, result.Centroids[j])
+			}
+		}
+		for _, center := range centers[2:] {
+			if j, _ := nearest(center, result.Centroids, EuclideanDistance); j < 2 || EuclideanDistance(center, result.Centroids[j]) > 5 {
+				t.Fatalf("no new centroid near %v in %v", center, result.Centroids)
+			}
+		}
+	}
+
+	// Frozen centroids alone
+	result, err := ClusterResult(dataset, 1, 0.001, 100, nil, WithFrozenCentroids([][]float64{{25, 25}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(result.Centroids[0], []float64{25, 25}) {
+		t.Errorf("frozen centroid moved to %v", result.Centroids[0])
+	}
+}
+
+func TestClusterFrozenCentroidsValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 1, 0.01, 100, rng, WithFrozenCentroids([][]float64{{1}, {2}})); err == nil {
+		t.Error("expected error for too many frozen centroids")
+	}
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithFrozenCentroids([][]float64{{1, 2}})); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithFrozenCentroids([][]float64{{1}}), WithAlgorithm(MacQueen)); err == nil {
+		t.Error("expected error for online updates")
+	}
+}
@@ -0,0 +1,257 @@
+package kmeans
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// mean calculates the component-wise mean of points.
+func mean(points [][]float64) []float64 {
+	m := make([]float64, len(points[0]))
+	for _, p := range points {
+		for d := range m {
+			m[d] += p[d]
+		}
+	}
+	for d := range m {
+		m[d] /= float64(len(points))
+	}
+	return m
+}
+
+// covariance calculates the covariance matrix of points around their mean.
+func covariance(points [][]float64, mean []float64) [][]float64 {
+	dim := len(mean)
+	cov := make([][]float64, dim)
+	for a := range cov {
+		cov[a] = make([]float64, dim)
+	}
+	for _, p := range points {
+		for a := range dim {
+			da := p[a] - mean[a]
+			for b := a; b < dim; b++ {
+				cov[a][b] += da * (p[b] - mean[b])
+			}
+		}
+	}
+	for a := range dim {
+		for b := a; b < dim; b++ {
+			cov[a][b] /= float64(len(points))
+			cov[b][a] = cov[a][b]
+		}
+	}
+	return cov
+}
+
+// principalComponent returns the unit eigenvector of the symmetric matrix m
+// with the largest eigenvalue, computed by power iteration.
+func principalComponent(m [][]float64) []float64 {
+	dim := len(m)
+
+	// Start from the per-axis spread so the start vector is not orthogonal to
+	// the dominant direction in practice
+	v := make([]float64, dim)
+	for d := range dim {
+		v[d] = math.Sqrt(math.Abs(m[d][d]))
+	}
+	if normalize(v) == 0 {
+		v[0] = 1
+		return v
+	}
+
+	w := make([]float64, dim)
+	for range 1000 {
+		for a := range dim {
+			w[a] = 0
+			for b := range dim {
+				w[a] += m[a][b] * v[b]
+			}
+		}
+		if normalize(w) == 0 {
+			return v
+		}
+		delta := 0.0
+		for d := range dim {
+			delta = max(delta, math.Abs(w[d]-v[d]))
+		}
+		v, w = w, v
+		if delta < 1e-12 {
+			break
+		}
+	}
+	return v
+}
+
+// normalize scales v to unit length in place and returns its original length.
+func normalize(v []float64) float64 {
+	norm := 0.0
+	for _, x := range v {
+		norm += x * x
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		return 0
+	}
+	for d := range v {
+		v[d] /= norm
+	}
+	return norm
+}
+
+// dot returns the dot product of a and b.
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for d := range a {
+		sum += a[d] * b[d]
+	}
+	return sum
+}
+
+// invert returns the inverse and the determinant of the square matrix m,
+// computed by Gauss-Jordan elimination with partial pivoting. The inverse is
+// nil when m is singular.
+func invert(m [][]float64) ([][]float64, float64) {
+	dim := len(m)
+
+	// Augment a copy of m with the identity
+	a := make([][]float64, dim)
+	for r := range dim {
+		a[r] = make([]float64, 2*dim)
+		copy(a[r], m[r])
+		a[r][dim+r] = 1
+	}
+
+	det := 1.0
+	for col := range dim {
+		pivot := col
+		for r := col + 1; r < dim; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if a[pivot][col] == 0 {
+			return nil, 0
+		}
+		if pivot != col {
+			a[pivot], a[col] = a[col], a[pivot]
+			det = -det
+		}
+		p := a[col][col]
+		det *= p
+		for c := range a[col] {
+			a[col][c] /= p
+		}
+		for r := range dim {
+			if r == col || a[r][col] == 0 {
+				continue
+			}
+			f := a[r][col]
+			for c := range a[r] {
+				a[r][c] -= f * a[col][c]
+			}
+		}
+	}
+
+	inverse := make([][]float64, dim)
+	for r := range dim {
+		inverse[r] = a[r][dim:]
+	}
+	return inverse, det
+}
+
+// quadraticForm returns (x-v)ᵀ a (x-v).
+func quadraticForm(x, v []float64, a [][]float64) float64 {
+	sum := 0.0
+	for r := range a {
+		dr := x[r] - v[r]
+		for c := range a[r] {
+			sum += dr * a[r][c] * (x[c] - v[c])
+		}
+	}
+	return sum
+}
+
+// symmetricEigen returns the eigenvalues of the symmetric matrix m in
+// decreasing order and the matching unit eigenvectors, computed with the
+// cyclic Jacobi method.
+func symmetricEigen(m [][]float64) ([]float64, [][]float64) {
+	n := len(m)
+	a := newMatrix(n, n)
+	v := newMatrix(n, n)
+	norm := 0.0
+	for r := range n {
+		copy(a[r], m[r])
+		v[r][r] = 1
+		for c := range n {
+			norm += m[r][c] * m[r][c]
+		}
+	}
+
+	for range 100 {
+		// Stop once the off-diagonal entries are negligible
+		off := 0.0
+		for p := range n {
+			for q := p + 1; q < n; q++ {
+				off += a[p][q] * a[p][q]
+			}
+		}
+		if off <= 1e-24*norm {
+			break
+		}
+
+		// Zero every off-diagonal entry in turn with a plane rotation
+		for p := range n {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for r := range n {
+					a[r][p], a[r][q] = c*a[r][p]-s*a[r][q], s*a[r][p]+c*a[r][q]
+				}
+				for r := range n {
+					a[p][r], a[q][r] = c*a[p][r]-s*a[q][r], s*a[p][r]+c*a[q][r]
+				}
+				for r := range n {
+					v[r][p], v[r][q] = c*v[r][p]-s*v[r][q], s*v[r][p]+c*v[r][q]
+				}
+			}
+		}
+	}
+
+	// Sort the eigenpairs by decreasing eigenvalue
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(a[j][j], a[i][i])
+	})
+	values := make([]float64, n)
+	vectors := newMatrix(n, n)
+	for i, o := range order {
+		values[i] = a[o][o]
+		for r := range n {
+			vectors[i][r] = v[r][o]
+		}
+	}
+	return values, vectors
+}
+
+// newMatrix returns a zero matrix of the given numbers of rows and columns,
+// sharing one contiguous backing array.
+func newMatrix(rows, cols int) [][]float64 {
+	flat := make([]float64, rows*cols)
+	m := make([][]float64, rows)
+	for r := range m {
+		m[r] = flat[r*cols : (r+1)*cols : (r+1)*cols]
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrincipalComponent(t *testing.T) {
+	points := [][]float64{{0, 0}, {1, 1}, {2, 2}, {3, 3.1}, {4, 3.9}}
+	axis := principalComponent(covariance(points, mean(points)))
+
+	// The dominant direction is close to the diagonal
+	expected := 1 / math.Sqrt2
+	if math.Abs(math.Abs(axis[0])-expected) > 0.01 || math.Abs(math.Abs(axis[1])-expected) > 0.01 {
+		t.Errorf("unexpected principal component: %v", axis)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	m := [][]float64{{0, 2}, {4, 1}}
+	inverse, det := invert(m)
+	if det != -8 {
+		t.Errorf("expected determinant -8, got %v", det)
+	}
+
+	// m times its inverse is the identity
+	for r := range 2 {
+		for c := range 2 {
+			v := m[r][0]*inverse[0][c] + m[r][1]*inverse[1][c]
+			expected := 0.0
+			if r == c {
+				expected = 1
+			}
+			if math.Abs(v-expected) > 1e-12 {
+				t.Errorf("unexpected product at (%d, %d): %v", r, c, v)
+			}
+		}
+	}
+
+	if inverse, det := invert([][]float64{{1, 2}, {2, 4}}); inverse != nil || det != 0 {
+		t.Errorf("expected a singular matrix, got %v and %v", inverse, det)
+	}
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	m := [][]float64{{4, 1, 2}, {1, 3, 0}, {2, 0, 5}}
+	values, vectors := symmetricEigen(m)
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			t.Errorf("expected decreasing eigenvalues, got %v", values)
+		}
+	}
+
+	// m v = λ v for every eigenpair
+	for i, v := range vectors {
+		for r := range m {
+			if mv := dot(m[r], v); math.Abs(mv-values[i]*v[r]) > 1e-9 {
+				t.Errorf("eigenpair %d does not satisfy m v = λ v: %v and %v", i, mv, values[i]*v[r])
+			}
+		}
+		if math.Abs(dot(v, v)-1) > 1e-9 {
+			t.Errorf("expected unit eigenvector, got %v", v)
+		}
+	}
+}
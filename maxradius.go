@@ -0,0 +1,105 @@
+package kmeans
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxRadius clusters with as few clusters as it can find such that every
+// observation lies within radius of its centroid, measured with the
+// configured distance, instead of fixing k. Starting from a single cluster,
+// it runs k-means and, while an observation lies farther than radius from
+// its centroid, adds a cluster centred on the farthest such observation and
+// runs k-means again. Finding the least number of clusters is NP-hard, so
+// the number found may exceed it, but never the number of observations. The
+// chosen k is the number of centroids of the result.
+//
+// Each k-means run iterates until no centroid moves by deltaThreshold or more
+// or iterationThreshold iterations ran. The result's Iterations is the total
+// number of iterations and Converged reports whether the last run converged.
+// It is deterministic and honours WithDistance, WithCenter, WithAlgorithm and
+// WithWorkers, e.g. HaversineDistance and HaversineCenter for a radius in
+// kilometres.
+func MaxRadius[T Observation](dataset []T, radius, deltaThreshold float64, iterationThreshold int, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate radius
+	if !(radius > 0) {
+		return nil, fmt.Errorf("invalid radius: %f", radius)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate algorithm, the search relies on full k-means runs
+	if !cfg.algorithm.valid() || cfg.algorithm.stochastic() || cfg.algorithm == MacQueen || cfg.algorithm == HartiganWong {
+		return nil, fmt.Errorf("invalid algorithm: %d", cfg.algorithm)
+	}
+	if cfg.algorithm.accelerated() && !cfg.euclidean() {
+		return nil, fmt.Errorf("accelerated algorithms require the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	distance := cfg.distanceFunc()
+	centroids := [][]float64{cfg.centerOf(points)}
+	labels := make([]int, len(points))
+	iterations, converged := 0, false
+	for {
+		var runIterations int
+		centroids, runIterations, converged = lloydLoop(points, centroids, labels, deltaThreshold, iterationThreshold, cfg)
+		iterations += runIterations
+		newAssigner(points, cfg).assign(centroids, labels)
+
+		// Stop once every observation is within radius of its centroid
+		farthest, farthestDist := -1, radius
+		for i, j := range labels {
+			if d := distance(points[i], centroids[j]); d > farthestDist {
+				farthest, farthestDist = i, d
+			}
+		}
+		if farthest == -1 {
+			break
+		}
+
+		// One cluster per observation always meets the radius
+		if len(centroids) == len(points) {
+			for i := range points {
+				centroids[i] = slices.Clone(points[i])
+				labels[i] = i
+			}
+			break
+		}
+		centroids = append(centroids, slices.Clone(points[farthest]))
+	}
+
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
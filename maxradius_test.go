@@ -0,0 +1,76 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMaxRadius(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 2)
+
+	// A radius covering one blob finds the blobs
+	result, err := MaxRadius(dataset, 10, 0.001, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d clusters, got %d", len(centers), len(result.Centroids))
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/50*50] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+
+	// Smaller radii need more clusters, all within the radius
+	for _, radius := range []float64{5, 2} {
+		result, err := MaxRadius(dataset, radius, 0.001, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Centroids) <= len(centers) {
+			t.Errorf("expected more than %d clusters for radius %v, got %d", len(centers), radius, len(result.Centroids))
+		}
+		for i, j := range result.Labels {
+			if d := EuclideanDistance(dataset[i], result.Centroids[j]); d > radius {
+				t.Fatalf("observation %v lies %v from its centroid", dataset[i], d)
+			}
+		}
+	}
+
+	// Great-circle distances in kilometres
+	cities := []Vector{{48.85, 2.35}, {48.80, 2.13}, {51.51, -0.13}, {51.45, -0.30}}
+	result, err = MaxRadius(cities, 50, 1e-6, 100, WithDistance(HaversineDistance), WithCenter(HaversineCenter))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != 2 {
+		t.Errorf("expected 2 clusters, got %d", len(result.Centroids))
+	}
+}
+
+func TestMaxRadiusValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := MaxRadius([]Numbers{}, 1, 0.01, 10); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := MaxRadius(dataset, 0, 0.01, 10); err == nil {
+		t.Error("expected error for invalid radius")
+	}
+	if _, err := MaxRadius(dataset, 1, 0, 10); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := MaxRadius(dataset, 1, 0.01, 0); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := MaxRadius(dataset, 1, 0.01, 10, WithAlgorithm(MiniBatch)); err == nil {
+		t.Error("expected error for stochastic algorithm")
+	}
+	if _, err := MaxRadius(dataset, 1, 0.01, 10, WithAlgorithm(Elkan), WithMedians()); err == nil {
+		t.Error("expected error for Elkan with medians")
+	}
+	if _, err := MaxRadius(dataset, 1, 0.01, 10, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
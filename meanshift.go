@@ -0,0 +1,156 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MeanShift implements mean-shift clustering, which seeks the modes of the
+// density of the observations. Starting from every observation, a point
+// repeatedly moves to the mean of the observations within distance bandwidth
+// of it (a flat kernel) until it moves by less than deltaThreshold or
+// iterationThreshold iterations ran. Modes closer than bandwidth to a mode
+// with more observations around it are discarded and every observation joins
+// the cluster of its nearest remaining mode, so the number of clusters
+// follows from the bandwidth. EstimateBandwidth suggests a bandwidth.
+//
+// The result's Centroids are the modes, its Iterations the largest number of
+// iterations of a starting point and Converged reports whether they all
+// converged. Time grows with the square of the number of observations. It
+// requires the Euclidean distance and mean centroids and honours WithWorkers.
+func MeanShift[T Observation](dataset []T, bandwidth, deltaThreshold float64, iterationThreshold int, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate bandwidth
+	if !(bandwidth > 0) {
+		return nil, fmt.Errorf("invalid bandwidth: %f", bandwidth)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate distance, the shift moves points to means
+	if !cfg.euclidean() {
+		return nil, fmt.Errorf("mean-shift requires the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	n := len(points)
+	radius := bandwidth * bandwidth
+
+	// Shift a point from every observation up to its mode
+	modes := make([][]float64, n)
+	supports := make([]int, n)
+	iterations := make([]int, n)
+	converged := make([]bool, n)
+	parallel(n, cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			mode := slices.Clone(points[i])
+			for range iterationThreshold {
+				iterations[i]++
+				next := make([]float64, len(mode))
+				within := 0
+				for _, p := range points {
+					if squaredDistance(p, mode) <= radius {
+						within++
+						for d, x := range p {
+							next[d] += x
+						}
+					}
+				}
+				for d := range next {
+					next[d] /= float64(within)
+				}
+				shift := EuclideanDistance(mode, next)
+				mode, supports[i] = next, within
+				if shift < deltaThreshold {
+					converged[i] = true
+					break
+				}
+			}
+			modes[i] = mode
+		}
+	})
+
+	// Keep the best supported modes apart from each other
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(supports[b], supports[a])
+	})
+	var centroids [][]float64
+	for _, i := range order {
+		if !slices.ContainsFunc(centroids, func(c []float64) bool {
+			return squaredDistance(c, modes[i]) < radius
+		}) {
+			centroids = append(centroids, modes[i])
+		}
+	}
+
+	labels := make([]int, n)
+	for i, p := range points {
+		labels[i], _ = nearest(p, centroids, EuclideanDistance)
+	}
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = slices.Max(iterations)
+	result.Converged = !slices.Contains(converged, false)
+	return result, nil
+}
+
+// EstimateBandwidth suggests a bandwidth for MeanShift: the average distance
+// of every observation to its nearest neighbour of rank quantile times the
+// number of observations, itself counting as the first. Larger quantiles give
+// larger bandwidths and fewer clusters; 0.3 is a common choice. Time grows
+// with the square of the number of observations.
+func EstimateBandwidth[T Observation](dataset []T, quantile float64) (float64, error) {
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return 0, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate quantile
+	if !(quantile > 0 && quantile <= 1) {
+		return 0, fmt.Errorf("invalid quantile: %f", quantile)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return 0, err
+	}
+	n := len(points)
+	rank := max(1, int(quantile*float64(n)))
+	dists := make([]float64, n)
+	total := 0.0
+	for _, p := range points {
+		for j, q := range points {
+			dists[j] = squaredDistance(p, q)
+		}
+		slices.Sort(dists)
+		total += math.Sqrt(dists[rank-1])
+	}
+	return total / float64(n), nil
+}
@@ -0,0 +1,75 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMeanShift(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	result, err := MeanShift(dataset, 10, 1e-6, 300)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d clusters, got %d", len(centers), len(result.Centroids))
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/100*100] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+	for _, center := range centers {
+		j, d := nearest(center, result.Centroids, EuclideanDistance)
+		if d > 1 {
+			t.Errorf("expected a mode near %v, got %v", center, result.Centroids[j])
+		}
+	}
+}
+
+func TestEstimateBandwidth(t *testing.T) {
+	dataset := []Numbers{0, 1, 3, 6}
+	bandwidth, err := EstimateBandwidth(dataset, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Distances to the second nearest, the observation itself being the first
+	if expected := (1.0 + 1 + 2 + 3) / 4; math.Abs(bandwidth-expected) > 1e-12 {
+		t.Errorf("expected bandwidth %v, got %v", expected, bandwidth)
+	}
+
+	blobs := gaussians(rand.New(rand.NewSource(0)), []Vector{{0, 0}, {50, 0}, {0, 50}}, 100, 3)
+	bandwidth, err = EstimateBandwidth(blobs, 0.2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := MeanShift(blobs, bandwidth, 1e-6, 300)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != 3 {
+		t.Errorf("expected 3 clusters with the estimated bandwidth %v, got %d", bandwidth, len(result.Centroids))
+	}
+}
+
+func TestMeanShiftValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := MeanShift(dataset, 0, 1e-6, 100); err == nil {
+		t.Error("expected error for invalid bandwidth")
+	}
+	if _, err := MeanShift(dataset, 1, 0, 100); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := MeanShift(dataset, 1, 1e-6, 100, WithMedians()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+	if _, err := EstimateBandwidth(dataset, 0); err == nil {
+		t.Error("expected error for invalid quantile")
+	}
+	if _, err := EstimateBandwidth([]Numbers{}, 0.3); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+}
@@ -0,0 +1,257 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// defaultSampleCount is the number of samples CLARA clusters when none is
+// configured.
+const defaultSampleCount = 5
+
+// MedoidResult holds the outcome of a k-medoids run. Its centroids are the
+// coordinates of the medoids, observations of the dataset minimising the sum
+// of distances to the members of their cluster.
+type MedoidResult[T Observation] struct {
+	Result[T]
+	// Medoids holds the index in the dataset of the medoid of each cluster.
+	Medoids []int
+	// Cost is the sum of the distances of every observation to its medoid.
+	Cost float64
+}
+
+// CLARA implements Clustering LARge Applications: it runs PAM (Partitioning
+// Around Medoids) on several random samples of the dataset and keeps the
+// medoids with the lowest cost over the whole dataset. Each sample after the
+// first includes the best medoids found so far. This keeps medoid clustering
+// tractable for hundreds of thousands of observations, since PAM is quadratic
+// in the number of observations.
+//
+// The number of samples is set with WithSampleCount (default 5) and their size
+// with WithSampleSize (default 40 + 2k). A sample size of at least the size of
+// the dataset runs PAM on the whole dataset. Observations are compared with
+// the configured distance, which need not be Euclidean.
+func CLARA[T Observation](dataset []T, k int, rng *rand.Rand, opts ...Option) (*MedoidResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate samples
+	if cfg.sampleCount < 0 {
+		return nil, fmt.Errorf("invalid sample count: %d", cfg.sampleCount)
+	}
+	if cfg.sampleSize < 0 || (cfg.sampleSize > 0 && cfg.sampleSize < k) {
+		return nil, fmt.Errorf("invalid sample size: %d", cfg.sampleSize)
+	}
+	count, size := cfg.sampleCount, cfg.sampleSize
+	if count == 0 {
+		count = defaultSampleCount
+	}
+	if size == 0 {
+		size = 40 + 2*k
+	}
+	size = min(size, len(dataset))
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	distance := cfg.distanceFunc()
+
+	var best []int
+	bestCost := math.Inf(1)
+	bestSwaps := 0
+	for range count {
+		// Draw a sample including the best medoids so far
+		sample := slices.Clone(best)
+		for _, i := range randomIndices(len(points), len(points), rng) {
+			if len(sample) == size {
+				break
+			}
+			if !slices.Contains(best, i) {
+				sample = append(sample, i)
+			}
+		}
+
+		// Run PAM on the sample and evaluate the medoids on the dataset
+		local, swaps := pam(distanceMatrix(points, sample, distance), k)
+		medoids := make([]int, k)
+		for j, m := range local {
+			medoids[j] = sample[m]
+		}
+		if cost := medoidCost(points, medoids, distance); cost < bestCost {
+			best, bestCost, bestSwaps = medoids, cost, swaps
+		}
+
+		// A sample of the whole dataset gives the same result every time
+		if size == len(points) {
+			break
+		}
+	}
+
+	return newMedoidResult(dataset, points, best, bestCost, bestSwaps, cfg), nil
+}
+
+// newMedoidResult assigns every point to its nearest medoid and describes the
+// clusters, swaps being the number of swaps that led to the medoids.
+func newMedoidResult[T Observation](dataset []T, points [][]float64, medoids []int, cost float64, swaps int, cfg *config) *MedoidResult[T] {
+	distance := cfg.distanceFunc()
+	centroids := make([][]float64, len(medoids))
+	for j, m := range medoids {
+		centroids[j] = slices.Clone(points[m])
+	}
+	labels := make([]int, len(points))
+	for i, p := range points {
+		labels[i], _ = nearest(p, centroids, distance)
+	}
+	result := &MedoidResult[T]{
+		Result:  *newResult(dataset, points, centroids, labels, nil, cfg.lossFunc()),
+		Medoids: medoids,
+		Cost:    cost,
+	}
+	result.Iterations = swaps
+	result.Converged = true
+	return result
+}
+
+// distanceMatrix returns the distances between the points of the given
+// indices.
+func distanceMatrix(points [][]float64, indices []int, distance DistanceFunc) [][]float64 {
+	matrix := make([][]float64, len(indices))
+	for a := range indices {
+		matrix[a] = make([]float64, len(indices))
+	}
+	for a, i := range indices {
+		for b := a + 1; b < len(indices); b++ {
+			d := distance(points[i], points[indices[b]])
+			matrix[a][b] = d
+			matrix[b][a] = d
+		}
+	}
+	return matrix
+}
+
+// medoidCost returns the sum of the distances of every point to its nearest
+// medoid.
+func medoidCost(points [][]float64, medoids []int, distance DistanceFunc) float64 {
+	cost := 0.0
+	for _, p := range points {
+		best := math.Inf(1)
+		for _, m := range medoids {
+			best = min(best, distance(p, points[m]))
+		}
+		cost += best
+	}
+	return cost
+}
+
+// pam runs Partitioning Around Medoids over the points of the distance matrix
+// dist: the BUILD phase greedily adds the medoid reducing the cost most, then
+// the SWAP phase applies the best swap of a medoid with a non-medoid as long
+// as it reduces the cost. It returns the indices of the k medoids and the
+// number of swaps.
+func pam(dist [][]float64, k int) ([]int, int) {
+	n := len(dist)
+	isMedoid := make([]bool, n)
+	medoids := make([]int, 0, k)
+
+	// Distance of each point to its nearest and second nearest medoids, and
+	// the position in medoids of the nearest
+	first := make([]float64, n)
+	second := make([]float64, n)
+	closest := make([]int, n)
+	for o := range n {
+		first[o], second[o] = math.Inf(1), math.Inf(1)
+	}
+
+	// BUILD: add the point reducing the total distance most
+	for len(medoids) < k {
+		best, bestGain := -1, -1.0
+		for h := range n {
+			if isMedoid[h] {
+				continue
+			}
+			gain := 0.0
+			for o := range n {
+				if first[o] == math.Inf(1) {
+					gain -= dist[o][h]
+				} else {
+					gain += max(0, first[o]-dist[o][h])
+				}
+			}
+			if best < 0 || gain > bestGain {
+				best, bestGain = h, gain
+			}
+		}
+		isMedoid[best] = true
+		medoids = append(medoids, best)
+		for o := range n {
+			first[o], second[o], closest[o] = twoNearestMedoids(dist[o], medoids)
+		}
+	}
+
+	// SWAP: apply the best improving swap until none is left
+	swaps := 0
+	for {
+		bestI, bestH, bestDelta := -1, -1, 0.0
+		for i := range medoids {
+			for h := range n {
+				if isMedoid[h] {
+					continue
+				}
+				delta := 0.0
+				for o := range n {
+					if closest[o] == i {
+						delta += min(dist[o][h], second[o]) - first[o]
+					} else {
+						delta += min(dist[o][h]-first[o], 0)
+					}
+				}
+				if delta < bestDelta-1e-12 {
+					bestI, bestH, bestDelta = i, h, delta
+				}
+			}
+		}
+		if bestI < 0 {
+			return medoids, swaps
+		}
+		swaps++
+		isMedoid[medoids[bestI]] = false
+		isMedoid[bestH] = true
+		medoids[bestI] = bestH
+		for o := range n {
+			first[o], second[o], closest[o] = twoNearestMedoids(dist[o], medoids)
+		}
+	}
+}
+
+// twoNearestMedoids returns the distances from a point, given its row of the
+// distance matrix, to its nearest and second nearest medoids, and the position
+// in medoids of the nearest.
+func twoNearestMedoids(row []float64, medoids []int) (float64, float64, int) {
+	first, second, closest := math.Inf(1), math.Inf(1), -1
+	for j, m := range medoids {
+		switch d := row[m]; {
+		case d < first:
+			first, second, closest = d, first, j
+		case d < second:
+			second = d
+		}
+	}
+	return first, second, closest
+}
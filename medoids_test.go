@@ -0,0 +1,72 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPAM(t *testing.T) {
+	points := [][]float64{{1}, {2}, {3}, {11}, {12}, {13}, {21}, {22}, {23}}
+	indices := []int{0, 1, 2, 3, 4, 5, 6, 7, 8}
+	medoids, _ := pam(distanceMatrix(points, indices, EuclideanDistance), 3)
+	slices.Sort(medoids)
+	if !slices.Equal(medoids, []int{1, 4, 7}) {
+		t.Errorf("expected medoids [1 4 7], got %v", medoids)
+	}
+}
+
+func TestCLARA(t *testing.T) {
+	// A sample as large as the dataset runs PAM on all observations
+	dataset := []Numbers{1, 2, 3, 11, 12, 13, 21, 22, 23, 1000}
+	result, err := CLARA(dataset, 4, rand.New(rand.NewSource(0)), WithSampleSize(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	medoids := slices.Sorted(slices.Values(result.Medoids))
+	if !slices.Equal(medoids, []int{1, 4, 7, 9}) {
+		t.Errorf("expected medoids [1 4 7 9], got %v", medoids)
+	}
+	if result.Cost != 6 {
+		t.Errorf("expected cost 6, got %v", result.Cost)
+	}
+}
+
+func TestCLARALarge(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20000, 4, 2)
+	expected, err := ClusterResult(dataset, 4, 1e-6, 100, rand.New(rand.NewSource(0)), WithInit(InitGreedyKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := CLARA(dataset, 4, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Medoids of compact blobs lie close to the means
+	for _, medoid := range result.Centroids {
+		if _, d := nearest(medoid, expected.Centroids, EuclideanDistance); d > 1.5 {
+			t.Errorf("expected medoid %v close to a centroid of %v", medoid, expected.Centroids)
+		}
+	}
+	if len(result.Labels) != len(dataset) {
+		t.Errorf("expected %d labels, got %d", len(dataset), len(result.Labels))
+	}
+}
+
+func TestCLARAValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := CLARA(dataset, 2, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := CLARA(dataset, 7, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := CLARA(dataset, 3, rng, WithSampleSize(2)); err == nil {
+		t.Error("expected error for a sample smaller than k")
+	}
+	if _, err := CLARA(dataset, 2, rng, WithSampleCount(-1)); err == nil {
+		t.Error("expected error for negative sample count")
+	}
+}
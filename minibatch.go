@@ -0,0 +1,71 @@
+package kmeans
+
+import "math/rand"
+
+// defaultBatchSize is the batch size of MiniBatch when none is configured.
+const defaultBatchSize = 1024
+
+// miniBatch runs Sculley's mini-batch k-means from centroids. Each iteration
+// samples a batch of points, assigns them to their nearest centroid and moves
+// each centroid towards its points with a per-centroid learning rate of one
+// over the number of points it has absorbed so far. Once done, every point is
+// assigned to its nearest centroid in labels. It returns the final centroids,
+// the number of iterations and whether the run converged.
+func miniBatch(points, centroids [][]float64, labels []int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, cfg *config) ([][]float64, int, bool) {
+	batchSize := cfg.batchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	distance := cfg.distanceFunc()
+
+	counts := make([]float64, len(centroids))
+	batch := make([][]float64, batchSize)
+	batchWeights := make([]float64, batchSize)
+	batchLabels := make([]int, batchSize)
+	iterations := 0
+	converged := false
+	for range iterationThreshold {
+		iterations++
+
+		// Sample a batch with replacement and assign it
+		for b := range batch {
+			i := rng.Intn(len(points))
+			batch[b] = points[i]
+			batchWeights[b] = cfg.weight(i)
+		}
+		previous := cloneAll(centroids)
+		parallel(len(batch), cfg.workerCount(), func(_, start, end int) {
+			for b := start; b < end; b++ {
+				batchLabels[b], _ = nearest(batch[b], previous, distance)
+			}
+		})
+
+		// Gradient step with per-centroid learning rates
+		miniBatchStep(centroids, counts, batch, batchWeights, batchLabels, cfg.spherical)
+
+		// Stop if maximum movement is below the threshold
+		if maxDrift(previous, centroids) < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	// Final labelling of every point
+	newAssigner(points, cfg).assign(centroids, labels)
+	return centroids, iterations, converged
+}
+
+// miniBatchStep moves each centroid towards the points assigned to it in
+// batch, with the given weights, counts holding the total weight each
+// centroid absorbed so far. In spherical mode updated centroids are
+// renormalized.
+func miniBatchStep(centroids [][]float64, counts []float64, batch [][]float64, weights []float64, labels []int, spherical bool) {
+	for b, point := range batch {
+		nudge(centroids[labels[b]], &counts[labels[b]], point, weights[b])
+	}
+	if spherical {
+		for _, centroid := range centroids {
+			normalize(centroid)
+		}
+	}
+}
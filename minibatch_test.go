@@ -0,0 +1,53 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestClusterMiniBatch(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20000, 8, 4)
+
+	expected, err := ClusterResult(dataset, 8, 1e-6, 300, rand.New(rand.NewSource(1)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := ClusterResult(dataset, 8, 1e-3, 300, rand.New(rand.NewSource(1)),
+		WithInit(InitKMeansPlusPlus),
+		WithAlgorithm(MiniBatch),
+		WithBatchSize(256),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Mini-batch gets within a few percent of the full Lloyd inertia
+	if result.Inertia > 1.05*expected.Inertia {
+		t.Errorf("expected inertia close to %v, got %v", expected.Inertia, result.Inertia)
+	}
+	if len(result.Labels) != len(dataset) {
+		t.Errorf("expected %d labels, got %d", len(dataset), len(result.Labels))
+	}
+}
+
+func TestMiniBatchStep(t *testing.T) {
+	centroids := [][]float64{{0}, {10}}
+	counts := []float64{0, 1}
+
+	// A fresh centroid jumps onto its first point, a used one moves halfway
+	miniBatchStep(centroids, counts, [][]float64{{4}, {20}}, []float64{1, 1}, []int{0, 1}, false)
+	if centroids[0][0] != 4 || centroids[1][0] != 15 {
+		t.Errorf("unexpected centroids %v", centroids)
+	}
+}
+
+func TestClusterMiniBatchValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := Cluster(dataset, 2, 0.01, 100, nil, WithAlgorithm(MiniBatch), WithInit(InitMaximin)); err == nil {
+		t.Error("expected error for mini-batch without a random number generator")
+	}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 2, 0.01, 100, rng, WithAlgorithm(MiniBatch), WithBatchSize(-1)); err == nil {
+		t.Error("expected error for negative batch size")
+	}
+}
@@ -0,0 +1,270 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// Model is a k-means model configured once, fitted with Fit and then used to
+// predict the cluster of new observations, for applications that keep the
+// trained centroids around.
+//
+// A Model is safe for concurrent use: predictions run concurrently with each
+// other, while Fit and PartialFit wait for them and hold them off until the
+// model is updated.
+type Model[T Observation] struct {
+	mu                 sync.RWMutex
+	k                  int
+	deltaThreshold     float64
+	iterationThreshold int
+	rng                *rand.Rand
+	opts               []Option
+	cfg                *config
+	centroids          [][]float64
+	counts             []float64
+	inertia            float64
+}
+
+// NewModel returns an unfitted Model of k clusters. Fit runs ClusterResult
+// with deltaThreshold, iterationThreshold, rng and opts, and predictions use
+// the configured distance and WithWorkers.
+func NewModel[T Observation](k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Model[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate k
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	return &Model[T]{
+		k:                  k,
+		deltaThreshold:     deltaThreshold,
+		iterationThreshold: iterationThreshold,
+		rng:                rng,
+		opts:               slices.Clone(opts),
+		cfg:                cfg,
+	}, nil
+}
+
+// Fit clusters the dataset and keeps the resulting centroids and inertia,
+// replacing those of a previous fit. On error the model is left unchanged.
+func (m *Model[T]) Fit(dataset []T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.fit(dataset)
+}
+
+// fit clusters the dataset and keeps the resulting centroids, the weight of
+// their clusters and the inertia.
+func (m *Model[T]) fit(dataset []T) error {
+	result, err := ClusterResult(dataset, m.k, m.deltaThreshold, m.iterationThreshold, m.rng, m.opts...)
+	if err != nil {
+		return err
+	}
+	weights, err := datasetWeights(dataset, m.cfg)
+	if err != nil {
+		return err
+	}
+	counts := make([]float64, m.k)
+	for i, j := range result.Labels {
+		if weights != nil {
+			counts[j] += weights[i]
+		} else {
+			counts[j]++
+		}
+	}
+	m.centroids, m.counts, m.inertia = result.Centroids, counts, result.Inertia
+	return nil
+}
+
+// PartialFit updates the model with a batch of newly arrived observations
+// without revisiting the data it was fitted on, as mini-batch k-means does:
+// every observation of the batch is assigned to its nearest centroid, then
+// moves it towards itself with a learning rate of its weight over the total
+// weight the centroid absorbed, starting from the size of its cluster in the
+// last fit. Centroids set with WithFrozenCentroids stay in place. An
+// unfitted model is fitted on the first batch instead.
+//
+// Inertia then reports the inertia of the batch against the updated
+// centroids. It honours the weights of weighted observations and requires
+// mean centroids. On error the model is left unchanged.
+func (m *Model[T]) PartialFit(batch []T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Validate center, online updates only maintain means
+	if m.cfg.center != nil {
+		return fmt.Errorf("online updates require mean centroids")
+	}
+	if m.centroids == nil {
+		return m.fit(batch)
+	}
+
+	points, err := m.materialize(batch)
+	if err != nil {
+		return err
+	}
+	weights, err := observationWeights(batch)
+	if err != nil {
+		return err
+	}
+	if m.cfg.spherical {
+		for _, p := range points {
+			normalize(p)
+		}
+	}
+
+	// Assign the batch, then update the centroids
+	distance := m.cfg.distanceFunc()
+	labels := make([]int, len(points))
+	parallel(len(points), m.cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			labels[i], _ = nearest(points[i], m.centroids, distance)
+		}
+	})
+	for i, j := range labels {
+		if j < len(m.cfg.frozen) {
+			continue
+		}
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		nudge(m.centroids[j], &m.counts[j], points[i], w)
+		if m.cfg.spherical {
+			normalize(m.centroids[j])
+		}
+	}
+
+	loss := m.cfg.lossFunc()
+	m.inertia = 0
+	for i, p := range points {
+		j, _ := nearest(p, m.centroids, distance)
+		if weights != nil {
+			m.inertia += weights[i] * loss(p, m.centroids[j])
+		} else {
+			m.inertia += loss(p, m.centroids[j])
+		}
+	}
+	return nil
+}
+
+// Predict returns the index of the centroid nearest to obs. It fails if the
+// model is not fitted.
+func (m *Model[T]) Predict(obs T) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.centroids == nil {
+		return 0, fmt.Errorf("model is not fitted")
+	}
+	point := obs.Coordinates()
+	if len(point) != len(m.centroids[0]) {
+		return 0, fmt.Errorf("inconsistent dimensions")
+	}
+	j, _ := nearest(point, m.centroids, m.cfg.distanceFunc())
+	return j, nil
+}
+
+// PredictBatch returns, for each observation of dataset in order, the index
+// of the centroid nearest to it, spreading the observations over WithWorkers
+// goroutines. It fails if the model is not fitted or if an observation does
+// not have the dimension of the centroids.
+func (m *Model[T]) PredictBatch(dataset []T) ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	points, err := m.materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	distance := m.cfg.distanceFunc()
+	labels := make([]int, len(points))
+	parallel(len(points), m.cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			labels[i], _ = nearest(points[i], m.centroids, distance)
+		}
+	})
+	return labels, nil
+}
+
+// Transform returns, for each observation of dataset in order, its distance
+// to each centroid, measured with the configured distance: an n×k matrix
+// embedding the observations in distance space, e.g. as features for a
+// downstream classifier. It spreads the observations over WithWorkers
+// goroutines and fails like PredictBatch.
+func (m *Model[T]) Transform(dataset []T) ([][]float64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	points, err := m.materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	distance := m.cfg.distanceFunc()
+	distances := newMatrix(len(points), len(m.centroids))
+	parallel(len(points), m.cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			for j, centroid := range m.centroids {
+				distances[i][j] = distance(points[i], centroid)
+			}
+		}
+	})
+	return distances, nil
+}
+
+// materialize copies the coordinates of the observations of dataset for
+// prediction. It fails if the model is not fitted or if an observation does
+// not have the dimension of the centroids.
+func (m *Model[T]) materialize(dataset []T) ([][]float64, error) {
+	if m.centroids == nil {
+		return nil, fmt.Errorf("model is not fitted")
+	}
+	if len(dataset) == 0 {
+		return [][]float64{}, nil
+	}
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if len(points[0]) != len(m.centroids[0]) {
+		return nil, fmt.Errorf("inconsistent dimensions")
+	}
+	return points, nil
+}
+
+// Centroids returns a copy of the fitted centroids, or nil if the model is
+// not fitted.
+func (m *Model[T]) Centroids() [][]float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.centroids == nil {
+		return nil
+	}
+	return cloneAll(m.centroids)
+}
+
+// Inertia returns the inertia of the dataset of the last fit.
+func (m *Model[T]) Inertia() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.inertia
+}
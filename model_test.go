@@ -0,0 +1,291 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestModel(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	model, err := NewModel[Vector](3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := model.Predict(Vector{0, 0}); err == nil {
+		t.Error("expected error predicting with an unfitted model")
+	}
+	if model.Centroids() != nil {
+		t.Error("expected no centroids before fitting")
+	}
+
+	if err := model.Fit(dataset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := ClusterResult(dataset, 3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if model.Inertia() != result.Inertia {
+		t.Errorf("expected inertia %f, got %f", result.Inertia, model.Inertia())
+	}
+	centroids := model.Centroids()
+	for j := range centroids {
+		if !slices.Equal(centroids[j], result.Centroids[j]) {
+			t.Fatalf("expected centroid %d to be %v, got %v", j, result.Centroids[j], centroids[j])
+		}
+	}
+
+	// Centroids returns a copy
+	centroids[0][0] = 1000
+	if model.Centroids()[0][0] == 1000 {
+		t.Error("expected Centroids to return a copy")
+	}
+
+	// New observations go to the cluster of their center
+	for _, center := range centers {
+		j, err := model.Predict(center)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dist := EuclideanDistance(model.Centroids()[j], center); dist > 3 {
+			t.Errorf("expected %v to be predicted near its center, got centroid %v", center, model.Centroids()[j])
+		}
+	}
+	if _, err := model.Predict(Vector{0}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+
+	// A failed fit leaves the model unchanged
+	if err := model.Fit([]Vector{{1, 2}}); err == nil {
+		t.Error("expected error fitting fewer observations than clusters")
+	}
+	if model.Inertia() != result.Inertia {
+		t.Error("expected a failed fit to leave the model unchanged")
+	}
+}
+
+func TestModelValidation(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	if _, err := NewModel[Numbers](0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := NewModel[Numbers](2, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := NewModel[Numbers](2, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	model, err := NewModel[Numbers](2, 0.01, 10, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := model.Fit([]Numbers{1, 2, 3}); err == nil {
+		t.Error("expected error fitting without a random number generator")
+	}
+}
+
+func TestModelPredictBatch(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+	unseen := gaussians(rand.New(rand.NewSource(1)), centers, 100, 3)
+
+	model, err := NewModel[Vector](3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus), WithWorkers(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := model.PredictBatch(unseen); err == nil {
+		t.Error("expected error predicting with an unfitted model")
+	}
+	if err := model.Fit(dataset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	labels, err := model.PredictBatch(unseen)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != len(unseen) {
+		t.Fatalf("expected %d labels, got %d", len(unseen), len(labels))
+	}
+	for i, obs := range unseen {
+		j, err := model.Predict(obs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if labels[i] != j {
+			t.Fatalf("expected observation %d in cluster %d, got %d", i, j, labels[i])
+		}
+	}
+
+	// The metric of the model is used
+	manhattan, err := NewModel[Vector](2, 0.001, 100, nil, WithCentroids([][]float64{{0, 0}, {3, 1}}), WithDistance(ManhattanDistance))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manhattan.Fit([]Vector{{0, 0}, {3, 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// {1.8, 0} is closer to {3, 1} in Euclidean distance but not in Manhattan
+	labels, err = manhattan.PredictBatch([]Vector{{1.8, 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if labels[0] != 0 {
+		t.Errorf("expected the Manhattan distance to pick cluster 0, got %d", labels[0])
+	}
+
+	if labels, err := model.PredictBatch(nil); err != nil || len(labels) != 0 {
+		t.Errorf("expected no labels for no observations, got %v and %v", labels, err)
+	}
+	if _, err := model.PredictBatch([]Vector{{1}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, err := NewModel[Vector](3, 0.001, 100, nil, WithWorkers(-1)); err == nil {
+		t.Error("expected error for invalid number of workers")
+	}
+}
+
+func TestModelTransform(t *testing.T) {
+	model, err := NewModel[Vector](2, 0.001, 100, nil, WithCentroids([][]float64{{0, 0}, {3, 4}}), WithWorkers(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := model.Transform([]Vector{{0, 0}}); err == nil {
+		t.Error("expected error transforming with an unfitted model")
+	}
+	if err := model.Fit([]Vector{{0, 0}, {3, 4}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	distances, err := model.Transform([]Vector{{0, 0}, {3, 0}, {3, 4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]float64{{0, 5}, {3, 4}, {5, 0}}
+	for i := range want {
+		if !slices.Equal(distances[i], want[i]) {
+			t.Errorf("expected distances %v for observation %d, got %v", want[i], i, distances[i])
+		}
+	}
+
+	if distances, err := model.Transform(nil); err != nil || len(distances) != 0 {
+		t.Errorf("expected no distances for no observations, got %v and %v", distances, err)
+	}
+	if _, err := model.Transform([]Vector{{1}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
+
+func TestModelPartialFit(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	rng := rand.New(rand.NewSource(0))
+
+	// An unfitted model is fitted on the first batch
+	model, err := NewModel[Vector](3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := model.PartialFit(gaussians(rng, centers, 20, 3)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Centroids()) != 3 {
+		t.Fatalf("expected 3 centroids, got %d", len(model.Centroids()))
+	}
+
+	// The centroids follow the clusters drifting away batch after batch
+	shifted := make([]Vector, len(centers))
+	for range 20 {
+		for c, center := range centers {
+			shifted[c] = Vector{center[0] + 5, center[1]}
+		}
+		if err := model.PartialFit(gaussians(rng, shifted, 20, 3)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for _, center := range shifted {
+		j, err := model.Predict(center)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dist := EuclideanDistance(model.Centroids()[j], center); dist > 3.5 {
+			t.Errorf("expected a centroid near %v, got %v", center, model.Centroids()[j])
+		}
+	}
+	if model.Inertia() <= 0 {
+		t.Errorf("expected the inertia of the last batch, got %f", model.Inertia())
+	}
+
+	// Counts carry over from the fit: a batch as large as the fitted dataset
+	// moves a centroid halfway
+	numbers, err := NewModel[Numbers](2, 0.001, 100, nil, WithCentroids([][]float64{{0}, {10}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := numbers.Fit([]Numbers{0, 0, 10, 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := numbers.PartialFit([]Numbers{2, 2, 14}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	centroids := numbers.Centroids()
+	if centroids[0][0] != 1 || math.Abs(centroids[1][0]-34.0/3) > 1e-12 {
+		t.Errorf("expected centroids 1 and 34/3, got %v", centroids)
+	}
+	if want := 1.0 + 1 + (14-34.0/3)*(14-34.0/3); math.Abs(numbers.Inertia()-want) > 1e-9 {
+		t.Errorf("expected the inertia of the batch %f, got %f", want, numbers.Inertia())
+	}
+}
+
+func TestModelPartialFitFrozen(t *testing.T) {
+	model, err := NewModel[Numbers](2, 0.001, 100, nil, WithCentroids([][]float64{{0}, {10}}), WithFrozenCentroids([][]float64{{0}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := model.Fit([]Numbers{0, 1, 10, 11}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := model.PartialFit([]Numbers{3, 3, 13}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	centroids := model.Centroids()
+	if centroids[0][0] != 0 || centroids[1][0] == 10.5 {
+		t.Errorf("expected only the unfrozen centroid to move, got %v", centroids)
+	}
+}
+
+func TestModelPartialFitValidation(t *testing.T) {
+	model, err := NewModel[Numbers](2, 0.001, 100, nil, WithCentroids([][]float64{{0}, {10}}), WithCenter(MedianCenter))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := model.PartialFit([]Numbers{1, 2, 3}); err == nil {
+		t.Error("expected error for custom centers")
+	}
+
+	model, err = NewModel[Numbers](3, 0.001, 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := model.PartialFit([]Numbers{1, 2}); err == nil {
+		t.Error("expected error for a first batch smaller than k")
+	}
+
+	vectors, err := NewModel[Vector](1, 0.001, 100, nil, WithCentroids([][]float64{{0, 0}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vectors.Fit([]Vector{{0, 0}, {2, 2}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := vectors.Centroids()
+	if err := vectors.PartialFit([]Vector{{1}}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if !slices.Equal(vectors.Centroids()[0], before[0]) {
+		t.Error("expected a failed update to leave the model unchanged")
+	}
+}
@@ -0,0 +1,107 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normalization selects how NormalizedMutualInformation scales the mutual
+// information by the entropies of the two labelings.
+type Normalization int
+
+const (
+	// NormalizationArithmetic divides by the arithmetic mean of the
+	// entropies.
+	NormalizationArithmetic Normalization = iota
+	// NormalizationGeometric divides by the geometric mean of the entropies.
+	NormalizationGeometric
+	// NormalizationMin divides by the smaller entropy.
+	NormalizationMin
+	// NormalizationMax divides by the larger entropy.
+	NormalizationMax
+
+	// numNormalizations is the number of supported normalizations.
+	numNormalizations
+)
+
+// valid reports whether n is a supported normalization.
+func (n Normalization) valid() bool {
+	return n >= 0 && n < numNormalizations
+}
+
+// MutualInformation returns the mutual information, in nats, between two
+// labelings of the same observations, such as the Labels of a Result and
+// known classes: how much knowing the label of an observation in one tells
+// about its label in the other. It is 0 for independent labelings. Labels
+// are compared for equality only.
+func MutualInformation(labels, truth []int) (float64, error) {
+	counts, rows, cols, err := contingency(labels, truth)
+	if err != nil {
+		return 0, err
+	}
+	return mutualInformation(counts, rows, cols, float64(len(labels))), nil
+}
+
+// NormalizedMutualInformation returns the mutual information between two
+// labelings of the same observations divided by a mean of their entropies
+// selected by normalization, between 0 for independent labelings and 1 for
+// identical partitions whatever the label values. It is 1 when both
+// labelings are a single cluster and 0 when only one is.
+func NormalizedMutualInformation(labels, truth []int, normalization Normalization) (float64, error) {
+	// Validate normalization
+	if !normalization.valid() {
+		return 0, fmt.Errorf("invalid normalization: %d", normalization)
+	}
+
+	counts, rows, cols, err := contingency(labels, truth)
+	if err != nil {
+		return 0, err
+	}
+	n := float64(len(labels))
+	hRows, hCols := entropy(rows, n), entropy(cols, n)
+	if hRows == 0 && hCols == 0 {
+		return 1, nil
+	}
+
+	var normalizer float64
+	switch normalization {
+	case NormalizationArithmetic:
+		normalizer = (hRows + hCols) / 2
+	case NormalizationGeometric:
+		normalizer = math.Sqrt(hRows * hCols)
+	case NormalizationMin:
+		normalizer = min(hRows, hCols)
+	case NormalizationMax:
+		normalizer = max(hRows, hCols)
+	}
+	if normalizer == 0 {
+		return 0, nil
+	}
+	return min(1, mutualInformation(counts, rows, cols, n)/normalizer), nil
+}
+
+// mutualInformation returns the mutual information of a contingency table
+// of n observations with the given row and column totals.
+func mutualInformation(counts [][]float64, rows, cols []float64, n float64) float64 {
+	mi := 0.0
+	for i, row := range counts {
+		for j, c := range row {
+			if c > 0 {
+				mi += c / n * math.Log(c*n/(rows[i]*cols[j]))
+			}
+		}
+	}
+	return max(0, mi)
+}
+
+// entropy returns the entropy, in nats, of a labeling of n observations with
+// the given label totals.
+func entropy(totals []float64, n float64) float64 {
+	h := 0.0
+	for _, t := range totals {
+		if t > 0 {
+			h -= t / n * math.Log(t/n)
+		}
+	}
+	return h
+}
@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMutualInformation(t *testing.T) {
+	// Identical partitions share all their information
+	mi, err := MutualInformation([]int{0, 0, 1, 1}, []int{7, 7, 3, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := math.Log(2); math.Abs(mi-want) > 1e-12 {
+		t.Errorf("expected %f, got %f", want, mi)
+	}
+
+	// Independent partitions share none
+	mi, err = MutualInformation([]int{0, 0, 1, 1}, []int{0, 1, 0, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(mi) > 1e-12 {
+		t.Errorf("expected 0, got %f", mi)
+	}
+}
+
+func TestNormalizedMutualInformation(t *testing.T) {
+	// A refinement: 4 clusters of 2 against 2 classes of 4, mutual
+	// information log 2 and entropies log 4 and log 2
+	labels := []int{0, 0, 1, 1, 2, 2, 3, 3}
+	truth := []int{0, 0, 0, 0, 1, 1, 1, 1}
+	want := map[Normalization]float64{
+		NormalizationArithmetic: 2.0 / 3,
+		NormalizationGeometric:  1 / math.Sqrt(2),
+		NormalizationMin:        1,
+		NormalizationMax:        0.5,
+	}
+	for normalization, w := range want {
+		nmi, err := NormalizedMutualInformation(labels, truth, normalization)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(nmi-w) > 1e-12 {
+			t.Errorf("expected %f with normalization %d, got %f", w, normalization, nmi)
+		}
+	}
+
+	// Single clusters
+	nmi, err := NormalizedMutualInformation([]int{1, 1, 1}, []int{2, 2, 2}, NormalizationArithmetic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nmi != 1 {
+		t.Errorf("expected 1 for single clusters, got %f", nmi)
+	}
+	nmi, err = NormalizedMutualInformation([]int{1, 1, 1}, []int{0, 1, 2}, NormalizationMin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nmi != 0 {
+		t.Errorf("expected 0 against a single cluster, got %f", nmi)
+	}
+}
+
+func TestNormalizedMutualInformationChance(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	labels, truth := make([]int, 5000), make([]int, 5000)
+	for i := range labels {
+		labels[i], truth[i] = rng.Intn(4), rng.Intn(3)
+	}
+	nmi, err := NormalizedMutualInformation(labels, truth, NormalizationGeometric)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nmi > 0.01 {
+		t.Errorf("expected close to 0 for independent labelings, got %f", nmi)
+	}
+}
+
+func TestNormalizedMutualInformationValidation(t *testing.T) {
+	if _, err := NormalizedMutualInformation([]int{0}, []int{0}, numNormalizations); err == nil {
+		t.Error("expected error for invalid normalization")
+	}
+	if _, err := NormalizedMutualInformation(nil, nil, NormalizationMax); err == nil {
+		t.Error("expected error for empty labels")
+	}
+	if _, err := MutualInformation([]int{0, 1}, []int{0}); err == nil {
+		t.Error("expected error for labels of different lengths")
+	}
+}
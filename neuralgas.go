@@ -0,0 +1,99 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// NeuralGas implements the neural gas vector quantizer of Martinetz and
+// Schulten. The k centroids start from random observations, then every
+// observation, in random order during each of iterationThreshold passes,
+// pulls every centroid towards it by a step decreasing exponentially with the
+// rank of the centroid by distance to the observation: the nearest moves the
+// most. Since every centroid moves, none is stranded far from the data and
+// the result depends much less on the initial centroids than Lloyd's
+// algorithm. The rank decay falls from k/2 to 0.01 and the learning rate from
+// 0.5 to 0.005 over training, ending with plain nearest-centroid updates.
+//
+// The result's Iterations is the number of passes and Converged is always
+// true. It requires the Euclidean distance and mean centroids.
+func NeuralGas[T Observation](dataset []T, k, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate distance, centroids move towards observations
+	if !cfg.euclidean() {
+		return nil, fmt.Errorf("neural gas requires the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	centroids := initRandom(points, k, rng)
+	ranks := make([]int, k)
+	dists := make([]float64, k)
+	steps := float64(iterationThreshold * len(points))
+	step := 0.0
+	for range iterationThreshold {
+		for _, i := range rng.Perm(len(points)) {
+			spread := anneal(float64(k)/2, 0.01, step/steps)
+			rate := anneal(0.5, 0.005, step/steps)
+			step++
+
+			// Rank the centroids by distance to the observation
+			for j, centroid := range centroids {
+				ranks[j] = j
+				dists[j] = squaredDistance(points[i], centroid)
+			}
+			slices.SortFunc(ranks, func(a, b int) int {
+				return cmp.Compare(dists[a], dists[b])
+			})
+			for rank, j := range ranks {
+				h := rate * math.Exp(-float64(rank)/spread)
+				if h < 1e-9 {
+					break
+				}
+				for d := range centroids[j] {
+					centroids[j][d] += h * (points[i][d] - centroids[j][d])
+				}
+			}
+		}
+	}
+
+	labels := make([]int, len(points))
+	newAssigner(points, cfg).assign(centroids, labels)
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = iterationThreshold
+	result.Converged = true
+	return result, nil
+}
+
+// anneal returns the value of a parameter decaying exponentially from start
+// to end as the fraction of training done goes from 0 to 1.
+func anneal(start, end, fraction float64) float64 {
+	return start * math.Pow(end/start, fraction)
+}
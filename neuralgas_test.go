@@ -0,0 +1,53 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNeuralGas(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}, {100, 100}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 3)
+
+	// Neural gas recovers the blobs from every seed, where Lloyd's algorithm
+	// from random observations sometimes merges two of them
+	for seed := range int64(10) {
+		result, err := NeuralGas(dataset, len(centers), 50, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, label := range result.Labels {
+			if label != result.Labels[i/50*50] {
+				t.Fatalf("seed %d: observation %v not with its blob", seed, dataset[i])
+			}
+		}
+		if len(result.Clusters) != len(centers) || result.Iterations != 50 {
+			t.Fatalf("seed %d: unexpected result", seed)
+		}
+		for j, cluster := range result.Clusters {
+			if len(cluster) != 50 {
+				t.Fatalf("seed %d: expected 50 observations in cluster %d, got %d", seed, j, len(cluster))
+			}
+		}
+	}
+}
+
+func TestNeuralGasValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := NeuralGas([]Numbers{}, 2, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := NeuralGas(dataset, 7, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := NeuralGas(dataset, 2, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := NeuralGas(dataset, 2, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := NeuralGas(dataset, 2, 10, rng, WithMedians()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+}
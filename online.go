@@ -0,0 +1,50 @@
+package kmeans
+
+// macQueen runs MacQueen's online k-means from centroids: observations are
+// visited in input order and each one immediately moves its nearest centroid
+// towards it, with a per-centroid learning rate of one over the number of
+// observations the centroid has absorbed. Each iteration is one pass over the
+// points. Once done, every point is assigned to its nearest centroid in
+// labels. It returns the final centroids, the number of passes and whether
+// the run converged.
+func macQueen(points, centroids [][]float64, labels []int, deltaThreshold float64, iterationThreshold int, cfg *config) ([][]float64, int, bool) {
+	distance := cfg.distanceFunc()
+	counts := make([]float64, len(centroids))
+	iterations := 0
+	converged := false
+	for range iterationThreshold {
+		iterations++
+		previous := cloneAll(centroids)
+		for i, point := range points {
+			j, _ := nearest(point, centroids, distance)
+			nudge(centroids[j], &counts[j], point, cfg.weight(i))
+			if cfg.spherical {
+				normalize(centroids[j])
+			}
+		}
+
+		// Stop if maximum movement over the pass is below the threshold
+		if maxDrift(previous, centroids) < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	// Final labelling of every point
+	newAssigner(points, cfg).assign(centroids, labels)
+	return centroids, iterations, converged
+}
+
+// nudge moves centroid towards point of weight w with a learning rate of w
+// over the total weight of the points it absorbed, count, which is increased
+// by w. The centroid stays the weighted running mean of the points it absorbed.
+func nudge(centroid []float64, count *float64, point []float64, w float64) {
+	*count += w
+	if *count == 0 {
+		return
+	}
+	rate := w / *count
+	for d := range centroid {
+		centroid[d] += rate * (point[d] - centroid[d])
+	}
+}
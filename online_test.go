@@ -0,0 +1,32 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNudge(t *testing.T) {
+	centroid, count := []float64{0, 0}, 0.0
+	for _, point := range [][]float64{{1, 2}, {3, 4}, {5, 0}} {
+		nudge(centroid, &count, point, 1)
+	}
+
+	// The centroid is the running mean of the absorbed points
+	if count != 3 || math.Abs(centroid[0]-3) > 1e-12 || math.Abs(centroid[1]-2) > 1e-12 {
+		t.Errorf("expected (3, 2) after 3 points, got %v after %v", centroid, count)
+	}
+}
+
+func TestClusterMacQueen(t *testing.T) {
+	dataset := []Numbers{1, 11, 2, 12, 3, 13, 21, 22, 23}
+
+	// A single deterministic pass is enough on well separated data
+	result, err := ClusterResult(dataset, 3, 0.01, 1, nil, WithAlgorithm(MacQueen), WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 3}, {11, 12, 13}, {21, 22, 23}})
+	if result.Iterations != 1 {
+		t.Errorf("expected a single pass, got %d", result.Iterations)
+	}
+}
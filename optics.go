@@ -0,0 +1,330 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// OPTICSResult holds the reachability ordering computed by OPTICS, from which
+// clusters of different densities can be extracted.
+type OPTICSResult[T Observation] struct {
+	// Ordering holds the indices of the observations in the order they were
+	// processed. Plotting their reachability distances in this order shows
+	// clusters as valleys.
+	Ordering []int
+	// Reachability holds, for each observation in input order, its
+	// reachability distance: the smallest distance at which it is density
+	// reachable from an observation processed before it, or +Inf.
+	Reachability []float64
+	// CoreDistances holds, for each observation in input order, the distance
+	// to its minPts-th nearest observation, itself included, or +Inf when it
+	// has fewer than minPts observations within maxEps.
+	CoreDistances []float64
+
+	dataset      []T
+	maxEps       float64
+	minPts       int
+	predecessors []int
+}
+
+// OPTICS implements Ordering Points To Identify the Clustering Structure. Like
+// DBSCAN, it considers dense regions where observations have at least minPts
+// observations nearby, but instead of a single distance eps it records, for
+// every observation, the smallest distance up to maxEps at which it joins a
+// dense region. Clusters at any density level are then extracted from the
+// result with ExtractDBSCAN or ExtractXi, which solves DBSCAN's difficulty
+// with clusters of very different densities. maxEps may be +Inf.
+//
+// Observations are compared with the configured distance, and time grows
+// with the square of the number of observations.
+func OPTICS[T Observation](dataset []T, maxEps float64, minPts int, opts ...Option) (*OPTICSResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate maxEps and minPts
+	if !(maxEps > 0) {
+		return nil, fmt.Errorf("invalid maximum eps: %f", maxEps)
+	}
+	if minPts <= 0 {
+		return nil, fmt.Errorf("invalid minimum number of points: %d", minPts)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	n := len(points)
+	distance := cfg.distanceFunc()
+
+	result := &OPTICSResult[T]{
+		Ordering:      make([]int, 0, n),
+		Reachability:  make([]float64, n),
+		CoreDistances: make([]float64, n),
+		dataset:       dataset,
+		maxEps:        maxEps,
+		minPts:        minPts,
+		predecessors:  make([]int, n),
+	}
+	for i := range n {
+		result.Reachability[i] = math.Inf(1)
+		result.predecessors[i] = -1
+	}
+
+	processed := make([]bool, n)
+	seeded := make([]bool, n)
+	dists := make([]float64, n)
+	sorted := make([]float64, 0, n)
+	var seeds []int
+	process := func(p int) {
+		processed[p] = true
+		result.Ordering = append(result.Ordering, p)
+
+		// Core distance of p
+		sorted = sorted[:0]
+		for q := range points {
+			dists[q] = distance(points[p], points[q])
+			if dists[q] <= maxEps {
+				sorted = append(sorted, dists[q])
+			}
+		}
+		result.CoreDistances[p] = math.Inf(1)
+		if len(sorted) < minPts {
+			return
+		}
+		slices.Sort(sorted)
+		core := sorted[minPts-1]
+		result.CoreDistances[p] = core
+
+		// Lower the reachability of the unprocessed neighbours of p
+		for q := range points {
+			if processed[q] || dists[q] > maxEps {
+				continue
+			}
+			if reachability := max(core, dists[q]); reachability < result.Reachability[q] {
+				result.Reachability[q] = reachability
+				result.predecessors[q] = p
+			}
+			if !seeded[q] {
+				seeded[q] = true
+				seeds = append(seeds, q)
+			}
+		}
+	}
+
+	for p := range points {
+		if processed[p] {
+			continue
+		}
+		process(p)
+
+		// Expand the ordering from the closest seed until none is left
+		for len(seeds) > 0 {
+			next := slices.MinFunc(seeds, func(a, b int) int {
+				return cmp.Compare(result.Reachability[a], result.Reachability[b])
+			})
+			seeds = slices.DeleteFunc(seeds, func(q int) bool { return q == next })
+			process(next)
+		}
+	}
+	return result, nil
+}
+
+// ExtractDBSCAN returns the clusters DBSCAN finds with distance eps, no
+// greater than the maxEps of the OPTICS run, up to the assignment of border
+// observations reachable from several clusters.
+func (r *OPTICSResult[T]) ExtractDBSCAN(eps float64) (*DensityResult[T], error) {
+	// Validate eps, reachabilities beyond maxEps were not computed
+	if !(eps > 0) || eps > r.maxEps {
+		return nil, fmt.Errorf("invalid eps: %f", eps)
+	}
+
+	labels := make([]int, len(r.Ordering))
+	k := 0
+	for _, i := range r.Ordering {
+		switch {
+		case r.Reachability[i] <= eps:
+			labels[i] = k - 1
+		case r.CoreDistances[i] <= eps:
+			labels[i] = k
+			k++
+		default:
+			labels[i] = Noise
+		}
+	}
+	return newDensityResult(r.dataset, labels, k), nil
+}
+
+// ExtractXi returns the clusters found by the ξ method: a cluster starts with
+// a steep downward area of the reachability plot, where reachability drops by
+// a factor of at least 1-xi between consecutive observations, and ends with a
+// matching steep upward area. Nested clusters of different densities are
+// found together; the innermost clusters of at least minClusterSize
+// observations are returned and the other observations are noise. xi must lie
+// in (0, 1).
+func (r *OPTICSResult[T]) ExtractXi(xi float64, minClusterSize int) (*DensityResult[T], error) {
+	// Validate xi and minClusterSize
+	if !(xi > 0 && xi < 1) {
+		return nil, fmt.Errorf("invalid xi: %f", xi)
+	}
+	if minClusterSize <= 0 {
+		return nil, fmt.Errorf("invalid minimum cluster size: %d", minClusterSize)
+	}
+
+	// Reachability plot, closed by an infinite reachability
+	n := len(r.Ordering)
+	plot := make([]float64, n+1)
+	for i, o := range r.Ordering {
+		plot[i] = r.Reachability[o]
+	}
+	plot[n] = math.Inf(1)
+
+	// Direction of the plot between consecutive observations
+	complement := 1 - xi
+	steepUp, steepDown := make([]bool, n), make([]bool, n)
+	up, down := make([]bool, n), make([]bool, n)
+	for i := range n {
+		ratio := plot[i] / plot[i+1]
+		steepUp[i] = ratio <= complement
+		steepDown[i] = ratio >= 1/complement
+		up[i] = ratio < 1
+		down[i] = ratio > 1
+	}
+
+	type area struct {
+		start, end int
+		mib        float64
+	}
+	var downAreas []area
+	var clusters [][2]int
+	index, mib := 0, 0.0
+	for i := range n {
+		if i < index || !(steepUp[i] || steepDown[i]) {
+			continue
+		}
+
+		// Drop the steep downward areas too low for the maximum in between
+		for _, v := range plot[index : i+1] {
+			mib = max(mib, v)
+		}
+		if math.IsInf(mib, 1) {
+			downAreas = downAreas[:0]
+		}
+		kept := downAreas[:0]
+		for _, d := range downAreas {
+			if mib <= plot[d.start]*complement {
+				d.mib = max(d.mib, mib)
+				kept = append(kept, d)
+			}
+		}
+		downAreas = kept
+
+		if steepDown[i] {
+			end := extendSteepArea(steepDown, up, i, r.minPts)
+			downAreas = append(downAreas, area{start: i, end: end})
+			index = end + 1
+			mib = plot[index]
+			continue
+		}
+
+		// A steep upward area closes a cluster for each matching downward area
+		upStart, upEnd := i, extendSteepArea(steepUp, down, i, r.minPts)
+		index = upEnd + 1
+		mib = plot[index]
+		var found [][2]int
+		for _, d := range downAreas {
+			start, end := d.start, upEnd
+			if plot[end+1]*complement < d.mib {
+				continue
+			}
+			top := plot[d.start]
+			if top*complement >= plot[end+1] {
+				for plot[start+1] > plot[end+1] && start < d.end {
+					start++
+				}
+			} else if plot[end+1]*complement >= top {
+				for plot[end-1] > top && end > upStart {
+					end--
+				}
+			}
+			end = r.correctPredecessor(plot, start, end)
+			if end-start+1 < minClusterSize || start > d.end || end < upStart {
+				continue
+			}
+			found = append(found, [2]int{start, end})
+		}
+
+		// Smaller clusters come first
+		slices.Reverse(found)
+		clusters = append(clusters, found...)
+	}
+
+	// Label the innermost clusters, which come before those enclosing them
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = Noise
+	}
+	k := 0
+	for _, c := range clusters {
+		span := positions[c[0] : c[1]+1]
+		if slices.ContainsFunc(span, func(label int) bool { return label != Noise }) {
+			continue
+		}
+		for i := range span {
+			span[i] = k
+		}
+		k++
+	}
+	labels := make([]int, n)
+	for i, o := range r.Ordering {
+		labels[o] = positions[i]
+	}
+	return newDensityResult(r.dataset, labels, k), nil
+}
+
+// correctPredecessor shrinks the cluster spanning positions start to end of
+// the reachability plot until its last observation was reached from an
+// observation of the cluster, so observations merely reached from a cluster on
+// the way out of it are left out. It returns the new end, before start when
+// no observation qualifies.
+func (r *OPTICSResult[T]) correctPredecessor(plot []float64, start, end int) int {
+	for ; start < end; end-- {
+		if plot[start] > plot[end] {
+			return end
+		}
+		predecessor := r.predecessors[r.Ordering[end]]
+		if slices.Contains(r.Ordering[start:end], predecessor) {
+			return end
+		}
+	}
+	return start - 1
+}
+
+// extendSteepArea returns the end of the steep area starting at start: it
+// extends over steep points and over at most minPts consecutive points going
+// the same way without being steep, and stops at the first point going the
+// opposite way, marked in opposite.
+func extendSteepArea(steep, opposite []bool, start, minPts int) int {
+	end := start
+	relaxed := 0
+	for i := start; i < len(steep); i++ {
+		switch {
+		case steep[i]:
+			relaxed = 0
+			end = i
+		case !opposite[i]:
+			relaxed++
+			if relaxed > minPts {
+				return end
+			}
+		default:
+			return end
+		}
+	}
+	return end
+}
@@ -0,0 +1,97 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOPTICS(t *testing.T) {
+	// Two tight clusters close to each other and a loose one: any eps large
+	// enough for the loose cluster merges the tight ones
+	rng := rand.New(rand.NewSource(0))
+	dataset := append(gaussians(rng, []Vector{{0, 0}, {1, 1}}, 50, 0.1), gaussians(rng, []Vector{{30, 30}}, 50, 2)...)
+
+	result, err := OPTICS(dataset, math.Inf(1), 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	order := slices.Clone(result.Ordering)
+	slices.Sort(order)
+	for i, o := range order {
+		if i != o {
+			t.Fatalf("expected every observation once in the ordering, got %v", result.Ordering)
+		}
+	}
+
+	clusters, err := result.ExtractXi(0.3, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters.Clusters) != 3 {
+		t.Fatalf("expected 3 clusters, got %d: %v", len(clusters.Clusters), clusters.Labels)
+	}
+	for i, label := range clusters.Labels {
+		if label != clusters.Labels[i/50*50] && label != Noise {
+			t.Fatalf("observation %v in the wrong cluster: %v", dataset[i], clusters.Labels)
+		}
+	}
+	if clusters.Labels[0] == clusters.Labels[50] || clusters.Labels[50] == clusters.Labels[100] {
+		t.Errorf("expected the three clusters apart, got %v", clusters.Labels)
+	}
+
+	// A single eps merges the tight clusters
+	merged, err := result.ExtractDBSCAN(1.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(merged.Clusters) != 2 {
+		t.Errorf("expected 2 clusters, got %d", len(merged.Clusters))
+	}
+}
+
+func TestOPTICSExtractDBSCAN(t *testing.T) {
+	dataset := append(rings(50), Vector{20, 20}, Vector{-20, 0})
+	result, err := OPTICS(dataset, 3, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clusters, err := result.ExtractDBSCAN(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, err := DBSCAN(dataset, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters.Clusters) != len(expected.Clusters) || len(clusters.Noise) != len(expected.Noise) {
+		t.Errorf("expected the DBSCAN clusters, got %v", clusters.Labels)
+	}
+}
+
+func TestOPTICSValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := OPTICS(dataset, 0, 2); err == nil {
+		t.Error("expected error for invalid maximum eps")
+	}
+	if _, err := OPTICS(dataset, 1, 0); err == nil {
+		t.Error("expected error for invalid minPts")
+	}
+	result, err := OPTICS(dataset, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := result.ExtractXi(1, 2); err == nil {
+		t.Error("expected error for invalid xi")
+	}
+	if _, err := result.ExtractXi(0.1, 0); err == nil {
+		t.Error("expected error for invalid minimum cluster size")
+	}
+	if _, err := result.ExtractDBSCAN(0); err == nil {
+		t.Error("expected error for invalid eps")
+	}
+	if _, err := result.ExtractDBSCAN(2); err == nil {
+		t.Error("expected error for eps above the maximum eps")
+	}
+}
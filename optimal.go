@@ -0,0 +1,134 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// maxOptimalObservations bounds the size of the datasets Optimal accepts: the
+// search is exponential in the number of observations.
+const maxOptimalObservations = 25
+
+// Optimal returns the optimal k-means clustering of a small dataset, the
+// partition of least inertia, by branch and bound. Observations are assigned
+// one at a time, farthest first, to one of the clusters used so far or to a
+// new one, and a branch is abandoned once its partial inertia, which only
+// grows as observations are added, reaches the inertia of the best complete
+// partition found, starting from a Lloyd solution. It accepts at most 25
+// observations and suits tests and benchmarks comparing heuristics with the
+// true optimum.
+//
+// The result's Iterations is zero and Converged is always true. It honours
+// the weights of weighted observations.
+func Optimal[T Observation](dataset []T, k int) (*Result[T], error) {
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate dataset size
+	if len(dataset) > maxOptimalObservations {
+		return nil, fmt.Errorf("too many observations for an exact search: %d", len(dataset))
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := observationWeights(dataset)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{weights: weights}
+
+	// Start from a Lloyd solution, as an upper bound on the optimal inertia
+	labels := make([]int, len(points))
+	centroids, _, _ := lloydLoop(points, initMaximin(points, k), labels, 1e-9, 100, cfg)
+	bestCost := 0.0
+	sizes := make([]int, k)
+	for i, j := range labels {
+		bestCost += cfg.weight(i) * squaredDistance(points[i], centroids[j])
+		sizes[j]++
+	}
+	if slices.Contains(sizes, 0) {
+		bestCost = math.Inf(1)
+	}
+
+	// Visit observations farthest first, so that distant observations split
+	// early and branches are pruned sooner
+	order := farthestFirst(points, len(points), squaredDistance)
+
+	// Running weighted means of the clusters of the current branch
+	means := newMatrix(k, len(points[0]))
+	totals := make([]float64, k)
+	current := make([]int, len(points))
+	best := labels
+	var search func(r, used int, cost float64)
+	search = func(r, used int, cost float64) {
+		if cost >= bestCost {
+			return
+		}
+		if r == len(order) {
+			bestCost = cost
+			best = slices.Clone(current)
+			return
+		}
+		p, w := points[order[r]], cfg.weight(order[r])
+
+		// Join a cluster in use or open the next one, unless the remaining
+		// observations are just enough to open the clusters left
+		from := 0
+		if len(order)-r == k-used {
+			from = used
+		}
+		previous := make([]float64, len(p))
+		for j := from; j <= min(used, k-1); j++ {
+			added := 0.0
+			copy(previous, means[j])
+			if totals[j] > 0 {
+				added = totals[j] * w / (totals[j] + w) * squaredDistance(p, means[j])
+				for d, x := range p {
+					means[j][d] += w / (totals[j] + w) * (x - means[j][d])
+				}
+			} else {
+				copy(means[j], p)
+			}
+			totals[j] += w
+			current[order[r]] = j
+			search(r+1, max(used, j+1), cost+added)
+			totals[j] -= w
+			copy(means[j], previous)
+		}
+	}
+	search(0, 0, 0)
+
+	// Weighted means of the optimal clusters, plain means of weightless ones
+	sums := newMatrix(k, len(points[0]))
+	totals = make([]float64, k)
+	for i, j := range best {
+		totals[j] += cfg.weight(i)
+		for d, x := range points[i] {
+			sums[j][d] += cfg.weight(i) * x
+		}
+	}
+	groups := groupPoints(points, best, k)
+	for j := range k {
+		if totals[j] == 0 {
+			centroids[j] = mean(groups[j])
+			continue
+		}
+		centroids[j] = sums[j]
+		for d := range sums[j] {
+			centroids[j][d] /= totals[j]
+		}
+	}
+	result := newResult(dataset, points, centroids, best, weights, squaredDistance)
+	result.Converged = true
+	return result, nil
+}
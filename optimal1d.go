@@ -0,0 +1,130 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Optimal1D returns the optimal k-means clustering of one-dimensional
+// observations, the partition of least inertia, by dynamic programming as in
+// Ckmeans.1d.dp. Optimal clusters are intervals of the sorted values, and the
+// best split of the first j values into m intervals is found from the best
+// splits into m-1 intervals. Since the start of the last interval does not
+// decrease with j, each of the k rounds is solved by divide and conquer in
+// O(n log n) time, for O(k n log n) time overall after sorting.
+//
+// Clusters are ordered by increasing value. The result's Iterations is zero
+// and Converged is always true. It honours the weights of weighted
+// observations.
+func Optimal1D[T Observation](dataset []T, k int) (*Result[T], error) {
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if len(points[0]) != 1 {
+		return nil, fmt.Errorf("optimal clustering requires one-dimensional observations")
+	}
+	weights, err := observationWeights(dataset)
+	if err != nil {
+		return nil, err
+	}
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+
+	// Prefix sums of the weights, values and squares in sorted order
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(points[a][0], points[b][0])
+	})
+	n := len(order)
+	sumW := make([]float64, n+1)
+	sumX := make([]float64, n+1)
+	sumXX := make([]float64, n+1)
+	for r, i := range order {
+		w, x := weight(i), points[i][0]
+		sumW[r+1] = sumW[r] + w
+		sumX[r+1] = sumX[r] + w*x
+		sumXX[r+1] = sumXX[r] + w*x*x
+	}
+
+	// cost returns the inertia of the sorted values from rank i to j excluded
+	cost := func(i, j int) float64 {
+		w := sumW[j] - sumW[i]
+		if w == 0 {
+			return 0
+		}
+		s := sumX[j] - sumX[i]
+		return max(sumXX[j]-sumXX[i]-s*s/w, 0)
+	}
+
+	// costs[j] is the least inertia of the first j values in m intervals and
+	// starts[m][j] the start of the last of them
+	costs := make([]float64, n+1)
+	for j := 1; j <= n; j++ {
+		costs[j] = cost(0, j)
+	}
+	starts := make([][]int, k+1)
+	starts[1] = make([]int, n+1)
+	for m := 2; m <= k; m++ {
+		previous := costs
+		costs = make([]float64, n+1)
+		starts[m] = make([]int, n+1)
+		var solve func(lo, hi, from, to int)
+		solve = func(lo, hi, from, to int) {
+			if lo > hi {
+				return
+			}
+			j := (lo + hi) / 2
+			best, bestCost := -1, math.Inf(1)
+			for i := max(from, m-1); i <= min(to, j-1); i++ {
+				if c := previous[i] + cost(i, j); c < bestCost {
+					best, bestCost = i, c
+				}
+			}
+			costs[j], starts[m][j] = bestCost, best
+			solve(lo, j-1, from, best)
+			solve(j+1, hi, best, to)
+		}
+		solve(m, n, m-1, n-1)
+	}
+
+	// Walk the intervals back from the last one
+	labels := make([]int, n)
+	centroids := make([][]float64, k)
+	end := n
+	for m := k; m >= 1; m-- {
+		start := starts[m][end]
+		for _, i := range order[start:end] {
+			labels[i] = m - 1
+		}
+		centroid := points[order[start]][0]
+		if w := sumW[end] - sumW[start]; w > 0 {
+			centroid = (sumX[end] - sumX[start]) / w
+		}
+		centroids[m-1] = []float64{centroid}
+		end = start
+	}
+
+	result := newResult(dataset, points, centroids, labels, weights, squaredDistance)
+	result.Converged = true
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOptimal1D(t *testing.T) {
+	dataset := []Numbers{13, 1, 22, 2, 11, 3, 23, 12, 21}
+	result, err := Optimal1D(dataset, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 3}, {13, 11, 12}, {22, 23, 21}})
+	if result.Inertia != 6 || !result.Converged {
+		t.Errorf("expected inertia 6, got %v", result.Inertia)
+	}
+}
+
+func TestOptimal1DExhaustive(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for range 20 {
+		dataset := make([]Vector, 10)
+		for i := range dataset {
+			dataset[i] = Vector{math.Round(rng.ExpFloat64() * 10)}
+		}
+		result, err := Optimal1D(dataset, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Optimal clusters are intervals: try every split of the sorted values
+		sorted := make([]float64, len(dataset))
+		for i, obs := range dataset {
+			sorted[i] = obs[0]
+		}
+		slices.Sort(sorted)
+		best := math.Inf(1)
+		for a := 1; a < len(sorted); a++ {
+			for b := a + 1; b < len(sorted); b++ {
+				best = min(best, intervalInertia(sorted[:a])+intervalInertia(sorted[a:b])+intervalInertia(sorted[b:]))
+			}
+		}
+		if math.Abs(result.Inertia-best) > 1e-9 {
+			t.Fatalf("expected inertia %f for %v, got %f", best, dataset, result.Inertia)
+		}
+	}
+}
+
+func TestOptimal1DWeighted(t *testing.T) {
+	dataset := []Weighted[Numbers]{{0, 1}, {1, 1}, {10, 3}, {11, 1}, {12, 0}}
+	result, err := Optimal1D(dataset, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Centroids[0][0] != 0.5 || result.Centroids[1][0] != 10.25 {
+		t.Errorf("expected centroids 0.5 and 10.25, got %v", result.Centroids)
+	}
+}
+
+func TestOptimal1DValidation(t *testing.T) {
+	if _, err := Optimal1D([]Numbers{}, 2); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Optimal1D([]Numbers{1, 2}, 3); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Optimal1D([]Vector{{1, 2}, {3, 4}}, 2); err == nil {
+		t.Error("expected error for multidimensional observations")
+	}
+}
+
+// intervalInertia returns the sum of squared deviations of values from their
+// mean.
+func intervalInertia(values []float64) float64 {
+	m := 0.0
+	for _, v := range values {
+		m += v / float64(len(values))
+	}
+	inertia := 0.0
+	for _, v := range values {
+		inertia += (v - m) * (v - m)
+	}
+	return inertia
+}
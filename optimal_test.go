@@ -0,0 +1,96 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestOptimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for range 10 {
+		dataset := make([]Vector, 8)
+		for i := range dataset {
+			dataset[i] = Vector{rng.Float64() * 10, rng.Float64() * 10}
+		}
+		result, err := Optimal(dataset, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Enumerate every labelling using all clusters
+		best := math.Inf(1)
+		labels := make([]int, len(dataset))
+		for code := range 6561 {
+			groups := make([][][]float64, 3)
+			for i := range labels {
+				labels[i] = code % 3
+				code /= 3
+				groups[labels[i]] = append(groups[labels[i]], dataset[i])
+			}
+			inertia := 0.0
+			for _, group := range groups {
+				if len(group) == 0 {
+					inertia = math.Inf(1)
+					break
+				}
+				m := mean(group)
+				for _, p := range group {
+					inertia += squaredDistance(p, m)
+				}
+			}
+			best = min(best, inertia)
+		}
+		if math.Abs(result.Inertia-best) > 1e-9 {
+			t.Fatalf("expected inertia %f, got %f", best, result.Inertia)
+		}
+		for j, cluster := range result.Clusters {
+			if len(cluster) == 0 {
+				t.Fatalf("cluster %d is empty", j)
+			}
+		}
+	}
+}
+
+func TestOptimalBeatsLloyd(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(1)), 25, 4, 2)
+	result, err := Optimal(dataset, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for seed := range int64(20) {
+		lloyd, err := ClusterResult(dataset, 4, 1e-9, 100, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if lloyd.Inertia < result.Inertia-1e-9 {
+			t.Fatalf("seed %d: Lloyd's inertia %f below optimal inertia %f", seed, lloyd.Inertia, result.Inertia)
+		}
+	}
+}
+
+func TestOptimalWeighted(t *testing.T) {
+	dataset := []Weighted[Numbers]{{0, 1}, {1, 1}, {10, 3}, {11, 1}, {12, 0}}
+	result, err := Optimal(dataset, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(result.Inertia-1.25) > 1e-9 {
+		t.Errorf("expected inertia 1.25, got %v", result.Inertia)
+	}
+}
+
+func TestOptimalValidation(t *testing.T) {
+	if _, err := Optimal([]Numbers{}, 2); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Optimal(make([]Numbers, 26), 2); err == nil {
+		t.Error("expected error for too many observations")
+	}
+	if _, err := Optimal([]Numbers{1, 2}, 3); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Optimal([]Vector{{1, 2}, {3}}, 2); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
@@ -0,0 +1,109 @@
+package kmeans
+
+import "fmt"
+
+// InertiaLowerBound returns a lower bound on the inertia of any clustering
+// of the dataset into k clusters, and so of the optimal one. It is the larger
+// of two bounds along the principal axes of the observations, the
+// eigenvectors of their scatter matrix:
+//
+//   - the spectral bound of Ding and He, the scatter left out of the k-1
+//     leading axes, which is 0 once k exceeds the dimension;
+//   - the sum over axes of the optimal inertia of the projections of the
+//     observations on the axis, computed with Optimal1D, since the inertia of
+//     any clustering is the sum of the inertias of its projections on
+//     orthogonal axes.
+//
+// It honours the weights of weighted observations and WithWeights, and
+// requires the Euclidean distance and mean centroids, the inertia being a
+// sum of squared distances.
+func InertiaLowerBound[T Observation](dataset []T, k int, opts ...Option) (float64, error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return 0, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return 0, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate distance
+	if !cfg.euclidean() {
+		return 0, fmt.Errorf("lower bound requires the Euclidean distance and mean centroids")
+	}
+
+	weights, err := datasetWeights(dataset, cfg)
+	if err != nil {
+		return 0, err
+	}
+	cfg.weights = weights
+	points, err := materialize(dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	// Weighted scatter matrix around the weighted mean
+	dim := len(points[0])
+	center := cfg.centerOf(points)
+	scatter := newMatrix(dim, dim)
+	for i, p := range points {
+		w := cfg.weight(i)
+		for a := range dim {
+			for b := range a + 1 {
+				scatter[a][b] += w * (p[a] - center[a]) * (p[b] - center[b])
+			}
+		}
+	}
+	for a := range dim {
+		for b := range a {
+			scatter[b][a] = scatter[a][b]
+		}
+	}
+
+	// Scatter left out of the k-1 leading principal axes
+	eigenvalues, axes := symmetricEigen(scatter)
+	spectral := 0.0
+	for _, v := range eigenvalues[min(k-1, dim):] {
+		spectral += max(0, v)
+	}
+
+	// Optimal inertias of the projections on every principal axis
+	projected := 0.0
+	projections := make([]Weighted[rawPoint], len(points))
+	for _, axis := range axes {
+		for i, p := range points {
+			projections[i] = Weighted[rawPoint]{Observation: rawPoint{dot(p, axis)}, W: cfg.weight(i)}
+		}
+		result, err := Optimal1D(projections, k)
+		if err != nil {
+			return 0, err
+		}
+		projected += result.Inertia
+	}
+	return max(spectral, projected), nil
+}
+
+// OptimalityGap returns how far the inertia of result, a clustering of
+// dataset, may be above the optimal inertia for as many clusters, as a
+// fraction of its inertia: 1 minus InertiaLowerBound over the inertia. The
+// optimum lies within this fraction of the solution, so restarts can stop
+// once it is small enough. The bound is loose when clusters overlap, so the
+// gap overestimates the true one. It takes the options of InertiaLowerBound.
+func OptimalityGap[T Observation](dataset []T, result *Result[T], opts ...Option) (float64, error) {
+	// Validate result
+	if len(result.Labels) != len(dataset) {
+		return 0, fmt.Errorf("expected labels of %d observations, got %d", len(dataset), len(result.Labels))
+	}
+
+	bound, err := InertiaLowerBound(dataset, len(result.Centroids), opts...)
+	if err != nil {
+		return 0, err
+	}
+	if result.Inertia <= bound {
+		return 0, nil
+	}
+	return 1 - bound/result.Inertia, nil
+}
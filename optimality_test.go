@@ -0,0 +1,102 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestInertiaLowerBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	dataset := make([]Vector, 12)
+	for i := range dataset {
+		dataset[i] = Vector{rng.Float64() * 10, rng.Float64() * 10, rng.Float64() * 10}
+	}
+
+	// With a single cluster the bound is the optimal inertia
+	single, err := ClusterResult(dataset, 1, 0.001, 10, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bound, err := InertiaLowerBound(dataset, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(bound-single.Inertia) > 1e-9 {
+		t.Errorf("expected the bound %f to equal the inertia %f", bound, single.Inertia)
+	}
+
+	// The bound never exceeds the optimal inertia, even with more clusters
+	// than dimensions
+	for k := 2; k <= 5; k++ {
+		optimal, err := Optimal(dataset, k)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		bound, err := InertiaLowerBound(dataset, k)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bound <= 0 || bound > optimal.Inertia+1e-9 {
+			t.Errorf("expected a positive bound below %f with %d clusters, got %f", optimal.Inertia, k, bound)
+		}
+	}
+
+	// Weights count as repeated observations
+	weighted, err := InertiaLowerBound(dataset, 2, WithWeights(slices.Repeat([]float64{2}, len(dataset))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unweighted, _ := InertiaLowerBound(dataset, 2)
+	if math.Abs(weighted-2*unweighted) > 1e-9 {
+		t.Errorf("expected doubling the weights to double the bound %f, got %f", unweighted, weighted)
+	}
+}
+
+func TestOptimalityGap(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	good, err := ClusterResult(dataset, 3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gap, err := OptimalityGap(dataset, good)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gap < 0 || gap > 0.1 {
+		t.Errorf("expected a small gap for well separated clusters, got %f", gap)
+	}
+
+	// A solution of much larger inertia has a larger gap
+	poor := *good
+	poor.Inertia *= 20
+	worse, err := OptimalityGap(dataset, &poor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if worse <= gap {
+		t.Errorf("expected a larger gap than %f for a poor solution, got %f", gap, worse)
+	}
+}
+
+func TestOptimalityGapValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := InertiaLowerBound([]Numbers{}, 1); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := InertiaLowerBound(dataset, 7); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := InertiaLowerBound(dataset, 2, WithDistance(ManhattanDistance)); err == nil {
+		t.Error("expected error for a non-Euclidean distance")
+	}
+	if _, err := InertiaLowerBound(dataset, 2, WithWeights([]float64{1})); err == nil {
+		t.Error("expected error for mismatched weights")
+	}
+	if _, err := OptimalityGap(dataset, &Result[Numbers]{Labels: []int{0}}); err == nil {
+		t.Error("expected error for mismatched labels")
+	}
+}
@@ -0,0 +1,388 @@
+package kmeans
+
+import "runtime"
+
+// Option configures optional behaviour of Cluster.
+type Option func(*config)
+
+// config holds the settings applied by Option values.
+type config struct {
+	init          Init
+	localTrials   int
+	centroids     [][]float64
+	distance      DistanceFunc
+	spherical     bool
+	center        CenterFunc
+	divergence    bool
+	linearLoss    bool
+	workers       int
+	algorithm     Algorithm
+	float32       bool
+	batchSize     int
+	decay         float64
+	weights       []float64
+	sampleWeights []float64
+	fixedSeeds    bool
+	frozen        [][]float64
+	sampleSize    int
+	chunkSize     int
+	sampleCount   int
+	numLocal      int
+	maxNeighbor   int
+	fuzzifier     float64
+	covariance    Covariance
+	preference    float64
+	topology      Topology
+	radius        float64
+}
+
+// newConfig returns the default configuration with opts applied.
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		init: InitRandom,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// deterministic reports whether a run involves no randomness: the initial
+// centroids are chosen deterministically and the algorithm does not sample.
+func (c *config) deterministic() bool {
+	return (c.centroids != nil || c.init.deterministic()) && !c.algorithm.stochastic() && c.sampleSize == 0
+}
+
+// distanceFunc returns the configured distance, defaulting to CosineDistance
+// in spherical mode and EuclideanDistance otherwise.
+func (c *config) distanceFunc() DistanceFunc {
+	switch {
+	case c.distance != nil:
+		return c.distance
+	case c.spherical:
+		return CosineDistance
+	default:
+		return EuclideanDistance
+	}
+}
+
+// lossFunc returns the per-observation contribution to the inertia: the
+// divergence itself when clustering with WithDivergence, the distance itself
+// with WithMedians or WithModes, the squared distance otherwise.
+func (c *config) lossFunc() DistanceFunc {
+	distance := c.distanceFunc()
+	switch {
+	case c.divergence, c.linearLoss:
+		return distance
+	case c.distance == nil && !c.spherical:
+		return squaredDistance
+	}
+	return func(a, b []float64) float64 {
+		d := distance(a, b)
+		return d * d
+	}
+}
+
+// euclidean reports whether observations are assigned with the Euclidean
+// distance and centroids updated as plain means.
+func (c *config) euclidean() bool {
+	return c.distance == nil && c.center == nil && !c.spherical && !c.divergence
+}
+
+// weight returns the weight of point i, one in unweighted runs.
+func (c *config) weight(i int) float64 {
+	if c.weights == nil {
+		return 1
+	}
+	return c.weights[i]
+}
+
+// fuzzifierOrDefault returns the configured fuzzifier, defaulting to 2.
+func (c *config) fuzzifierOrDefault() float64 {
+	if c.fuzzifier == 0 {
+		return 2
+	}
+	return c.fuzzifier
+}
+
+// workerCount returns the configured number of workers, defaulting to GOMAXPROCS.
+func (c *config) workerCount() int {
+	if c.workers == 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return c.workers
+}
+
+// centerOf computes the centroid of a non-empty group of points using the
+// configured center, or their mean, weighted in weighted runs and normalized
+// in spherical mode.
+func (c *config) centerOf(points [][]float64) []float64 {
+	if c.center != nil {
+		return c.center(points)
+	}
+	centroid := mean(points)
+	if c.weights != nil {
+		centroid = weightedMeans(points, c.weights, make([]int, len(points)), [][]float64{centroid})[0]
+	}
+	if c.spherical {
+		normalize(centroid)
+	}
+	return centroid
+}
+
+// WithInit selects the strategy used to choose the initial centroids.
+func WithInit(init Init) Option {
+	return func(c *config) {
+		c.init = init
+	}
+}
+
+// WithAlgorithm selects the engine used for the assignment step.
+// The default is Lloyd.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(c *config) {
+		c.algorithm = algorithm
+	}
+}
+
+// WithBatchSize sets the number of observations sampled at each iteration of
+// the MiniBatch algorithm. Zero selects the default of 1024.
+func WithBatchSize(n int) Option {
+	return func(c *config) {
+		c.batchSize = n
+	}
+}
+
+// WithSampleSize clusters a random sample of n observations only, then
+// assigns every observation to the nearest resulting centroid in a single
+// parallel pass. On massive datasets this finds good centroids and labels
+// everything at a fraction of the cost of iterating over all observations.
+// Zero, the default, clusters all observations. For CLARA, it sets the size
+// of each sample, zero selecting the default of 40 + 2k. For Silhouette and
+// Dunn, it estimates the score on a sample of n observations.
+func WithSampleSize(n int) Option {
+	return func(c *config) {
+		c.sampleSize = n
+	}
+}
+
+// WithSampleCount sets the number of samples clustered by CLARA.
+// Zero selects the default of 5.
+func WithSampleCount(n int) Option {
+	return func(c *config) {
+		c.sampleCount = n
+	}
+}
+
+// WithChunkSize sets the number of observations ClusterReader loads in
+// memory at once. Zero selects the default of 65536.
+func WithChunkSize(n int) Option {
+	return func(c *config) {
+		c.chunkSize = n
+	}
+}
+
+// WithFuzzifier sets the fuzzifier m of soft clustering methods such as
+// FuzzyCMeans, greater than 1. Memberships are crisper as m approaches 1 and
+// fuzzier as it grows. Zero selects the default of 2.
+func WithFuzzifier(m float64) Option {
+	return func(c *config) {
+		c.fuzzifier = m
+	}
+}
+
+// WithCovariance sets the shape of the covariance matrices estimated by
+// GaussianMixture.
+func WithCovariance(covariance Covariance) Option {
+	return func(c *config) {
+		c.covariance = covariance
+	}
+}
+
+// WithNumLocal sets the number of local searches run by CLARANS.
+// Zero selects the default of 2.
+func WithNumLocal(n int) Option {
+	return func(c *config) {
+		c.numLocal = n
+	}
+}
+
+// WithMaxNeighbor sets the number of consecutive failed swaps after which a
+// CLARANS local search stops. Zero selects the default of 1.25% of k(n-k),
+// at least 250.
+func WithMaxNeighbor(n int) Option {
+	return func(c *config) {
+		c.maxNeighbor = n
+	}
+}
+
+// WithPreference sets the preference of AffinityPropagation: the similarity
+// of every observation to itself, the negated squared distance at which it
+// would rather be its own exemplar. Higher preferences give more clusters.
+// Zero selects the default of the median similarity.
+func WithPreference(preference float64) Option {
+	return func(c *config) {
+		c.preference = preference
+	}
+}
+
+// WithTopology sets the arrangement of the units of a self-organizing map
+// trained by TrainSOM.
+func WithTopology(topology Topology) Option {
+	return func(c *config) {
+		c.topology = topology
+	}
+}
+
+// WithRadius sets the initial neighbourhood radius of TrainSOM, in grid
+// units. Zero selects the default of half the largest grid dimension.
+func WithRadius(radius float64) Option {
+	return func(c *config) {
+		c.radius = radius
+	}
+}
+
+// WithDecay sets the factor, between 0 and 1, by which a StreamingClusterer
+// multiplies the weight of past observations at every call to Add. Lower
+// values follow a drifting stream faster. Zero selects the default of 1,
+// which never forgets.
+func WithDecay(decay float64) Option {
+	return func(c *config) {
+		c.decay = decay
+	}
+}
+
+// WithLocalTrials sets the number of candidates sampled at each step of
+// InitGreedyKMeansPlusPlus. Zero selects the default of 2 + ln(k).
+func WithLocalTrials(n int) Option {
+	return func(c *config) {
+		c.localTrials = n
+	}
+}
+
+// WithCentroids starts the run from the given centroids instead of choosing
+// them with the init strategy, e.g. to refine the centroids of a previous run.
+// There must be exactly k centroids with the dimension of the observations.
+func WithCentroids(centroids [][]float64) Option {
+	return func(c *config) {
+		c.centroids = centroids
+	}
+}
+
+// WithWeights gives observation i the weight weights[i], as an alternative to
+// implementing WeightedObservation, e.g. to cluster pre-aggregated data. There
+// must be one non-negative weight per observation. Algorithms honouring
+// weighted observations honour these weights.
+func WithWeights(weights []float64) Option {
+	return func(c *config) {
+		c.sampleWeights = weights
+	}
+}
+
+// WithFrozenCentroids fixes the centroids of the first len(centroids) of the
+// k clusters, e.g. existing facilities, while the others are optimised, e.g.
+// to place new facilities. The other initial centroids continue the init
+// strategy from the frozen ones: farthest-first for deterministic strategies,
+// k-means++ sampling otherwise, unless WithCentroids gives them. It requires
+// an algorithm updating centroids in batch, not MiniBatch, MacQueen nor
+// HartiganWong. Only ClusterResult and the functions running it honour it;
+// the other clustering methods reject it.
+func WithFrozenCentroids(centroids [][]float64) Option {
+	return func(c *config) {
+		c.frozen = centroids
+	}
+}
+
+// WithDistance sets the distance used to assign observations to centroids.
+// Centroids are still updated as the mean of their observations unless
+// WithCenter is also set. The distance may be called from several goroutines
+// at once, see WithWorkers.
+func WithDistance(distance DistanceFunc) Option {
+	return func(c *config) {
+		c.distance = distance
+	}
+}
+
+// WithCenter sets how a centroid is computed from the observations assigned
+// to it, instead of taking their mean. Use it to pair a distance with the
+// update it calls for, such as HaversineDistance with HaversineCenter.
+func WithCenter(center CenterFunc) Option {
+	return func(c *config) {
+		c.center = center
+	}
+}
+
+// WithDivergence clusters with a Bregman divergence such as KLDivergence or
+// ItakuraSaitoDivergence, called as divergence(observation, centroid).
+// Centroids remain the mean of their observations, which minimises any Bregman
+// divergence, and the inertia is reported as the sum of divergences.
+func WithDivergence(divergence DistanceFunc) Option {
+	return func(c *config) {
+		c.distance = divergence
+		c.divergence = true
+	}
+}
+
+// WithMedians enables k-medians: observations are assigned with
+// ManhattanDistance and centroids are the component-wise median of their
+// observations (MedianCenter), which makes clusters robust to heavy-tailed
+// outliers. The inertia is reported as the sum of Manhattan distances.
+func WithMedians() Option {
+	return func(c *config) {
+		c.distance = ManhattanDistance
+		c.center = MedianCenter
+		c.linearLoss = true
+	}
+}
+
+// WithModes enables k-modes for categorical observations, whose coordinates
+// are category codes: observations are assigned with the matching
+// dissimilarity, the number of attributes in which they differ
+// (HammingDistance), and centroids are the most frequent category of each
+// attribute (ModeCenter). The inertia is reported as the total number of
+// mismatches.
+func WithModes() Option {
+	return func(c *config) {
+		c.distance = HammingDistance
+		c.center = ModeCenter
+		c.linearLoss = true
+	}
+}
+
+// WithFixedSeeds keeps the observations seeded in SeededKMeans in the cluster
+// of their seed, instead of only using them to choose the initial centroids.
+func WithFixedSeeds() Option {
+	return func(c *config) {
+		c.fixedSeeds = true
+	}
+}
+
+// WithSpherical enables spherical k-means: observations are normalized to unit
+// length, assigned with CosineDistance and centroids are renormalized after
+// each update. This suits text embeddings and other directional data.
+func WithSpherical() Option {
+	return func(c *config) {
+		c.spherical = true
+	}
+}
+
+// WithWorkers sets the number of goroutines sharing the assignment step.
+// Zero, the default, uses runtime.GOMAXPROCS. Results are reproducible for a
+// given number of workers.
+func WithWorkers(n int) Option {
+	return func(c *config) {
+		c.workers = n
+	}
+}
+
+// WithFloat32 stores the working copy of the observations and the centroids
+// as float32, halving memory for large datasets such as embeddings. Distances
+// and sums are still accumulated in float64. It requires the Lloyd algorithm,
+// the Euclidean distance and mean centroids, and cannot be combined with
+// frozen centroids. Init strategies other than InitRandom temporarily work on
+// a float64 copy.
+func WithFloat32() Option {
+	return func(c *config) {
+		c.float32 = true
+	}
+}
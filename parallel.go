@@ -0,0 +1,35 @@
+package kmeans
+
+import "sync"
+
+// shardSize returns the number of items handled by each of at most workers
+// shards covering n items.
+func shardSize(n, workers int) int {
+	return max(1, (n+workers-1)/max(1, workers))
+}
+
+// numShards returns the number of shards parallel splits n items into.
+func numShards(n, workers int) int {
+	size := shardSize(n, workers)
+	return (n + size - 1) / size
+}
+
+// parallel splits the range [0, n) into contiguous shards and calls fn on
+// each of them from its own goroutine, waiting for all of them to return.
+// Shards are numbered from 0 to numShards(n, workers)-1 in range order.
+func parallel(n, workers int, fn func(shard, start, end int)) {
+	size := shardSize(n, workers)
+	if size >= n {
+		fn(0, 0, n)
+		return
+	}
+	var wg sync.WaitGroup
+	for shard, start := 0, 0; start < n; shard, start = shard+1, start+size {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(shard, start, min(start+size, n))
+		}()
+	}
+	wg.Wait()
+}
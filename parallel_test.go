@@ -0,0 +1,57 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	for _, workers := range []int{1, 3, 4, 100} {
+		var visits [10]atomic.Int32
+		shards := make([]bool, numShards(len(visits), workers))
+		parallel(len(visits), workers, func(shard, start, end int) {
+			shards[shard] = true
+			for i := start; i < end; i++ {
+				visits[i].Add(1)
+			}
+		})
+		for i := range visits {
+			if n := visits[i].Load(); n != 1 {
+				t.Errorf("workers = %d: item %d visited %d times", workers, i, n)
+			}
+		}
+		for shard, seen := range shards {
+			if !seen {
+				t.Errorf("workers = %d: shard %d not run", workers, shard)
+			}
+		}
+	}
+}
+
+func TestClusterWithWorkers(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	dataset := make([]Coordinates, 1000)
+	for i := range dataset {
+		offset := 100 * (i % 4)
+		dataset[i] = Coordinates{offset + rng.Intn(10), offset + rng.Intn(10)}
+	}
+
+	// Serial and parallel runs agree on the labels
+	serial, err := ClusterLabels(dataset, 4, 1e-9, 100, rand.New(rand.NewSource(1)), WithWorkers(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sharded, err := ClusterLabels(dataset, 4, 1e-9, 100, rand.New(rand.NewSource(1)), WithWorkers(8))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(serial, sharded) {
+		t.Error("parallel labels differ from serial labels")
+	}
+
+	if _, err := Cluster(dataset, 4, 0.01, 100, rng, WithWorkers(-1)); err == nil {
+		t.Error("expected error for negative workers")
+	}
+}
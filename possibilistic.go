@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+)
+
+// PossibilisticCMeans implements possibilistic c-means: memberships are
+// typicalities, the degree to which an observation is typical of each
+// cluster, and no longer sum to 1 across clusters. Noise observations far
+// from every centroid are typical of none and barely pull the centroids,
+// which makes the clustering robust to noise.
+//
+// The run starts from a fuzzy c-means solution, which also sets the scale of
+// each cluster, its fuzzy within-cluster mean squared distance. The typicality
+// of an observation at squared distance d² from a centroid of scale η is
+// 1/(1 + (d²/η)^(1/(m-1))), m being the fuzzifier. It then iterates until no
+// centroid moves by deltaThreshold or more or iterationThreshold iterations
+// ran. It takes the same options as FuzzyCMeans.
+func PossibilisticCMeans[T Observation](dataset []T, c int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*FuzzyResult[T], error) {
+	cfg, points, err := newFuzzyRun(dataset, c, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+	m := cfg.fuzzifierOrDefault()
+	distance := cfg.distanceFunc()
+
+	// Start from fuzzy c-means
+	memberships := newMatrix(len(points), c)
+	centroids, _, _ := fuzzyCMeans(points, initCentroids(points, c, cfg, rng), memberships, m, deltaThreshold, iterationThreshold, cfg)
+
+	// Scale of each cluster
+	scales := make([]float64, c)
+	totals := make([]float64, c)
+	for i, p := range points {
+		for j, u := range memberships[i] {
+			d := distance(p, centroids[j])
+			w := cfg.weight(i) * math.Pow(u, m)
+			scales[j] += w * d * d
+			totals[j] += w
+		}
+	}
+	for j := range scales {
+		if totals[j] > 0 {
+			scales[j] /= totals[j]
+		}
+		// A cluster without spread still needs a positive scale
+		if scales[j] == 0 {
+			scales[j] = math.SmallestNonzeroFloat64
+		}
+	}
+
+	result := &FuzzyResult[T]{Memberships: memberships}
+	for range iterationThreshold {
+		result.Iterations++
+		typicalities(points, centroids, scales, memberships, m, cfg)
+		newCentroids := fuzzyCentroids(points, memberships, centroids, m, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			result.Converged = true
+			break
+		}
+	}
+	typicalities(points, centroids, scales, memberships, m, cfg)
+
+	// Objective of the final centroids
+	for i, p := range points {
+		for j, t := range memberships[i] {
+			d := distance(p, centroids[j])
+			result.Objective += cfg.weight(i) * (math.Pow(t, m)*d*d + scales[j]*math.Pow(1-t, m))
+		}
+	}
+	result.Centroids = centroids
+	result.Labels = hardLabels(memberships)
+	return result, nil
+}
+
+// typicalities sets the possibilistic typicality of every point to every
+// centroid given the scale of each cluster.
+func typicalities(points, centroids [][]float64, scales []float64, memberships [][]float64, m float64, cfg *config) {
+	distance := cfg.distanceFunc()
+	exponent := 1 / (m - 1)
+	parallel(len(points), cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			for j, centroid := range centroids {
+				d := distance(points[i], centroid)
+				memberships[i][j] = 1 / (1 + math.Pow(d*d/scales[j], exponent))
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPossibilisticCMeans(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 4, 5, 21, 22, 23, 24, 25, 40}
+	initial := WithCentroids([][]float64{{3}, {23}})
+	fuzzy, err := FuzzyCMeans(dataset, 2, 1e-9, 300, nil, initial)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := PossibilisticCMeans(dataset, 2, 1e-9, 300, nil, initial)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The noise observation is hardly typical of the nearest cluster, while
+	// fuzzy c-means gives it most of its membership
+	if typicality := result.Memberships[10][1]; typicality > 0.2 || fuzzy.Memberships[10][1] < 0.8 {
+		t.Errorf("expected a low typicality for noise, got %v", typicality)
+	}
+
+	// So it pulls the centroid less
+	if math.Abs(result.Centroids[1][0]-23) >= math.Abs(fuzzy.Centroids[1][0]-23) {
+		t.Errorf("expected centroid closer to 23 than %v, got %v", fuzzy.Centroids[1], result.Centroids[1])
+	}
+}
+
+func TestPossibilisticCMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	if _, err := PossibilisticCMeans(dataset, 2, 0.01, 100, rand.New(rand.NewSource(0)), WithFuzzifier(0.5)); err == nil {
+		t.Error("expected error for a fuzzifier below 1")
+	}
+	if _, err := PossibilisticCMeans(dataset, 2, 0.01, 100, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := PossibilisticCMeans(dataset, 2, 0.01, 100, rand.New(rand.NewSource(0)), WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
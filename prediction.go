@@ -0,0 +1,162 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// predictionStrengthThreshold is the prediction strength above which
+// PredictionStrength deems clusters reproducible, as recommended by
+// Tibshirani and Walther.
+const predictionStrengthThreshold = 0.8
+
+// PredictionStrengthResult holds the prediction strength curve of
+// PredictionStrength.
+type PredictionStrengthResult struct {
+	// Strengths holds the mean prediction strength with kMin+i clusters at
+	// index i, between 0 and 1.
+	Strengths []float64
+	// K is the recommended number of clusters, the largest k whose strength
+	// is at least 0.8, or kMin if there is none.
+	K int
+}
+
+// PredictionStrength estimates the number of clusters with the prediction
+// strength of Tibshirani and Walther. The dataset is split at random into
+// two halves which are clustered separately with ClusterResult; every
+// cluster of the test half is then checked against the centroids of the
+// training half, counting how many of its pairs of observations those
+// centroids also put together. The strength of a split is the proportion of
+// the worst predicted cluster, and reproducible clusterings keep it close to
+// 1 while too many clusters break it down.
+//
+// The strength with every number of clusters from kMin to kMax, at most half
+// the dataset, is averaged over splits random splits. The splits are drawn
+// from rng once and shared by every k, and every run draws its seed from rng
+// beforehand, so results do not depend on the number of WithWorkers
+// goroutines the runs are spread over, each run using one. Test observations
+// are assigned to the training centroids with the configured distance. Other
+// options are passed to every run.
+func PredictionStrength[T Observation](dataset []T, kMin, kMax, splits int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*PredictionStrengthResult, error) {
+	cfg := newConfig(opts)
+
+	// Validate kMin and kMax
+	if kMin <= 0 || kMin > len(dataset)/2 {
+		return nil, fmt.Errorf("invalid minimum number of clusters: %d", kMin)
+	}
+	if kMax < kMin || kMax > len(dataset)/2 {
+		return nil, fmt.Errorf("invalid maximum number of clusters: %d", kMax)
+	}
+
+	// Validate splits
+	if splits <= 0 {
+		return nil, fmt.Errorf("invalid number of splits: %d", splits)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Random halves, the training half first
+	half := len(dataset) / 2
+	orders := make([][]int, splits)
+	for s := range orders {
+		orders[s] = rng.Perm(len(dataset))
+	}
+
+	// One run per k and split, with seeds drawn in order
+	n := kMax - kMin + 1
+	runs := n * splits
+	seeds := make([]int64, runs)
+	for r := range seeds {
+		seeds[r] = rng.Int63()
+	}
+
+	strengths := make([]float64, runs)
+	errs := make([]error, runs)
+	runOpts := append(opts[:len(opts):len(opts)], WithWorkers(1))
+	distance := cfg.distanceFunc()
+	parallel(runs, cfg.workerCount(), func(_, start, end int) {
+		for r := start; r < end; r++ {
+			k, order := kMin+r/splits, orders[r%splits]
+			runRng := rand.New(rand.NewSource(seeds[r]))
+			train, test := make([]T, half), make([]T, len(dataset)-half)
+			for i, o := range order {
+				if i < half {
+					train[i] = dataset[o]
+				} else {
+					test[i-half] = dataset[o]
+				}
+			}
+			trained, err := ClusterResult(train, k, deltaThreshold, iterationThreshold, runRng, runOpts...)
+			if err != nil {
+				errs[r] = err
+				continue
+			}
+			tested, err := ClusterResult(test, k, deltaThreshold, iterationThreshold, runRng, runOpts...)
+			if err != nil {
+				errs[r] = err
+				continue
+			}
+			predicted := make([]int, len(test))
+			for i, o := range order[half:] {
+				predicted[i], _ = nearest(points[o], trained.Centroids, distance)
+			}
+			strengths[r] = predictionStrength(tested.Labels, predicted, k)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &PredictionStrengthResult{Strengths: make([]float64, n), K: kMin}
+	for i := range n {
+		for _, strength := range strengths[i*splits : (i+1)*splits] {
+			result.Strengths[i] += strength / float64(splits)
+		}
+		if result.Strengths[i] >= predictionStrengthThreshold {
+			result.K = kMin + i
+		}
+	}
+	return result, nil
+}
+
+// predictionStrength returns the smallest proportion, over the clusters of
+// labels with at least two observations, of their pairs of observations that
+// predicted also puts in the same cluster, or 1 if there is no such cluster.
+func predictionStrength(labels, predicted []int, k int) float64 {
+	// Co-occurrences of every cluster of labels with every predicted one
+	counts := make([][]float64, k)
+	for j := range counts {
+		counts[j] = make([]float64, k)
+	}
+	for i, j := range labels {
+		counts[j][predicted[i]]++
+	}
+
+	strength := 1.0
+	for _, row := range counts {
+		size, together := 0.0, 0.0
+		for _, c := range row {
+			size += c
+			together += c * (c - 1) / 2
+		}
+		if size >= 2 {
+			strength = min(strength, together/(size*(size-1)/2))
+		}
+	}
+	return strength
+}
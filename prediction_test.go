@@ -0,0 +1,70 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPredictionStrength(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	result, err := PredictionStrength(dataset, 1, 6, 5, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Strengths) != 6 {
+		t.Fatalf("expected 6 strengths, got %d", len(result.Strengths))
+	}
+	if result.Strengths[0] != 1 {
+		t.Errorf("expected a strength of 1 with a single cluster, got %f", result.Strengths[0])
+	}
+	if result.K != len(centers) {
+		t.Errorf("expected %d clusters, got %d (strengths %v)", len(centers), result.K, result.Strengths)
+	}
+
+	// Results do not depend on the number of workers
+	again, err := PredictionStrength(dataset, 1, 6, 5, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus), WithWorkers(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range result.Strengths {
+		if again.Strengths[i] != result.Strengths[i] {
+			t.Fatalf("strength with %d clusters differs between runs", i+1)
+		}
+	}
+}
+
+func TestPredictionStrengthPairs(t *testing.T) {
+	// The first cluster keeps 1 of its 3 pairs together, the second all of
+	// them, and the singleton third one is ignored
+	labels := []int{0, 0, 0, 1, 1, 1, 2}
+	predicted := []int{0, 0, 1, 2, 2, 2, 0}
+	if s := predictionStrength(labels, predicted, 3); math.Abs(s-1.0/3) > 1e-12 {
+		t.Errorf("expected strength 1/3, got %f", s)
+	}
+	if s := predictionStrength([]int{0, 1}, []int{1, 0}, 2); s != 1 {
+		t.Errorf("expected strength 1 without pairs, got %f", s)
+	}
+}
+
+func TestPredictionStrengthValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := PredictionStrength(dataset, 0, 2, 3, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid minimum k")
+	}
+	if _, err := PredictionStrength(dataset, 1, 4, 3, 0.01, 10, rng); err == nil {
+		t.Error("expected error for more clusters than half the dataset")
+	}
+	if _, err := PredictionStrength(dataset, 1, 2, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid number of splits")
+	}
+	if _, err := PredictionStrength(dataset, 1, 2, 3, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := PredictionStrength(dataset, 1, 2, 3, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+}
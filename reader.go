@@ -0,0 +1,238 @@
+package kmeans
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// defaultChunkSize is the number of observations ClusterReader loads at once
+// when none is configured.
+const defaultChunkSize = 65536
+
+// ChunkedResult holds the outcome of ClusterReader. Observations are not kept
+// in memory, so it holds no clusters nor labels.
+type ChunkedResult struct {
+	// Centroids holds the center of each cluster.
+	Centroids [][]float64
+	// Sizes holds the number of observations of each cluster.
+	Sizes []int
+	// Inertia is the total within-cluster sum of squared distances, measured
+	// during the last pass over the data, against the centroids before their
+	// final update.
+	Inertia float64
+	// Iterations is the number of passes over the data.
+	Iterations int
+	// Converged reports whether the centroids moved less than the delta
+	// threshold before the iteration threshold was reached.
+	Converged bool
+}
+
+// ClusterReader clusters a dataset too large for memory, stored in r as
+// consecutive records of dim little-endian float64 values. Each iteration is
+// a pass over r, which is rewound with Seek, loading chunks of observations
+// set with WithChunkSize and accumulating the sums and counts of each cluster.
+// The initial centroids are chosen from a uniform sample of one chunk of
+// observations, drawn in a first pass, or from the first chunk if the init
+// strategy is deterministic and rng is nil.
+//
+// It runs the Lloyd algorithm with mean centroids and honours WithInit,
+// WithLocalTrials, WithCentroids, WithDistance, WithDivergence, WithSpherical
+// and WithWorkers.
+func ClusterReader(r io.ReadSeeker, dim, k int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*ChunkedResult, error) {
+	cfg := newConfig(opts)
+
+	// Validate dim
+	if dim <= 0 {
+		return nil, fmt.Errorf("invalid dimension: %d", dim)
+	}
+
+	// Validate k
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate algorithm and center, chunks only support mean updates
+	if cfg.algorithm != Lloyd || cfg.center != nil {
+		return nil, fmt.Errorf("chunked clustering requires the Lloyd algorithm and mean centroids")
+	}
+
+	// Validate chunk size
+	if cfg.chunkSize < 0 {
+		return nil, fmt.Errorf("invalid chunk size: %d", cfg.chunkSize)
+	}
+	chunkSize := cfg.chunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	// Validate initial centroids
+	if err := validateCentroids(cfg.centroids, k, dim); err != nil {
+		return nil, err
+	}
+
+	// Choose the initial centroids from a sample of the data
+	var centroids [][]float64
+	if cfg.centroids != nil {
+		centroids = initCentroids(nil, k, cfg, rng)
+	} else {
+		sample, err := reservoir(r, dim, chunkSize, rng)
+		if err != nil {
+			return nil, err
+		}
+		if k > len(sample) {
+			return nil, fmt.Errorf("invalid number of clusters: %d", k)
+		}
+		if cfg.spherical {
+			for _, p := range sample {
+				normalize(p)
+			}
+		}
+		centroids = initCentroids(sample, k, cfg, rng)
+	}
+	if cfg.spherical {
+		for _, centroid := range centroids {
+			normalize(centroid)
+		}
+	}
+
+	// Main k-means loop, one pass over the data per iteration
+	result := &ChunkedResult{}
+	loss := cfg.lossFunc()
+	labels := make([]int, chunkSize)
+	for range iterationThreshold {
+		result.Iterations++
+
+		stats := NewStats(k, dim)
+		inertia := 0.0
+		err := readChunks(r, dim, chunkSize, func(chunk [][]float64) {
+			if cfg.spherical {
+				for _, p := range chunk {
+					normalize(p)
+				}
+			}
+			stats.merge(newAssigner(chunk, cfg).assign(centroids, labels[:len(chunk)]))
+			for i, p := range chunk {
+				inertia += loss(p, centroids[labels[i]])
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result.Inertia = inertia
+		result.Sizes = make([]int, k)
+		for j, count := range stats.Counts {
+			result.Sizes[j] = int(count)
+		}
+
+		// Update step, then stop if maximum movement is below the threshold
+		newCentroids := update(nil, nil, centroids, stats, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			result.Converged = true
+			break
+		}
+	}
+	result.Centroids = centroids
+	return result, nil
+}
+
+// readChunks rewinds r and calls fn with consecutive chunks of at most
+// chunkSize records of dim little-endian float64 values. The chunk is reused
+// between calls. It fails if r ends in the middle of a record.
+func readChunks(r io.ReadSeeker, dim, chunkSize int, fn func(chunk [][]float64)) error {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(r)
+	record := dim * 8
+	buf := make([]byte, chunkSize*record)
+	flat := make([]float64, chunkSize*dim)
+	rows := make([][]float64, chunkSize)
+	for i := range rows {
+		rows[i] = flat[i*dim : (i+1)*dim : (i+1)*dim]
+	}
+	for {
+		n, err := io.ReadFull(reader, buf)
+		if n%record != 0 {
+			return fmt.Errorf("truncated record")
+		}
+		for i := range flat[:n/8] {
+			flat[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[i*8:]))
+		}
+		if n > 0 {
+			fn(rows[:n/record])
+		}
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+}
+
+// reservoir draws a uniform sample of at most size records from r in a single
+// pass, or keeps the first size records if rng is nil. It fails if r holds no
+// record.
+func reservoir(r io.ReadSeeker, dim, size int, rng *rand.Rand) ([][]float64, error) {
+	var sample [][]float64
+	seen := 0
+	err := readChunks(r, dim, size, func(chunk [][]float64) {
+		for _, p := range chunk {
+			seen++
+			switch {
+			case len(sample) < size:
+				sample = append(sample, slices.Clone(p))
+			case rng != nil:
+				if i := rng.Intn(seen); i < size {
+					sample[i] = slices.Clone(p)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sample) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+	return sample, nil
+}
@@ -0,0 +1,84 @@
+package kmeans
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// records encodes dataset as consecutive little-endian float64 values.
+func records(t *testing.T, dataset []Vector) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, v := range dataset {
+		if err := binary.Write(&buf, binary.LittleEndian, []float64(v)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestClusterReader(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 5000, 5, 3)
+	initial := [][]float64{dataset[0], dataset[1], dataset[2], dataset[3], dataset[4]}
+
+	// Chunked passes give the same solution as clustering in memory
+	expected, err := ClusterResult(dataset, 5, 1e-9, 100, nil, WithCentroids(initial))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := ClusterReader(records(t, dataset), 3, 5, 1e-9, 100, nil, WithCentroids(initial), WithChunkSize(700))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Iterations != expected.Iterations {
+		t.Errorf("expected %d iterations, got %d", expected.Iterations, result.Iterations)
+	}
+	for j := range expected.Centroids {
+		if d := EuclideanDistance(result.Centroids[j], expected.Centroids[j]); d > 1e-9 {
+			t.Errorf("expected centroid %v, got %v", expected.Centroids[j], result.Centroids[j])
+		}
+		if result.Sizes[j] != len(expected.Clusters[j]) {
+			t.Errorf("expected %d observations in cluster %d, got %d", len(expected.Clusters[j]), j, result.Sizes[j])
+		}
+	}
+	if math.Abs(result.Inertia-expected.Inertia) > 1e-6*expected.Inertia {
+		t.Errorf("expected inertia %v, got %v", expected.Inertia, result.Inertia)
+	}
+}
+
+func TestClusterReaderSampledInit(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 5000, 5, 3)
+	result, err := ClusterReader(records(t, dataset), 3, 5, 1e-6, 100, rand.New(rand.NewSource(0)), WithInit(InitGreedyKMeansPlusPlus), WithChunkSize(1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	total := 0
+	for _, size := range result.Sizes {
+		total += size
+	}
+	if total != len(dataset) || !result.Converged {
+		t.Errorf("expected %d observations and convergence, got %d and %v", len(dataset), total, result.Converged)
+	}
+}
+
+func TestClusterReaderValidation(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	if _, err := ClusterReader(bytes.NewReader(nil), 2, 2, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := ClusterReader(bytes.NewReader(make([]byte, 20)), 2, 1, 0.01, 10, rng); err == nil {
+		t.Error("expected error for truncated record")
+	}
+	if _, err := ClusterReader(bytes.NewReader(make([]byte, 32)), 2, 1, 0.01, 10, rng, WithAlgorithm(Elkan)); err == nil {
+		t.Error("expected error for unsupported algorithm")
+	}
+	if _, err := ClusterReader(bytes.NewReader(make([]byte, 32)), 0, 1, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid dimension")
+	}
+	if _, err := ClusterReader(bytes.NewReader(make([]byte, 32)), 2, 1, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1, 2}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
@@ -0,0 +1,209 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// reportFeatures is the number of most discriminative features listed for
+// each cluster by Report.
+const reportFeatures = 3
+
+// QualityReport summarises a clustering. It marshals to JSON with
+// encoding/json and String renders it as text.
+type QualityReport struct {
+	// Inertia is the total inertia of the clustering.
+	Inertia float64 `json:"inertia"`
+	// Silhouette is the mean silhouette coefficient of the observations, 0
+	// when fewer than two clusters are non-empty.
+	Silhouette float64 `json:"silhouette"`
+	// Clusters describes each cluster.
+	Clusters []ClusterReport `json:"clusters"`
+	// CentroidDistances holds, at CentroidDistances[i][j], the distance
+	// between the centroids of clusters i and j.
+	CentroidDistances [][]float64 `json:"centroid_distances"`
+}
+
+// ClusterReport describes a cluster in a QualityReport.
+type ClusterReport struct {
+	// Size is the number of observations of the cluster.
+	Size int `json:"size"`
+	// Inertia is the share of the inertia due to the cluster.
+	Inertia float64 `json:"inertia"`
+	// Silhouette is the mean silhouette coefficient of the observations of
+	// the cluster, 0 when it is empty.
+	Silhouette float64 `json:"silhouette"`
+	// Features lists the dimensions that most set the cluster apart, by
+	// decreasing absolute score.
+	Features []FeatureReport `json:"features"`
+}
+
+// FeatureReport describes how a dimension sets a cluster apart.
+type FeatureReport struct {
+	// Dimension is the index of the coordinate.
+	Dimension int `json:"dimension"`
+	// Centroid is the coordinate of the centroid of the cluster.
+	Centroid float64 `json:"centroid"`
+	// Mean is the mean coordinate of all observations.
+	Mean float64 `json:"mean"`
+	// Score is the distance from the mean to the centroid in standard
+	// deviations of all observations, 0 for a constant coordinate.
+	Score float64 `json:"score"`
+}
+
+// Report summarises result in a QualityReport: the size, inertia and mean
+// silhouette coefficient of every cluster, the coordinates in which its
+// centroid departs the most from the mean of all observations, and the
+// distances between centroids.
+//
+// The inertia of every cluster and the distances are measured as in the run,
+// so options such as WithDistance should be those of the run, and the
+// inertia honours the weights of weighted observations. The silhouette
+// coefficients are computed as in Silhouette, which takes time quadratic in
+// the number of observations unless WithSampleSize is set; rng may be nil
+// when not sampling. It honours WithWorkers.
+func Report[T Observation](result *Result[T], rng *rand.Rand, opts ...Option) (*QualityReport, error) {
+	cfg := newConfig(opts)
+
+	// Validate result
+	if len(result.Centroids) != len(result.Clusters) {
+		return nil, fmt.Errorf("expected %d centroids, got %d", len(result.Clusters), len(result.Centroids))
+	}
+	var dataset []T
+	for _, cluster := range result.Clusters {
+		dataset = append(dataset, cluster...)
+	}
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := observationWeights(dataset)
+	if err != nil {
+		return nil, err
+	}
+	cfg.weights = weights
+	dim := len(points[0])
+	for _, centroid := range result.Centroids {
+		if len(centroid) != dim {
+			return nil, fmt.Errorf("inconsistent dimensions")
+		}
+	}
+
+	report := &QualityReport{
+		Inertia:           result.Inertia,
+		Clusters:          make([]ClusterReport, len(result.Clusters)),
+		CentroidDistances: newMatrix(len(result.Centroids), len(result.Centroids)),
+	}
+
+	// Silhouette coefficients, when at least two clusters are non-empty
+	nonEmpty := 0
+	for _, cluster := range result.Clusters {
+		if len(cluster) > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty >= 2 {
+		score, values, err := Silhouette(result.Clusters, rng, opts...)
+		if err != nil {
+			return nil, err
+		}
+		report.Silhouette = score
+		for j, cluster := range values {
+			sum, count := 0.0, 0
+			for _, v := range cluster {
+				if !math.IsNaN(v) {
+					sum += v
+					count++
+				}
+			}
+			if count > 0 {
+				report.Clusters[j].Silhouette = sum / float64(count)
+			}
+		}
+	}
+
+	// Mean and standard deviation of every coordinate
+	mean := make([]float64, dim)
+	for _, p := range points {
+		for d, v := range p {
+			mean[d] += v / float64(len(points))
+		}
+	}
+	deviation := make([]float64, dim)
+	for _, p := range points {
+		for d, v := range p {
+			deviation[d] += (v - mean[d]) * (v - mean[d]) / float64(len(points))
+		}
+	}
+	for d := range deviation {
+		deviation[d] = math.Sqrt(deviation[d])
+	}
+
+	loss := cfg.lossFunc()
+	start := 0
+	for j, cluster := range result.Clusters {
+		centroid := result.Centroids[j]
+		c := &report.Clusters[j]
+		c.Size = len(cluster)
+		for i := start; i < start+len(cluster); i++ {
+			c.Inertia += cfg.weight(i) * loss(points[i], centroid)
+		}
+		start += len(cluster)
+
+		// Coordinates departing the most from the mean
+		features := make([]FeatureReport, dim)
+		for d := range dim {
+			features[d] = FeatureReport{Dimension: d, Centroid: centroid[d], Mean: mean[d]}
+			if deviation[d] > 0 {
+				features[d].Score = (centroid[d] - mean[d]) / deviation[d]
+			}
+		}
+		slices.SortStableFunc(features, func(a, b FeatureReport) int {
+			return cmp.Compare(math.Abs(b.Score), math.Abs(a.Score))
+		})
+		c.Features = features[:min(reportFeatures, dim):min(reportFeatures, dim)]
+	}
+
+	distance := cfg.distanceFunc()
+	for i := range result.Centroids {
+		for j := range i {
+			d := distance(result.Centroids[i], result.Centroids[j])
+			report.CentroidDistances[i][j], report.CentroidDistances[j][i] = d, d
+		}
+	}
+	return report, nil
+}
+
+// String renders the report as human-readable text.
+func (r *QualityReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d clusters, inertia %.6g, silhouette %.3f\n", len(r.Clusters), r.Inertia, r.Silhouette)
+	for j, c := range r.Clusters {
+		fmt.Fprintf(&b, "cluster %d: size %d, inertia %.6g, silhouette %.3f", j, c.Size, c.Inertia, c.Silhouette)
+		if c.Size > 0 && len(c.Features) > 0 {
+			b.WriteString(", features")
+			for _, f := range c.Features {
+				fmt.Fprintf(&b, " %d=%.4g (%+.2fσ)", f.Dimension, f.Centroid, f.Score)
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("centroid distances:\n")
+	for _, row := range r.CentroidDistances {
+		for j, d := range row {
+			if j > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprintf(&b, "%.4g", d)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package kmeans
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	// Clusters apart along the first coordinate only
+	clusters := [][]Vector{{{0, 0}, {2, 1}}, {{10, 0}, {12, 1}}}
+	result := &Result[Vector]{
+		Clusters:  clusters,
+		Centroids: [][]float64{{1, 0.5}, {11, 0.5}},
+		Labels:    []int{0, 0, 1, 1},
+		Inertia:   5,
+	}
+	report, err := Report(result, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Inertia != 5 || len(report.Clusters) != 2 {
+		t.Fatalf("expected inertia 5 and 2 clusters, got %f and %d", report.Inertia, len(report.Clusters))
+	}
+	for j, c := range report.Clusters {
+		if c.Size != 2 || math.Abs(c.Inertia-2.5) > 1e-12 {
+			t.Errorf("expected cluster %d of size 2 and inertia 2.5, got %d and %f", j, c.Size, c.Inertia)
+		}
+		if c.Silhouette <= 0.7 {
+			t.Errorf("expected a high silhouette for cluster %d, got %f", j, c.Silhouette)
+		}
+		if c.Features[0].Dimension != 0 || c.Features[1].Score != 0 {
+			t.Errorf("expected the first coordinate to set cluster %d apart, got %+v", j, c.Features)
+		}
+	}
+	if score, want := report.Clusters[0].Features[0].Score, -5/math.Sqrt(26); math.Abs(score-want) > 1e-12 {
+		t.Errorf("expected cluster 0 %f standard deviations from the mean, got %f", want, score)
+	}
+	if report.CentroidDistances[0][1] != 10 || report.CentroidDistances[1][0] != 10 {
+		t.Errorf("expected centroids 10 apart, got %v", report.CentroidDistances)
+	}
+
+	// JSON and text renderings
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded QualityReport
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.Clusters[1].Size != 2 || decoded.CentroidDistances[0][1] != 10 {
+		t.Errorf("expected the report to round-trip through JSON, got %s", encoded)
+	}
+	text := report.String()
+	if !strings.Contains(text, "2 clusters") || !strings.Contains(text, "cluster 1: size 2") {
+		t.Errorf("unexpected text report:\n%s", text)
+	}
+}
+
+func TestReportSingleCluster(t *testing.T) {
+	dataset := []Numbers{1, 2, 3}
+	result, err := ClusterResult(dataset, 1, 0.01, 10, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report, err := Report(result, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Silhouette != 0 || report.Clusters[0].Size != 3 || len(report.Clusters[0].Features) != 1 {
+		t.Errorf("unexpected report of a single cluster: %+v", report)
+	}
+	if _, err := json.Marshal(report); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReportValidation(t *testing.T) {
+	if _, err := Report(&Result[Numbers]{Clusters: [][]Numbers{{}}, Centroids: [][]float64{{0}}}, nil); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := Report(&Result[Numbers]{Clusters: [][]Numbers{{1}, {2}}, Centroids: [][]float64{{1}}}, nil); err == nil {
+		t.Error("expected error for missing centroids")
+	}
+	if _, err := Report(&Result[Numbers]{Clusters: [][]Numbers{{1}, {2}}, Centroids: [][]float64{{1}, {2, 0}}}, nil); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, err := Report(&Result[Numbers]{Clusters: [][]Numbers{{1, 2}, {3, 4}}, Centroids: [][]float64{{1.5}, {3.5}}}, nil, WithSampleSize(2)); err == nil {
+		t.Error("expected error for sampling without a random number generator")
+	}
+}
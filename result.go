@@ -0,0 +1,44 @@
+package kmeans
+
+// Result holds the outcome of a k-means run.
+type Result[T Observation] struct {
+	// Clusters groups the observations by cluster.
+	Clusters [][]T
+	// Centroids holds the center of each cluster.
+	Centroids [][]float64
+	// Labels holds the cluster index of each observation, in input order.
+	Labels []int
+	// Inertia is the total within-cluster sum of squared distances, measured
+	// with the configured distance, or the sum of divergences when clustering
+	// with WithDivergence, or of Manhattan distances with WithMedians, or of
+	// mismatches with WithModes.
+	Inertia float64
+	// Iterations is the number of iterations executed.
+	Iterations int
+	// Converged reports whether the centroids moved less than the delta
+	// threshold before the iteration threshold was reached.
+	Converged bool
+}
+
+// newResult groups dataset by labels and computes the inertia of the solution
+// as the sum of the loss of each point to its centroid, scaled by the weight
+// of the point when weights is not nil.
+func newResult[T Observation](dataset []T, points, centroids [][]float64, labels []int, weights []float64, loss DistanceFunc) *Result[T] {
+	clusters := make([][]T, len(centroids))
+	inertia := 0.0
+	for i, obs := range dataset {
+		j := labels[i]
+		clusters[j] = append(clusters[j], obs)
+		if weights != nil {
+			inertia += weights[i] * loss(points[i], centroids[j])
+		} else {
+			inertia += loss(points[i], centroids[j])
+		}
+	}
+	return &Result[T]{
+		Clusters:  clusters,
+		Centroids: centroids,
+		Labels:    labels,
+		Inertia:   inertia,
+	}
+}
@@ -0,0 +1,51 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestClusterResult(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+
+	result, err := ClusterResult(dataset, 2, 0.01, 100, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClusters(t, result.Clusters, [][]Numbers{{1, 2, 3}, {11, 12, 13}})
+
+	// Each group contributes 1 + 0 + 1 to the within-cluster sum of squares
+	if math.Abs(result.Inertia-4) > 1e-9 {
+		t.Errorf("expected inertia 4, got %v", result.Inertia)
+	}
+	if !result.Converged {
+		t.Error("expected run to converge")
+	}
+	if result.Iterations < 1 || result.Iterations > 100 {
+		t.Errorf("unexpected iteration count: %d", result.Iterations)
+	}
+	for i, obs := range dataset {
+		if float64(obs) < 10 != (result.Centroids[result.Labels[i]][0] < 10) {
+			t.Errorf("observation %v labelled with centroid %v", obs, result.Centroids[result.Labels[i]])
+		}
+	}
+}
+
+func TestClusterResultIterationCap(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	rng := rand.New(rand.NewSource(0))
+
+	// A single iteration cannot confirm convergence with a tiny threshold
+	result, err := ClusterResult(dataset, 3, 1e-12, 1, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Iterations != 1 {
+		t.Errorf("expected 1 iteration, got %d", result.Iterations)
+	}
+	if result.Converged {
+		t.Error("expected run to hit the iteration cap")
+	}
+}
@@ -0,0 +1,21 @@
+package kmeans
+
+import "math/rand"
+
+// sample draws cfg.sampleSize distinct points uniformly at random and returns
+// them with a copy of cfg holding their weights, to fit centroids on.
+func sample(points [][]float64, cfg *config, rng *rand.Rand) ([][]float64, *config) {
+	indices := randomIndices(len(points), cfg.sampleSize, rng)
+	sampled := make([][]float64, len(indices))
+	sampleCfg := *cfg
+	if cfg.weights != nil {
+		sampleCfg.weights = make([]float64, len(indices))
+	}
+	for s, i := range indices {
+		sampled[s] = points[i]
+		if cfg.weights != nil {
+			sampleCfg.weights[s] = cfg.weights[i]
+		}
+	}
+	return sampled, &sampleCfg
+}
@@ -0,0 +1,54 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestClusterSampleSize(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20000, 5, 2)
+
+	expected, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(0)), WithInit(InitGreedyKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(0)), WithInit(InitGreedyKMeansPlusPlus), WithSampleSize(1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every observation is labelled and the inertia is close to the full run
+	if len(result.Labels) != len(dataset) {
+		t.Errorf("expected %d labels, got %d", len(dataset), len(result.Labels))
+	}
+	if result.Inertia > 1.05*expected.Inertia {
+		t.Errorf("expected inertia close to %v, got %v", expected.Inertia, result.Inertia)
+	}
+}
+
+func TestSample(t *testing.T) {
+	points := [][]float64{{0}, {1}, {2}, {3}}
+	cfg := &config{sampleSize: 2, weights: []float64{0, 1, 2, 3}}
+	sampled, sampleCfg := sample(points, cfg, rand.New(rand.NewSource(0)))
+
+	// Sampled points keep their weights
+	if len(sampled) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(sampled))
+	}
+	for s, point := range sampled {
+		if sampleCfg.weights[s] != point[0] {
+			t.Errorf("expected weight %v for %v, got %v", point[0], point, sampleCfg.weights[s])
+		}
+	}
+}
+
+func TestClusterSampleSizeValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Cluster(dataset, 3, 0.01, 100, rng, WithSampleSize(2)); err == nil {
+		t.Error("expected error for a sample smaller than k")
+	}
+	if _, err := Cluster(dataset, 2, 0.01, 100, nil, WithInit(InitMaximin), WithSampleSize(4)); err == nil {
+		t.Error("expected error for sampling without a random number generator")
+	}
+}
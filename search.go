@@ -0,0 +1,263 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Index selects the validity index scoring the candidates of Search.
+type Index int
+
+const (
+	// IndexSilhouette scores candidates with Silhouette.
+	IndexSilhouette Index = iota
+	// IndexCalinskiHarabasz scores candidates with CalinskiHarabasz.
+	IndexCalinskiHarabasz
+	// IndexDaviesBouldin scores candidates with the opposite of
+	// DaviesBouldin, so that higher is better.
+	IndexDaviesBouldin
+	// IndexDunn scores candidates with Dunn.
+	IndexDunn
+	// IndexBIC scores candidates with BIC.
+	IndexBIC
+	// IndexAIC scores candidates with AIC.
+	IndexAIC
+
+	// numIndices is the number of supported indices.
+	numIndices
+)
+
+// valid reports whether i is a supported index.
+func (i Index) valid() bool {
+	return i >= 0 && i < numIndices
+}
+
+// SearchSpace lists the values of the hyperparameters explored by Search,
+// which tries every combination of them.
+type SearchSpace struct {
+	// Ks holds the numbers of clusters to try.
+	Ks []int
+	// Inits holds the initialization strategies to try. When empty, the one
+	// set with WithInit is used.
+	Inits []Init
+	// Distances holds the distances to try by name. When empty, the one set
+	// with WithDistance is used, with an empty name.
+	Distances map[string]DistanceFunc
+	// Restarts holds the numbers of runs to try, keeping the run with the
+	// lowest inertia. When empty, every candidate runs once.
+	Restarts []int
+}
+
+// Candidate is a combination of hyperparameters tried by Search.
+type Candidate struct {
+	// K is the number of clusters.
+	K int
+	// Init is the initialization strategy.
+	Init Init
+	// Distance is the name of the distance in the search space.
+	Distance string
+	// Restarts is the number of runs.
+	Restarts int
+}
+
+// SearchEntry is a candidate tried by Search and its score.
+type SearchEntry struct {
+	Candidate
+	// Score is the validity index of the best run of the candidate, higher
+	// being better.
+	Score float64
+	// Err holds why the candidate could not be run or scored, in which case
+	// Score is -Inf.
+	Err error
+}
+
+// SearchResult holds the outcome of Search.
+type SearchResult[T Observation] struct {
+	// Best holds the result of the best candidate.
+	Best *Result[T]
+	// Leaderboard holds every candidate tried, by decreasing score, failed
+	// candidates last.
+	Leaderboard []SearchEntry
+}
+
+// Search runs ClusterResult with every combination of the hyperparameters of
+// space, or with samples of them drawn at random when samples is positive
+// and less than the number of combinations, and scores the best run of
+// every candidate with index. It returns the result of the best candidate
+// and the leaderboard of all of them. Candidates that fail, for instance a
+// single cluster scored with Silhouette, are ranked last with their error;
+// Search fails only when every candidate does.
+//
+// Candidates are spread over WithWorkers goroutines, each run using one, and
+// draw their seeds from rng beforehand, so results do not depend on the
+// number of workers. Other options are passed to every run and to the index,
+// a candidate's distance replacing the one set with WithDistance.
+func Search[T Observation](dataset []T, space SearchSpace, index Index, samples int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*SearchResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate the search space
+	if len(space.Ks) == 0 {
+		return nil, fmt.Errorf("search space has no number of clusters")
+	}
+	for _, k := range space.Ks {
+		if k <= 0 || k > len(dataset) {
+			return nil, fmt.Errorf("invalid number of clusters: %d", k)
+		}
+	}
+	inits := space.Inits
+	if len(inits) == 0 {
+		inits = []Init{cfg.init}
+	}
+	for _, init := range inits {
+		if !init.valid() {
+			return nil, fmt.Errorf("invalid init strategy: %d", init)
+		}
+	}
+	names := slices.Sorted(maps.Keys(space.Distances))
+	for _, name := range names {
+		if space.Distances[name] == nil {
+			return nil, fmt.Errorf("distance %q is nil", name)
+		}
+	}
+	if len(names) == 0 {
+		names = []string{""}
+	}
+	restarts := space.Restarts
+	if len(restarts) == 0 {
+		restarts = []int{1}
+	}
+	for _, r := range restarts {
+		if r <= 0 {
+			return nil, fmt.Errorf("invalid number of restarts: %d", r)
+		}
+	}
+
+	// Validate index
+	if !index.valid() {
+		return nil, fmt.Errorf("invalid validity index: %d", index)
+	}
+
+	// Validate samples
+	if samples < 0 {
+		return nil, fmt.Errorf("invalid number of samples: %d", samples)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Every combination, or a random sample of them
+	var candidates []Candidate
+	for _, k := range space.Ks {
+		for _, init := range inits {
+			for _, name := range names {
+				for _, r := range restarts {
+					candidates = append(candidates, Candidate{K: k, Init: init, Distance: name, Restarts: r})
+				}
+			}
+		}
+	}
+	if samples > 0 && samples < len(candidates) {
+		picked := make([]Candidate, samples)
+		for i, c := range randomIndices(len(candidates), samples, rng) {
+			picked[i] = candidates[c]
+		}
+		candidates = picked
+	}
+
+	// One seed per candidate, drawn in order
+	seeds := make([]int64, len(candidates))
+	for i := range seeds {
+		seeds[i] = rng.Int63()
+	}
+
+	results := make([]*Result[T], len(candidates))
+	entries := make([]SearchEntry, len(candidates))
+	parallel(len(candidates), cfg.workerCount(), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			c := candidates[i]
+			runOpts := append(opts[:len(opts):len(opts)], WithInit(c.Init), WithWorkers(1))
+			if distance := space.Distances[c.Distance]; distance != nil {
+				runOpts = append(runOpts, WithDistance(distance))
+			}
+			runRng := rand.New(rand.NewSource(seeds[i]))
+			entries[i] = SearchEntry{Candidate: c, Score: math.Inf(-1)}
+			results[i], entries[i].Err = bestOf(dataset, c.K, c.Restarts, deltaThreshold, iterationThreshold, runRng, runOpts)
+			if entries[i].Err == nil {
+				entries[i].Score, entries[i].Err = validityScore(results[i], index, runRng, runOpts)
+			}
+			if entries[i].Err != nil {
+				entries[i].Score = math.Inf(-1)
+			}
+		}
+	})
+
+	// Rank candidates, keeping the grid order between ties
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if (entries[a].Err == nil) != (entries[b].Err == nil) {
+			if entries[a].Err == nil {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(entries[b].Score, entries[a].Score)
+	})
+	if err := entries[order[0]].Err; err != nil {
+		return nil, err
+	}
+	result := &SearchResult[T]{Best: results[order[0]], Leaderboard: make([]SearchEntry, len(order))}
+	for rank, i := range order {
+		result.Leaderboard[rank] = entries[i]
+	}
+	return result, nil
+}
+
+// bestOf runs ClusterResult restarts times and returns the result with the
+// lowest inertia.
+func bestOf[T Observation](dataset []T, k, restarts int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts []Option) (*Result[T], error) {
+	var best *Result[T]
+	for range restarts {
+		result, err := ClusterResult(dataset, k, deltaThreshold, iterationThreshold, rng, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil || result.Inertia < best.Inertia {
+			best = result
+		}
+	}
+	return best, nil
+}
+
+// validityScore returns the validity index of result, higher being better.
+func validityScore[T Observation](result *Result[T], index Index, rng *rand.Rand, opts []Option) (float64, error) {
+	switch index {
+	case IndexSilhouette:
+		s, _, err := Silhouette(result.Clusters, rng, opts...)
+		return s, err
+	case IndexCalinskiHarabasz:
+		return CalinskiHarabasz(result.Clusters)
+	case IndexDaviesBouldin:
+		s, err := DaviesBouldin(result.Clusters, result.Centroids, opts...)
+		return -s, err
+	case IndexDunn:
+		return Dunn(result.Clusters, rng, opts...)
+	case IndexBIC:
+		return BIC(result.Clusters, result.Centroids)
+	default:
+		return AIC(result.Clusters, result.Centroids)
+	}
+}
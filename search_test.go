@@ -0,0 +1,112 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+	space := SearchSpace{
+		Ks:        []int{1, 2, 3, 4, 5},
+		Inits:     []Init{InitRandom, InitKMeansPlusPlus},
+		Distances: map[string]DistanceFunc{"euclidean": EuclideanDistance, "manhattan": ManhattanDistance},
+		Restarts:  []int{1, 3},
+	}
+
+	result, err := Search(dataset, space, IndexSilhouette, 0, 0.001, 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Leaderboard) != 5*2*2*2 {
+		t.Fatalf("expected %d candidates, got %d", 5*2*2*2, len(result.Leaderboard))
+	}
+	best := result.Leaderboard[0]
+	if best.K != len(centers) || best.Err != nil {
+		t.Errorf("expected the best candidate to have %d clusters, got %+v", len(centers), best)
+	}
+	if len(result.Best.Centroids) != best.K {
+		t.Errorf("expected the best result to have %d centroids, got %d", best.K, len(result.Best.Centroids))
+	}
+	for i := 1; i < len(result.Leaderboard); i++ {
+		if result.Leaderboard[i].Score > result.Leaderboard[i-1].Score {
+			t.Fatalf("leaderboard is not sorted at rank %d", i)
+		}
+	}
+
+	// A single cluster cannot be scored with Silhouette
+	last := result.Leaderboard[len(result.Leaderboard)-1]
+	if last.K != 1 || last.Err == nil || !math.IsInf(last.Score, -1) {
+		t.Errorf("expected a failed single cluster candidate last, got %+v", last)
+	}
+
+	// Results do not depend on the number of workers
+	again, err := Search(dataset, space, IndexSilhouette, 0, 0.001, 100, rand.New(rand.NewSource(0)), WithWorkers(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range result.Leaderboard {
+		if again.Leaderboard[i].Candidate != result.Leaderboard[i].Candidate || again.Leaderboard[i].Score != result.Leaderboard[i].Score {
+			t.Fatalf("leaderboard differs between runs at rank %d", i)
+		}
+	}
+}
+
+func TestSearchSamples(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+	space := SearchSpace{Ks: []int{2, 3, 4, 5, 6, 7, 8}}
+
+	for index := range numIndices {
+		result, err := Search(dataset, space, index, 0, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if k := result.Leaderboard[0].K; k != len(centers) {
+			t.Errorf("expected index %d to prefer %d clusters, got %d", index, len(centers), k)
+		}
+	}
+
+	result, err := Search(dataset, space, IndexCalinskiHarabasz, 4, 0.001, 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Leaderboard) != 4 {
+		t.Errorf("expected 4 sampled candidates, got %d", len(result.Leaderboard))
+	}
+}
+
+func TestSearchValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	space := SearchSpace{Ks: []int{2}}
+	if _, err := Search(dataset, SearchSpace{}, IndexSilhouette, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for an empty search space")
+	}
+	if _, err := Search(dataset, SearchSpace{Ks: []int{7}}, IndexSilhouette, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Search(dataset, SearchSpace{Ks: []int{2}, Inits: []Init{-1}}, IndexSilhouette, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid init strategy")
+	}
+	if _, err := Search(dataset, SearchSpace{Ks: []int{2}, Distances: map[string]DistanceFunc{"none": nil}}, IndexSilhouette, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for nil distance")
+	}
+	if _, err := Search(dataset, SearchSpace{Ks: []int{2}, Restarts: []int{0}}, IndexSilhouette, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid number of restarts")
+	}
+	if _, err := Search(dataset, space, numIndices, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid index")
+	}
+	if _, err := Search(dataset, space, IndexSilhouette, -1, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid number of samples")
+	}
+	if _, err := Search(dataset, space, IndexSilhouette, 0, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Search(dataset, SearchSpace{Ks: []int{1}}, IndexSilhouette, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error when every candidate fails")
+	}
+}
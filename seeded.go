@@ -0,0 +1,167 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// SeededKMeans implements Basu et al.'s semi-supervised k-means. seeds holds,
+// for each observation in input order, the cluster it is known to belong to,
+// or -1 when unknown. Each initial centroid is the mean of its seeds, and
+// clusters without seeds are seeded by D² sampling from the observations, as
+// in k-means++. The run then iterates as in Cluster until no centroid moves
+// by deltaThreshold or more or iterationThreshold iterations ran. By default
+// seeds only guide the start and may change cluster; with WithFixedSeeds they
+// stay in their cluster throughout (constrained k-means).
+//
+// rng may be nil when every cluster has seeds. It honours WithDistance,
+// WithCenter, WithAlgorithm and WithWorkers, the algorithm being ignored with
+// WithFixedSeeds.
+func SeededKMeans[T Observation](dataset []T, k int, seeds []int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate seeds
+	if len(seeds) != len(dataset) {
+		return nil, fmt.Errorf("expected %d seeds, got %d", len(dataset), len(seeds))
+	}
+	seeded := make([]bool, k)
+	for _, j := range seeds {
+		if j < -1 || j >= k {
+			return nil, fmt.Errorf("invalid seed cluster: %d", j)
+		}
+		if j >= 0 {
+			seeded[j] = true
+		}
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate rng, which seeding clusters without seeds needs
+	if rng == nil && slices.Contains(seeded, false) {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate algorithm
+	if !cfg.algorithm.valid() || cfg.algorithm.stochastic() || cfg.algorithm == MacQueen {
+		return nil, fmt.Errorf("invalid algorithm: %d", cfg.algorithm)
+	}
+	if (cfg.algorithm.accelerated() || cfg.algorithm == HartiganWong) && !cfg.euclidean() {
+		return nil, fmt.Errorf("accelerated and Hartigan-Wong algorithms require the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initial centroids: centers of the seeds, then D² sampling
+	groups := make([][][]float64, k)
+	for i, j := range seeds {
+		if j >= 0 {
+			groups[j] = append(groups[j], points[i])
+		}
+	}
+	centroids := make([][]float64, k)
+	for j, group := range groups {
+		if len(group) > 0 {
+			centroids[j] = cfg.centerOf(group)
+		}
+	}
+	dists := make([]float64, len(points))
+	for j := range centroids {
+		if centroids[j] != nil {
+			continue
+		}
+		for i, p := range points {
+			dists[i] = math.Inf(1)
+			for _, centroid := range centroids {
+				if centroid != nil {
+					dists[i] = min(dists[i], squaredDistance(p, centroid))
+				}
+			}
+			if math.IsInf(dists[i], 1) {
+				dists[i] = 1
+			}
+		}
+		centroids[j] = slices.Clone(points[sampleIndex(dists, rng)])
+	}
+
+	labels := make([]int, len(points))
+	var iterations int
+	var converged bool
+	switch {
+	case cfg.fixedSeeds:
+		centroids, iterations, converged = fixedSeedsLoop(points, centroids, labels, seeds, deltaThreshold, iterationThreshold, cfg)
+	case cfg.algorithm == HartiganWong:
+		centroids, iterations, converged = hartiganWong(points, centroids, labels, deltaThreshold, iterationThreshold, cfg)
+	default:
+		centroids, iterations, converged = lloydLoop(points, centroids, labels, deltaThreshold, iterationThreshold, cfg)
+	}
+
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
+
+// fixedSeedsLoop runs Lloyd iterations from centroids in which every point
+// with a seed other than -1 stays in the cluster of its seed, until no
+// centroid moves by deltaThreshold or more or iterationThreshold iterations
+// ran. It fills labels with the last assignment and returns the final
+// centroids, the number of iterations and whether the run converged.
+func fixedSeedsLoop(points, centroids [][]float64, labels, seeds []int, deltaThreshold float64, iterationThreshold int, cfg *config) ([][]float64, int, bool) {
+	distance := cfg.distanceFunc()
+	iterations := 0
+	for range iterationThreshold {
+		iterations++
+
+		// Assignment step: only unseeded points move
+		stats := shardStats(len(points), len(centroids), len(points[0]), cfg.workerCount(), func(start, end int, partial *Stats) {
+			for i := start; i < end; i++ {
+				if labels[i] = seeds[i]; labels[i] == -1 {
+					labels[i], _ = nearest(points[i], centroids, distance)
+				}
+				partial.add(points[i], labels[i])
+			}
+		})
+
+		// Update step: calculate new centroids
+		newCentroids := update(points, labels, centroids, stats, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			return centroids, iterations, true
+		}
+	}
+	return centroids, iterations, false
+}
@@ -0,0 +1,74 @@
+package kmeans
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSeededKMeans(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13, 21, 22, 23}
+	seeds := []int{2, -1, -1, -1, -1, 0, 1, -1, -1}
+
+	// Seeds choose the initial centroids and name the clusters, then move
+	result, err := SeededKMeans(dataset, 3, seeds, 0.01, 100, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(result.Labels, []int{2, 2, 2, 0, 0, 0, 1, 1, 1}) {
+		t.Errorf("unexpected labels %v", result.Labels)
+	}
+
+	// Fixed seeds stay in their cluster
+	seeds[5] = 2
+	seeds[3] = 0
+	result, err = SeededKMeans(dataset, 3, seeds, 0.01, 100, nil, WithFixedSeeds())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Labels[5] != 2 || result.Labels[4] != 0 || !result.Converged {
+		t.Errorf("unexpected labels %v", result.Labels)
+	}
+
+	// Clusters without seeds are seeded at random
+	result, err = SeededKMeans(dataset, 3, []int{0, -1, -1, -1, -1, -1, -1, -1, -1}, 0.01, 100, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Labels[0] != 0 || len(result.Clusters) != 3 {
+		t.Errorf("unexpected labels %v", result.Labels)
+	}
+}
+
+func TestSeededKMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	seeds := []int{0, -1, -1, 1, -1, -1}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := SeededKMeans([]Numbers{}, 2, nil, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := SeededKMeans(dataset, 7, seeds, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := SeededKMeans(dataset, 2, seeds[:5], 0.01, 10, rng); err == nil {
+		t.Error("expected error for missing seeds")
+	}
+	if _, err := SeededKMeans(dataset, 2, []int{0, 2, -1, 1, -1, -1}, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid seed cluster")
+	}
+	if _, err := SeededKMeans(dataset, 2, seeds, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := SeededKMeans(dataset, 2, seeds, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := SeededKMeans(dataset, 3, seeds, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator with unseeded clusters")
+	}
+	if _, err := SeededKMeans(dataset, 2, seeds, 0.01, 10, rng, WithAlgorithm(MiniBatch)); err == nil {
+		t.Error("expected error for stochastic algorithm")
+	}
+	if _, err := SeededKMeans(dataset, 2, seeds, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
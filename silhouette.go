@@ -0,0 +1,128 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Silhouette returns the mean silhouette coefficient of clusters and the
+// coefficient of every observation, values[j][i] being that of clusters[j][i].
+// The coefficient of an observation is (b-a)/max(a, b), where a is its mean
+// distance to the other observations of its cluster and b its mean distance
+// to the observations of the nearest other cluster. It ranges from -1 to 1,
+// higher when clusters are compact and well separated, and is 0 for an
+// observation alone in its cluster. Empty clusters are ignored and at least
+// two clusters must remain.
+//
+// Every pair of observations is compared with the configured distance, so
+// time grows with the square of the number of observations. With
+// WithSampleSize, the coefficients of a random sample of that many
+// observations are computed against the sample only, estimating the mean in
+// time quadratic in the sample size; the values of other observations are
+// NaN. rng may be nil when not sampling. It honours WithWorkers.
+func Silhouette[T Observation](clusters [][]T, rng *rand.Rand, opts ...Option) (float64, [][]float64, error) {
+	cfg := newConfig(opts)
+	points, labels, err := flattenClusters(clusters)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Validate sample size
+	if cfg.sampleSize < 0 {
+		return 0, nil, fmt.Errorf("invalid sample size: %d", cfg.sampleSize)
+	}
+	sampled := make([]int, len(points))
+	for i := range sampled {
+		sampled[i] = i
+	}
+	if cfg.sampleSize > 0 && cfg.sampleSize < len(points) {
+		if rng == nil {
+			return 0, nil, fmt.Errorf("random number generator is nil")
+		}
+		sampled = randomIndices(len(points), cfg.sampleSize, rng)
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return 0, nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	distance := cfg.distanceFunc()
+	coefficients := make([]float64, len(sampled))
+	parallel(len(sampled), cfg.workerCount(), func(_, start, end int) {
+		sums := make([]float64, len(clusters))
+		counts := make([]float64, len(clusters))
+		for s := start; s < end; s++ {
+			i := sampled[s]
+			clear(sums)
+			clear(counts)
+			for _, o := range sampled {
+				if o != i {
+					sums[labels[o]] += distance(points[i], points[o])
+					counts[labels[o]]++
+				}
+			}
+			own := labels[i]
+			if counts[own] == 0 {
+				continue
+			}
+			a, b := sums[own]/counts[own], math.Inf(1)
+			for j := range sums {
+				if j != own && counts[j] > 0 {
+					b = min(b, sums[j]/counts[j])
+				}
+			}
+			if math.IsInf(b, 1) {
+				continue
+			}
+			if m := max(a, b); m > 0 {
+				coefficients[s] = (b - a) / m
+			}
+		}
+	})
+
+	// Report the coefficients in the layout of clusters
+	values := make([][]float64, len(clusters))
+	flat := make([]float64, len(points))
+	for i := range flat {
+		flat[i] = math.NaN()
+	}
+	score := 0.0
+	for s, i := range sampled {
+		flat[i] = coefficients[s]
+		score += coefficients[s]
+	}
+	start := 0
+	for j, cluster := range clusters {
+		values[j] = flat[start : start+len(cluster) : start+len(cluster)]
+		start += len(cluster)
+	}
+	return score / float64(len(sampled)), values, nil
+}
+
+// flattenClusters returns the coordinates of the observations of clusters,
+// cluster after cluster, and the index of the cluster of each. It fails
+// unless at least two clusters are non-empty and all observations have the
+// same dimension.
+func flattenClusters[T Observation](clusters [][]T) ([][]float64, []int, error) {
+	var dataset []T
+	var labels []int
+	nonEmpty := 0
+	for j, cluster := range clusters {
+		dataset = append(dataset, cluster...)
+		labels = append(labels, slices.Repeat([]int{j}, len(cluster))...)
+		if len(cluster) > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty < 2 {
+		return nil, nil, fmt.Errorf("at least 2 non-empty clusters are required, got %d", nonEmpty)
+	}
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return points, labels, nil
+}
@@ -0,0 +1,82 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSilhouette(t *testing.T) {
+	score, values, err := Silhouette([][]Numbers{{1, 2}, {}, {5}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]float64{{0.75, 2.0 / 3}, {}, {0}}
+	for j := range expected {
+		for i := range expected[j] {
+			if math.Abs(values[j][i]-expected[j][i]) > 1e-12 {
+				t.Errorf("expected value %v for observation %d of cluster %d, got %v", expected[j][i], i, j, values[j][i])
+			}
+		}
+	}
+	if want := (0.75 + 2.0/3) / 3; math.Abs(score-want) > 1e-12 {
+		t.Errorf("expected score %v, got %v", want, score)
+	}
+}
+
+func TestSilhouetteSample(t *testing.T) {
+	dataset := gaussians(rand.New(rand.NewSource(0)), []Vector{{0, 0}, {10, 0}, {0, 10}}, 300, 3)
+	result, err := ClusterResult(dataset, 3, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	score, _, err := Silhouette(result.Clusters, nil, WithWorkers(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A sample estimates the score, leaving other values unknown
+	estimate, values, err := Silhouette(result.Clusters, rand.New(rand.NewSource(0)), WithSampleSize(300))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(estimate-score) > 0.05 {
+		t.Errorf("expected estimate near %v, got %v", score, estimate)
+	}
+	unknown := 0
+	for _, cluster := range values {
+		for _, v := range cluster {
+			if math.IsNaN(v) {
+				unknown++
+			}
+		}
+	}
+	if unknown != 600 {
+		t.Errorf("expected 600 unknown values, got %d", unknown)
+	}
+
+	// A worse clustering scores lower
+	shuffled := [][]Vector{append(result.Clusters[0][:100:100], result.Clusters[1][100:]...), append(result.Clusters[1][:100:100], result.Clusters[0][100:]...), result.Clusters[2]}
+	worse, _, err := Silhouette(shuffled, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if worse >= score {
+		t.Errorf("expected a mixed clustering to score below %v, got %v", score, worse)
+	}
+}
+
+func TestSilhouetteValidation(t *testing.T) {
+	if _, _, err := Silhouette([][]Numbers{{1, 2}, {}}, nil); err == nil {
+		t.Error("expected error for a single cluster")
+	}
+	if _, _, err := Silhouette([][]Vector{{{1, 2}}, {{3}}}, nil); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+	if _, _, err := Silhouette([][]Numbers{{1, 2}, {5, 6}}, nil, WithSampleSize(-1)); err == nil {
+		t.Error("expected error for invalid sample size")
+	}
+	if _, _, err := Silhouette([][]Numbers{{1, 2}, {5, 6}}, nil, WithSampleSize(3)); err == nil {
+		t.Error("expected error for nil random number generator when sampling")
+	}
+}
@@ -0,0 +1,120 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// SizeConstrained implements constrained k-means: every cluster holds
+// between minSize and maxSize observations, so that no cluster is too small
+// to act on or too large to handle. Each iteration assigns the observations
+// to the centroids with the least total loss under the size constraints,
+// solving a min-cost flow as proposed by Bradley, Bennett and Demiriz, then
+// moves every centroid to the center of its cluster. It iterates until no
+// centroid moves by deltaThreshold or more or iterationThreshold iterations
+// ran.
+//
+// The sizes must allow a partition: k·minSize observations at most and
+// k·maxSize at least. Initial centroids are chosen as in Cluster. An
+// iteration takes O(n²k) time, which suits datasets of up to a few thousand
+// observations. It honours WithDistance and WithCenter.
+func SizeConstrained[T Observation](dataset []T, k, minSize, maxSize int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate minSize and maxSize
+	if minSize < 0 || k*minSize > len(dataset) {
+		return nil, fmt.Errorf("invalid minimum cluster size: %d", minSize)
+	}
+	if maxSize < max(minSize, 1) || k*maxSize < len(dataset) {
+		return nil, fmt.Errorf("invalid maximum cluster size: %d", maxSize)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCentroids(cfg.centroids, k, len(points[0])); err != nil {
+		return nil, err
+	}
+
+	lower, upper := make([]int, k), make([]int, k)
+	for j := range k {
+		lower[j], upper[j] = minSize, maxSize
+	}
+	labels := make([]int, len(points))
+	centroids, iterations, converged := sizeConstrainedLoop(points, initCentroids(points, k, cfg, rng), labels, lower, upper, deltaThreshold, iterationThreshold, cfg)
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = iterations
+	result.Converged = converged
+	return result, nil
+}
+
+// sizeConstrainedLoop alternates assignment steps respecting cluster sizes
+// between lower[j] and upper[j] and update steps, starting from centroids,
+// until no centroid moves by deltaThreshold or more or iterationThreshold
+// iterations ran. It fills labels with the last assignment and returns the
+// final centroids, the number of iterations and whether the run converged.
+func sizeConstrainedLoop(points, centroids [][]float64, labels []int, lower, upper []int, deltaThreshold float64, iterationThreshold int, cfg *config) ([][]float64, int, bool) {
+	loss := cfg.lossFunc()
+	costs := newMatrix(len(points), len(centroids))
+	assign := func() *Stats {
+		for i, p := range points {
+			for j, centroid := range centroids {
+				costs[i][j] = loss(p, centroid)
+			}
+		}
+		copy(labels, transportAssign(costs, lower, upper))
+		stats := NewStats(len(centroids), len(points[0]))
+		for i, j := range labels {
+			stats.add(points[i], j)
+		}
+		return stats
+	}
+
+	iterations := 0
+	for range iterationThreshold {
+		iterations++
+		newCentroids := update(points, labels, centroids, assign(), cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			return centroids, iterations, true
+		}
+	}
+	return centroids, iterations, false
+}
@@ -0,0 +1,68 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSizeConstrained(t *testing.T) {
+	// A lone observation far from the rest forms a cluster of 1 without
+	// constraints
+	rng := rand.New(rand.NewSource(0))
+	dataset := append(gaussians(rng, []Vector{{0, 0}, {30, 0}}, 40, 3), Vector{200, 200})
+	plain, err := ClusterResult(dataset, 3, 0.001, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size := len(plain.Clusters[plain.Labels[80]]); size != 1 {
+		t.Fatalf("expected a cluster of 1 without constraints, got %d", size)
+	}
+
+	result, err := SizeConstrained(dataset, 3, 10, 40, 0.001, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Converged {
+		t.Error("expected convergence")
+	}
+	for j, cluster := range result.Clusters {
+		if len(cluster) < 10 || len(cluster) > 40 {
+			t.Errorf("expected between 10 and 40 observations in cluster %d, got %d", j, len(cluster))
+		}
+	}
+	if result.Inertia < plain.Inertia {
+		t.Errorf("expected constraints to cost inertia, got %f below %f", result.Inertia, plain.Inertia)
+	}
+}
+
+func TestSizeConstrainedValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := SizeConstrained([]Numbers{}, 2, 1, 3, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := SizeConstrained(dataset, 7, 0, 3, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := SizeConstrained(dataset, 2, 4, 6, 0.01, 10, rng); err == nil {
+		t.Error("expected error for minimum size above n/k")
+	}
+	if _, err := SizeConstrained(dataset, 2, 1, 2, 0.01, 10, rng); err == nil {
+		t.Error("expected error for maximum size below n/k")
+	}
+	if _, err := SizeConstrained(dataset, 2, 3, 2, 0.01, 10, rng); err == nil {
+		t.Error("expected error for maximum size below minimum size")
+	}
+	if _, err := SizeConstrained(dataset, 2, 1, 3, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := SizeConstrained(dataset, 2, 1, 3, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := SizeConstrained(dataset, 2, 1, 3, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := SizeConstrained(dataset, 2, 1, 3, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
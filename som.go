@@ -0,0 +1,161 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Topology selects the arrangement of the units of a self-organizing map.
+type Topology int
+
+const (
+	// RectangularGrid places units on a square lattice, where inner units have
+	// four direct neighbours.
+	RectangularGrid Topology = iota
+	// HexagonalGrid shifts every other row by half a unit, so inner units
+	// have six equidistant neighbours and the map shows fewer artefacts along
+	// the axes.
+	HexagonalGrid
+
+	// numTopologies is the number of supported topologies.
+	numTopologies
+)
+
+// valid reports whether t is a supported topology.
+func (t Topology) valid() bool {
+	return t >= 0 && t < numTopologies
+}
+
+// SOM is a trained self-organizing map: a grid of units, each with a weight
+// vector in the space of the observations, such that neighbouring units have
+// similar weights. Observations are mapped to their best-matching unit, the
+// unit with the nearest weights, which makes the map a k-means with its
+// centroids constrained to a two-dimensional grid.
+type SOM[T Observation] struct {
+	// Rows and Cols are the dimensions of the grid.
+	Rows, Cols int
+	// Topology is the arrangement of the units.
+	Topology Topology
+	// Units holds the weights of the units, row by row: the unit at row r and
+	// column c is Units[r*Cols+c].
+	Units [][]float64
+}
+
+// TrainSOM trains a self-organizing map of rows × cols units over the
+// dataset. The units start from random observations, then every observation,
+// in random order during each of iterationThreshold passes, pulls its
+// best-matching unit and the units around it on the grid towards it. The pull
+// decreases with the grid distance to the best-matching unit as a Gaussian of
+// width the neighbourhood radius, and both the radius and the learning rate
+// decay exponentially during training: the radius from its initial value to
+// 1 and the learning rate from 0.5 to 0.01.
+//
+// The initial radius is set with WithRadius (default half the largest grid
+// dimension) and the grid with WithTopology (default RectangularGrid). It
+// requires the Euclidean distance and mean centroids.
+func TrainSOM[T Observation](dataset []T, rows, cols, iterationThreshold int, rng *rand.Rand, opts ...Option) (*SOM[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate the grid
+	if rows <= 0 || cols <= 0 {
+		return nil, fmt.Errorf("invalid grid: %d × %d", rows, cols)
+	}
+	if !cfg.topology.valid() {
+		return nil, fmt.Errorf("invalid topology: %d", cfg.topology)
+	}
+	if cfg.radius < 0 {
+		return nil, fmt.Errorf("invalid radius: %f", cfg.radius)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate distance, units move towards observations
+	if !cfg.euclidean() {
+		return nil, fmt.Errorf("self-organizing maps require the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SOM[T]{
+		Rows:     rows,
+		Cols:     cols,
+		Topology: cfg.topology,
+		Units:    newMatrix(rows*cols, len(points[0])),
+	}
+	for _, unit := range s.Units {
+		copy(unit, points[rng.Intn(len(points))])
+	}
+
+	// Exponential decay of the radius and of the learning rate
+	radius := cfg.radius
+	if radius == 0 {
+		radius = max(1, float64(max(rows, cols))/2)
+	}
+	steps := float64(iterationThreshold * len(points))
+
+	step := 0.0
+	for range iterationThreshold {
+		for _, i := range rng.Perm(len(points)) {
+			sigma, rate := anneal(radius, min(1, radius), step/steps), anneal(0.5, 0.01, step/steps)
+			step++
+
+			best, _ := nearest(points[i], s.Units, EuclideanDistance)
+			for u, unit := range s.Units {
+				g := s.gridDistance(best, u)
+				h := rate * math.Exp(-g*g/(2*sigma*sigma))
+				if h < 1e-9 {
+					continue
+				}
+				for d := range unit {
+					unit[d] += h * (points[i][d] - unit[d])
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+// BestMatchingUnit returns the index in Units of the unit whose weights are
+// nearest to obs.
+func (s *SOM[T]) BestMatchingUnit(obs T) (int, error) {
+	point := obs.Coordinates()
+	if len(point) != len(s.Units[0]) {
+		return 0, fmt.Errorf("inconsistent dimensions")
+	}
+	u, _ := nearest(point, s.Units, EuclideanDistance)
+	return u, nil
+}
+
+// Position returns the row and the column of unit on the grid.
+func (s *SOM[T]) Position(unit int) (int, int) {
+	return unit / s.Cols, unit % s.Cols
+}
+
+// gridDistance returns the distance between units a and b on the grid, one
+// between direct neighbours.
+func (s *SOM[T]) gridDistance(a, b int) float64 {
+	ra, ca := s.Position(a)
+	rb, cb := s.Position(b)
+	if s.Topology == RectangularGrid {
+		return math.Hypot(float64(ra-rb), float64(ca-cb))
+	}
+	xa, xb := float64(ca)+0.5*float64(ra%2), float64(cb)+0.5*float64(rb%2)
+	return math.Hypot(xa-xb, float64(ra-rb)*math.Sqrt(3)/2)
+}
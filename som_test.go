@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTrainSOM(t *testing.T) {
+	// A line of units over observations spread along a segment
+	rng := rand.New(rand.NewSource(0))
+	dataset := make([]Numbers, 500)
+	for i := range dataset {
+		dataset[i] = Numbers(rng.Float64() * 100)
+	}
+
+	som, err := TrainSOM(dataset, 1, 10, 20, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Neighbouring units have ordered weights covering the segment
+	increasing := som.Units[1][0] > som.Units[0][0]
+	for u := 1; u < len(som.Units); u++ {
+		if (som.Units[u][0] > som.Units[u-1][0]) != increasing {
+			t.Fatalf("expected ordered units, got %v", som.Units)
+		}
+	}
+	first, last := som.Units[0][0], som.Units[9][0]
+	if math.Min(first, last) > 15 || math.Max(first, last) < 85 {
+		t.Errorf("expected units covering the segment, got %v", som.Units)
+	}
+
+	u, err := som.BestMatchingUnit(Numbers(first))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row, col := som.Position(u); u != 0 || row != 0 || col != 0 {
+		t.Errorf("expected the first unit, got %d at (%d, %d)", u, row, col)
+	}
+}
+
+func TestTrainSOMHexagonal(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 2)
+
+	som, err := TrainSOM(dataset, 4, 4, 20, rand.New(rand.NewSource(1)), WithTopology(HexagonalGrid))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	units := map[int]bool{}
+	for _, center := range centers {
+		u, err := som.BestMatchingUnit(center)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		units[u] = true
+	}
+	if len(units) != len(centers) {
+		t.Errorf("expected distinct units for the blobs, got %v", units)
+	}
+	if _, err := som.BestMatchingUnit(Vector{1}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+
+	// Every unit of a hexagonal grid is one away from its six neighbours
+	for _, neighbor := range []int{4, 6, 1, 2, 9, 10} {
+		if d := som.gridDistance(5, neighbor); math.Abs(d-1) > 1e-12 {
+			t.Errorf("expected distance 1 to unit %d, got %v", neighbor, d)
+		}
+	}
+}
+
+func TestTrainSOMValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := TrainSOM(dataset, 0, 3, 10, rng); err == nil {
+		t.Error("expected error for invalid grid")
+	}
+	if _, err := TrainSOM(dataset, 2, 3, 10, rng, WithTopology(Topology(-1))); err == nil {
+		t.Error("expected error for invalid topology")
+	}
+	if _, err := TrainSOM(dataset, 2, 3, 10, rng, WithRadius(-1)); err == nil {
+		t.Error("expected error for invalid radius")
+	}
+	if _, err := TrainSOM(dataset, 2, 3, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := TrainSOM(dataset, 2, 3, 10, rng, WithSpherical()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+}
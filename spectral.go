@@ -0,0 +1,154 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Affinity builds the affinity matrix of points: a symmetric matrix of
+// non-negative similarities between every pair of points.
+type Affinity func(points [][]float64) [][]float64
+
+// RBFAffinity returns an Affinity relating every pair of points by the
+// Gaussian radial basis function exp(-gamma‖a-b‖²). It panics if gamma is not
+// positive.
+func RBFAffinity(gamma float64) Affinity {
+	kernel := RBFKernel(gamma)
+	return func(points [][]float64) [][]float64 {
+		n := len(points)
+		affinity := newMatrix(n, n)
+		for i := range n {
+			for j := range i {
+				affinity[i][j] = kernel(points[i], points[j])
+				affinity[j][i] = affinity[i][j]
+			}
+		}
+		return affinity
+	}
+}
+
+// NearestNeighborsAffinity returns an Affinity connecting, with an affinity of
+// 1, every point to its given number of nearest neighbours and those points
+// of which it is a nearest neighbour. Points are otherwise unrelated, which
+// keeps clusters of very different densities apart. It panics if neighbors is
+// not positive.
+func NearestNeighborsAffinity(neighbors int) Affinity {
+	if neighbors <= 0 {
+		panic("invalid number of neighbors")
+	}
+	return func(points [][]float64) [][]float64 {
+		n := len(points)
+		affinity := newMatrix(n, n)
+		dists := make([]float64, n)
+		order := make([]int, n)
+		for i := range n {
+			for j := range n {
+				dists[j] = squaredDistance(points[i], points[j])
+				order[j] = j
+			}
+			dists[i] = math.Inf(1)
+			slices.SortFunc(order, func(a, b int) int {
+				return cmp.Compare(dists[a], dists[b])
+			})
+			for _, j := range order[:min(neighbors, n-1)] {
+				affinity[i][j] = 1
+				affinity[j][i] = 1
+			}
+		}
+		return affinity
+	}
+}
+
+// spectralRow is an observation embedded by Spectral.
+type spectralRow []float64
+
+// Coordinates returns the embedded coordinates.
+func (r spectralRow) Coordinates() []float64 {
+	return r
+}
+
+// Spectral implements spectral clustering as described by Ng, Jordan and
+// Weiss. It builds the affinity matrix A of the observations with affinity,
+// and embeds each observation as its row of the k leading eigenvectors of the
+// normalised affinity D^-1/2 A D^-1/2, where D holds the degrees of the
+// observations, scaled to unit length. The embedded rows are then clustered
+// with k-means, which separates clusters that are connected but not convex,
+// such as rings or moons.
+//
+// The affinity matrix and its eigenvectors are computed for the whole
+// dataset, so memory grows with the square and time with the cube of the
+// number of observations. The final k-means run takes the same options as
+// Cluster and stops as Cluster does. The result's Inertia is that of the
+// embedded rows and its Centroids are the input-space means of the clusters,
+// for reference only.
+func Spectral[T Observation](dataset []T, k int, affinity Affinity, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	// Validate affinity
+	if affinity == nil {
+		return nil, fmt.Errorf("affinity is nil")
+	}
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	// Normalised affinity matrix
+	a := affinity(points)
+	scales := make([]float64, n)
+	for i := range n {
+		degree := 0.0
+		for j := range n {
+			degree += a[i][j]
+		}
+		if degree > 0 {
+			scales[i] = 1 / math.Sqrt(degree)
+		}
+	}
+	normalised := newMatrix(n, n)
+	for i := range n {
+		for j := range n {
+			normalised[i][j] = scales[i] * a[i][j] * scales[j]
+		}
+	}
+
+	// Embed each observation as its row of the k leading eigenvectors
+	embedding := make([]spectralRow, n)
+	if k > 0 && k <= n {
+		_, vectors := symmetricEigen(normalised)
+		for i := range embedding {
+			embedding[i] = make(spectralRow, k)
+			for j := range k {
+				embedding[i][j] = vectors[j][i]
+			}
+			normalize(embedding[i])
+		}
+	}
+
+	embedded, err := ClusterResult(embedding, k, deltaThreshold, iterationThreshold, rng, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Input-space means of the clusters, for reference
+	stats := NewStats(k, len(points[0]))
+	for i, j := range embedded.Labels {
+		stats.add(points[i], j)
+	}
+	centroids := newMatrix(k, len(points[0]))
+	centroids = update(nil, nil, centroids, stats, &config{})
+
+	result := newResult(dataset, points, centroids, embedded.Labels, nil, EuclideanDistance)
+	result.Inertia = embedded.Inertia
+	result.Iterations = embedded.Iterations
+	result.Converged = embedded.Converged
+	return result, nil
+}
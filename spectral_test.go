@@ -0,0 +1,69 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// rings returns n observations on each of two concentric circles.
+func rings(n int) []Vector {
+	var dataset []Vector
+	for _, radius := range []float64{1, 5} {
+		for i := range n {
+			angle := 2 * math.Pi * float64(i) / float64(n)
+			dataset = append(dataset, Vector{radius * math.Cos(angle), radius * math.Sin(angle)})
+		}
+	}
+	return dataset
+}
+
+func TestSpectral(t *testing.T) {
+	for name, affinity := range map[string]Affinity{
+		"rbf":       RBFAffinity(2),
+		"neighbors": NearestNeighborsAffinity(5),
+	} {
+		t.Run(name, func(t *testing.T) {
+			dataset := rings(50)
+			result, err := Spectral(dataset, 2, affinity, 1e-6, 100, rand.New(rand.NewSource(0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, label := range result.Labels {
+				if label != result.Labels[i/50*50] {
+					t.Fatalf("observation %v not with its ring: %v", dataset[i], result.Labels)
+				}
+			}
+			if result.Labels[0] == result.Labels[50] {
+				t.Errorf("expected the two rings in different clusters, got %v", result.Labels)
+			}
+		})
+	}
+}
+
+func TestNearestNeighborsAffinity(t *testing.T) {
+	points := [][]float64{{0}, {1}, {5}, {6}}
+	affinity := NearestNeighborsAffinity(1)(points)
+	expected := [][]float64{{0, 1, 0, 0}, {1, 0, 0, 0}, {0, 0, 0, 1}, {0, 0, 1, 0}}
+	for i := range expected {
+		for j := range expected[i] {
+			if affinity[i][j] != expected[i][j] {
+				t.Fatalf("expected affinity %v, got %v", expected, affinity)
+			}
+		}
+	}
+}
+
+func TestSpectralValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Spectral(dataset, 2, nil, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for nil affinity")
+	}
+	if _, err := Spectral(dataset, 0, RBFAffinity(1), 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Spectral([]Numbers{}, 2, RBFAffinity(1), 1e-6, 100, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+}
@@ -0,0 +1,160 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// dissolutionThreshold is the Jaccard similarity at or below which
+// Bootstrap deems a cluster dissolved in a resample, as recommended by
+// Hennig.
+const dissolutionThreshold = 0.5
+
+// StabilityResult holds the outcome of Bootstrap.
+type StabilityResult[T Observation] struct {
+	// Result describes the clustering of the whole dataset.
+	Result[T]
+	// Stability holds the mean Jaccard similarity of each cluster to its
+	// most similar cluster in the resamples, between 0 and 1. Clusters above
+	// 0.75 are commonly deemed stable and those at or below 0.5 spurious. It
+	// is NaN for a cluster that no resample drew.
+	Stability []float64
+	// Dissolved holds the number of resamples in which each cluster was
+	// dissolved, its Jaccard similarity being 0.5 or less.
+	Dissolved []int
+}
+
+// Bootstrap assesses the stability of each cluster as described by Hennig.
+// It clusters the dataset with ClusterResult, then clusters resamples
+// bootstrap resamples of it, drawn with replacement, and compares every
+// cluster, restricted to the observations drawn, with its most similar
+// cluster of the resample by the Jaccard similarity of their observations.
+// Clusters that match real structure are found again in most resamples
+// while spurious ones dissolve.
+//
+// The resamples and the seeds of their runs are drawn from rng beforehand,
+// so results do not depend on the number of WithWorkers goroutines the runs
+// are spread over, each run using one. Other options are passed to every
+// run.
+func Bootstrap[T Observation](dataset []T, k, resamples int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*StabilityResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate resamples
+	if resamples <= 0 {
+		return nil, fmt.Errorf("invalid number of resamples: %d", resamples)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	result, err := ClusterResult(dataset, k, deltaThreshold, iterationThreshold, rng, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resamples and seeds drawn in order
+	draws := make([][]int, resamples)
+	seeds := make([]int64, resamples)
+	for b := range draws {
+		draws[b] = make([]int, len(dataset))
+		for i := range draws[b] {
+			draws[b][i] = rng.Intn(len(dataset))
+		}
+		seeds[b] = rng.Int63()
+	}
+
+	// Jaccard similarity of every cluster in every resample, NaN when the
+	// resample drew none of its observations
+	similarities := make([][]float64, resamples)
+	errs := make([]error, resamples)
+	runOpts := append(opts[:len(opts):len(opts)], WithWorkers(1))
+	parallel(resamples, cfg.workerCount(), func(_, start, end int) {
+		for b := start; b < end; b++ {
+			resample := make([]T, len(dataset))
+			for i, o := range draws[b] {
+				resample[i] = dataset[o]
+			}
+			run, err := ClusterResult(resample, k, deltaThreshold, iterationThreshold, rand.New(rand.NewSource(seeds[b])), runOpts...)
+			if err != nil {
+				errs[b] = err
+				continue
+			}
+
+			// Cluster of every observation drawn, duplicates sharing the
+			// label of their first draw
+			drawn := make([]int, len(dataset))
+			for i := range drawn {
+				drawn[i] = -1
+			}
+			for i, o := range draws[b] {
+				if drawn[o] < 0 {
+					drawn[o] = run.Labels[i]
+				}
+			}
+			similarities[b] = jaccardMatch(result.Labels, drawn, k)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stability := &StabilityResult[T]{Result: *result, Stability: make([]float64, k), Dissolved: make([]int, k)}
+	for j := range k {
+		sum, count := 0.0, 0
+		for _, s := range similarities {
+			if math.IsNaN(s[j]) {
+				continue
+			}
+			sum += s[j]
+			count++
+			if s[j] <= dissolutionThreshold {
+				stability.Dissolved[j]++
+			}
+		}
+		stability.Stability[j] = sum / float64(count)
+	}
+	return stability, nil
+}
+
+// jaccardMatch returns, for every cluster of labels restricted to the
+// observations with a label in drawn, -1 marking the others, the largest
+// Jaccard similarity with a cluster of drawn, or NaN when none of its
+// observations was drawn.
+func jaccardMatch(labels, drawn []int, k int) []float64 {
+	// Sizes of the restricted clusters and of their intersections
+	sizes, drawnSizes := make([]float64, k), make([]float64, k)
+	intersections := make([][]float64, k)
+	for j := range intersections {
+		intersections[j] = make([]float64, k)
+	}
+	for i, m := range drawn {
+		if m < 0 {
+			continue
+		}
+		sizes[labels[i]]++
+		drawnSizes[m]++
+		intersections[labels[i]][m]++
+	}
+
+	similarities := make([]float64, k)
+	for j := range k {
+		if sizes[j] == 0 {
+			similarities[j] = math.NaN()
+			continue
+		}
+		for m, inter := range intersections[j] {
+			similarities[j] = max(similarities[j], inter/(sizes[j]+drawnSizes[m]-inter))
+		}
+	}
+	return similarities
+}
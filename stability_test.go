@@ -0,0 +1,81 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBootstrap(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {25, 40}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 40, 3)
+
+	result, err := Bootstrap(dataset, 3, 20, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != 3 {
+		t.Fatalf("expected 3 centroids, got %d", len(result.Centroids))
+	}
+	for j, s := range result.Stability {
+		if s < 0.95 || result.Dissolved[j] != 0 {
+			t.Errorf("expected cluster %d to be stable, got stability %f dissolved %d times", j, s, result.Dissolved[j])
+		}
+	}
+
+	// Splitting a round cluster in two is arbitrary, so the halves dissolve
+	// in some resamples
+	result, err = Bootstrap(dataset, 4, 20, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unstable, dissolved := 0, 0
+	for j, s := range result.Stability {
+		if s < 0.75 {
+			unstable++
+		}
+		dissolved += result.Dissolved[j]
+	}
+	if unstable == 0 || dissolved == 0 {
+		t.Errorf("expected unstable clusters, got stabilities %v dissolved %v times", result.Stability, result.Dissolved)
+	}
+
+	// Results do not depend on the number of workers
+	again, err := Bootstrap(dataset, 4, 20, 0.001, 100, rand.New(rand.NewSource(0)), WithInit(InitKMeansPlusPlus), WithWorkers(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for j := range result.Stability {
+		if again.Stability[j] != result.Stability[j] {
+			t.Fatalf("stability of cluster %d differs between runs", j)
+		}
+	}
+}
+
+func TestJaccardMatch(t *testing.T) {
+	// Cluster 0 drawn as {0, 1} best matches {0, 1, 2}, cluster 1 drawn as
+	// {2, 3} best matches {3}, cluster 2 is not drawn
+	labels := []int{0, 0, 1, 1, 2}
+	drawn := []int{0, 0, 0, 1, -1}
+	similarities := jaccardMatch(labels, drawn, 3)
+	if math.Abs(similarities[0]-2.0/3) > 1e-12 || similarities[1] != 0.5 || !math.IsNaN(similarities[2]) {
+		t.Errorf("expected [2/3 1/2 NaN], got %v", similarities)
+	}
+}
+
+func TestBootstrapValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := Bootstrap(dataset, 2, 0, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid number of resamples")
+	}
+	if _, err := Bootstrap(dataset, 2, 5, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := Bootstrap(dataset, 7, 5, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := Bootstrap(dataset, 2, 5, 0.01, 10, rng, WithWorkers(-1)); err == nil {
+		t.Error("expected error for invalid number of workers")
+	}
+}
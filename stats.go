@@ -0,0 +1,68 @@
+package kmeans
+
+import "fmt"
+
+// Stats holds the sufficient statistics of a k-means iteration: the sum and
+// count of the observations assigned to each cluster. Statistics computed
+// over separate shards of a dataset, for instance on several machines, can be
+// merged into the statistics of the whole dataset.
+type Stats struct {
+	// Sums holds the sum of the observations of each cluster.
+	Sums [][]float64
+	// Counts holds the number of observations of each cluster, or their total
+	// weight for weighted observations.
+	Counts []float64
+}
+
+// NewStats returns empty statistics for k clusters of dimension dim.
+func NewStats(k, dim int) *Stats {
+	s := &Stats{
+		Sums:   make([][]float64, k),
+		Counts: make([]float64, k),
+	}
+	for j := range s.Sums {
+		s.Sums[j] = make([]float64, dim)
+	}
+	return s
+}
+
+// Merge adds the statistics of other to s. It fails if they do not have the
+// same number of clusters and dimension.
+func (s *Stats) Merge(other *Stats) error {
+	if len(other.Sums) != len(s.Sums) || len(other.Counts) != len(s.Counts) {
+		return fmt.Errorf("expected statistics of %d clusters, got %d", len(s.Sums), len(other.Sums))
+	}
+	for j := range s.Sums {
+		if len(other.Sums[j]) != len(s.Sums[j]) {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+	}
+	s.merge(other)
+	return nil
+}
+
+// add accounts for point in cluster j.
+func (s *Stats) add(point []float64, j int) {
+	for d := range point {
+		s.Sums[j][d] += point[d]
+	}
+	s.Counts[j]++
+}
+
+// addWeighted accounts for point with weight w in cluster j.
+func (s *Stats) addWeighted(point []float64, w float64, j int) {
+	for d := range point {
+		s.Sums[j][d] += w * point[d]
+	}
+	s.Counts[j] += w
+}
+
+// merge adds the statistics of other, of the same shape, to s.
+func (s *Stats) merge(other *Stats) {
+	for j := range s.Sums {
+		for d := range s.Sums[j] {
+			s.Sums[j][d] += other.Sums[j][d]
+		}
+		s.Counts[j] += other.Counts[j]
+	}
+}
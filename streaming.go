@@ -0,0 +1,165 @@
+package kmeans
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// StreamingClusterer maintains k centroids over an unbounded stream of
+// observations without buffering it. The first k observations seed the
+// centroids, then each observation moves its nearest centroid towards it
+// (MacQueen updates). With WithDecay, the weight of past observations shrinks
+// at every call to Add so the centroids follow a drifting stream.
+//
+// A StreamingClusterer is safe for concurrent use: the centroids can be read
+// while another goroutine consumes the stream.
+type StreamingClusterer[T Observation] struct {
+	mu        sync.Mutex
+	k         int
+	decay     float64
+	distance  DistanceFunc
+	spherical bool
+	centroids [][]float64
+	weights   []float64
+}
+
+// NewStreamingClusterer returns a StreamingClusterer maintaining k centroids.
+// It honours WithDistance, WithSpherical, WithDecay and WithCentroids, which
+// seeds the centroids instead of the first k observations.
+func NewStreamingClusterer[T Observation](k int, opts ...Option) (*StreamingClusterer[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate k
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate decay
+	if cfg.decay < 0 || cfg.decay > 1 {
+		return nil, fmt.Errorf("invalid decay: %f", cfg.decay)
+	}
+
+	// Validate center, online updates only maintain means
+	if cfg.center != nil {
+		return nil, fmt.Errorf("online updates require mean centroids")
+	}
+
+	s := &StreamingClusterer[T]{
+		k:         k,
+		decay:     cfg.decay,
+		distance:  cfg.distanceFunc(),
+		spherical: cfg.spherical,
+	}
+	if s.decay == 0 {
+		s.decay = 1
+	}
+
+	// Validate initial centroids
+	if cfg.centroids != nil {
+		dim := 0
+		if len(cfg.centroids) > 0 {
+			dim = len(cfg.centroids[0])
+		}
+		if err := validateCentroids(cfg.centroids, k, dim); err != nil {
+			return nil, err
+		}
+		s.centroids = cloneAll(cfg.centroids)
+		s.weights = make([]float64, k)
+	}
+	return s, nil
+}
+
+// Add absorbs a batch of observations. The weight of the observations seen so
+// far is multiplied by the decay once per call, so feeding observations one
+// at a time forgets faster than feeding them in batches. It fails if an
+// observation does not have the dimension of the previous ones, in which case
+// the observations before it have already been absorbed.
+func (s *StreamingClusterer[T]) Add(observations ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for j := range s.weights {
+		s.weights[j] *= s.decay
+	}
+	for _, obs := range observations {
+		point := slices.Clone(obs.Coordinates())
+		if len(s.centroids) > 0 && len(point) != len(s.centroids[0]) {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+		if s.spherical {
+			normalize(point)
+		}
+
+		// Seed the centroids with the first observations
+		if len(s.centroids) < s.k {
+			s.centroids = append(s.centroids, point)
+			s.weights = append(s.weights, 1)
+			continue
+		}
+
+		j, _ := nearest(point, s.centroids, s.distance)
+		nudge(s.centroids[j], &s.weights[j], point, 1)
+		if s.spherical {
+			normalize(s.centroids[j])
+		}
+	}
+	return nil
+}
+
+// Consume absorbs observations one at a time until the channel is closed.
+// It stops at the first error returned by Add.
+func (s *StreamingClusterer[T]) Consume(observations <-chan T) error {
+	for obs := range observations {
+		if err := s.Add(obs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConsumeBatches absorbs batches of observations until the channel is closed.
+// It stops at the first error returned by Add.
+func (s *StreamingClusterer[T]) ConsumeBatches(batches <-chan []T) error {
+	for batch := range batches {
+		if err := s.Add(batch...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Centroids returns a copy of the current centroids. There are fewer than k
+// until k observations have been seen.
+func (s *StreamingClusterer[T]) Centroids() [][]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneAll(s.centroids)
+}
+
+// Weights returns the current weight of each centroid: the number of
+// observations it absorbed, discounted by the decay.
+func (s *StreamingClusterer[T]) Weights() []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.weights)
+}
+
+// Predict returns the index of the current centroid nearest to obs.
+func (s *StreamingClusterer[T]) Predict(obs T) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.centroids) == 0 {
+		return 0, fmt.Errorf("no observation seen")
+	}
+	point := slices.Clone(obs.Coordinates())
+	if len(point) != len(s.centroids[0]) {
+		return 0, fmt.Errorf("inconsistent dimensions")
+	}
+	if s.spherical {
+		normalize(point)
+	}
+	j, _ := nearest(point, s.centroids, s.distance)
+	return j, nil
+}
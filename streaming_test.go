@@ -0,0 +1,93 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestStreamingClusterer(t *testing.T) {
+	s, err := NewStreamingClusterer[Numbers](3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The first observations seed one centroid each
+	if err := s.Add(1, 11, 21); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	observations := make(chan Numbers)
+	go func() {
+		for _, obs := range []Numbers{3, 13, 23, 2, 12, 22} {
+			observations <- obs
+		}
+		close(observations)
+	}()
+	if err := s.Consume(observations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := [][]float64{{2}, {12}, {22}}
+	if centroids := s.Centroids(); !slices.EqualFunc(centroids, expected, slices.Equal) {
+		t.Errorf("expected centroids %v, got %v", expected, centroids)
+	}
+	if weights := s.Weights(); !slices.Equal(weights, []float64{3, 3, 3}) {
+		t.Errorf("expected weights [3 3 3], got %v", weights)
+	}
+	if j, err := s.Predict(14); err != nil || j != 1 {
+		t.Errorf("expected 14 in cluster 1, got %d (%v)", j, err)
+	}
+}
+
+func TestStreamingClustererDecay(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	s, err := NewStreamingClusterer[Vector](1, WithDecay(0.9))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The stream moves from 0 to 100: a decaying centroid follows it
+	batches := make(chan []Vector)
+	go func() {
+		for _, center := range []float64{0, 100} {
+			for range 100 {
+				batch := make([]Vector, 10)
+				for i := range batch {
+					batch[i] = Vector{center + rng.NormFloat64()}
+				}
+				batches <- batch
+			}
+		}
+		close(batches)
+	}()
+	if err := s.ConsumeBatches(batches); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if centroid := s.Centroids()[0][0]; math.Abs(centroid-100) > 1 {
+		t.Errorf("expected centroid close to 100, got %v", centroid)
+	}
+}
+
+func TestStreamingClustererValidation(t *testing.T) {
+	if _, err := NewStreamingClusterer[Numbers](0); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := NewStreamingClusterer[Numbers](2, WithDecay(1.5)); err == nil {
+		t.Error("expected error for invalid decay")
+	}
+	if _, err := NewStreamingClusterer[Numbers](2, WithCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for wrong number of initial centroids")
+	}
+
+	s, err := NewStreamingClusterer[Vector](2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Predict(Vector{1}); err == nil {
+		t.Error("expected error before any observation")
+	}
+	if err := s.Add(Vector{1, 2}, Vector{1}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
@@ -0,0 +1,183 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// StreamKM implements StreamKM++: it summarises an unbounded stream with a
+// merge-and-reduce tree of coresets, weighted sets of at most coresetSize
+// points, and clusters the summary with weighted k-means++ and Lloyd
+// iterations when centroids are requested. Memory grows with the logarithm of
+// the stream length only. Observations are compared with the Euclidean
+// distance.
+//
+// A StreamKM is safe for concurrent use.
+type StreamKM[T Observation] struct {
+	mu   sync.Mutex
+	k    int
+	size int
+	rng  *rand.Rand
+	dim  int
+	// buffer holds the latest observations, not yet reduced
+	buffer [][]float64
+	// levels holds at most one coreset per level, level l summarising
+	// coresetSize << l observations
+	levels []*coreset
+}
+
+// coreset is a set of weighted points summarising a larger set.
+type coreset struct {
+	points  [][]float64
+	weights []float64
+}
+
+// NewStreamKM returns a StreamKM finding k clusters from coresets of
+// coresetSize points. A coreset size of about 200k gives results close to
+// clustering the whole stream.
+func NewStreamKM[T Observation](k, coresetSize int, rng *rand.Rand) (*StreamKM[T], error) {
+	// Validate k
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate coresetSize
+	if coresetSize < k {
+		return nil, fmt.Errorf("invalid coreset size: %d", coresetSize)
+	}
+
+	// Validate rng
+	if rng == nil {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	return &StreamKM[T]{k: k, size: coresetSize, rng: rng}, nil
+}
+
+// Add absorbs a batch of observations. It fails if an observation does not
+// have the dimension of the previous ones, in which case the observations
+// before it have already been absorbed.
+func (s *StreamKM[T]) Add(observations ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, obs := range observations {
+		point := slices.Clone(obs.Coordinates())
+		if s.dim == 0 {
+			s.dim = len(point)
+		}
+		if len(point) != s.dim {
+			return fmt.Errorf("inconsistent dimensions")
+		}
+		s.buffer = append(s.buffer, point)
+		if len(s.buffer) == s.size {
+			s.carry()
+		}
+	}
+	return nil
+}
+
+// carry turns the full buffer into a coreset and merges it up the tree,
+// reducing two coresets of the same level into one of the next level.
+func (s *StreamKM[T]) carry() {
+	weights := make([]float64, len(s.buffer))
+	for i := range weights {
+		weights[i] = 1
+	}
+	carried := &coreset{points: s.buffer, weights: weights}
+	s.buffer = nil
+
+	for l := 0; ; l++ {
+		if l == len(s.levels) {
+			s.levels = append(s.levels, nil)
+		}
+		if s.levels[l] == nil {
+			s.levels[l] = carried
+			return
+		}
+		merged := &coreset{
+			points:  append(s.levels[l].points, carried.points...),
+			weights: append(s.levels[l].weights, carried.weights...),
+		}
+		carried = reduceCoreset(merged, s.size, s.rng)
+		s.levels[l] = nil
+	}
+}
+
+// Consume absorbs observations until the channel is closed.
+// It stops at the first error returned by Add.
+func (s *StreamKM[T]) Consume(observations <-chan T) error {
+	for obs := range observations {
+		if err := s.Add(obs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Coreset returns the weighted points currently summarising the stream.
+// The weights sum to the number of observations seen.
+func (s *StreamKM[T]) Coreset() ([][]float64, []float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	summary := s.summary()
+	return cloneAll(summary.points), summary.weights
+}
+
+// summary gathers the buffer and every level into a single coreset.
+func (s *StreamKM[T]) summary() *coreset {
+	summary := &coreset{}
+	for _, point := range s.buffer {
+		summary.points = append(summary.points, point)
+		summary.weights = append(summary.weights, 1)
+	}
+	for _, level := range s.levels {
+		if level != nil {
+			summary.points = append(summary.points, level.points...)
+			summary.weights = append(summary.weights, level.weights...)
+		}
+	}
+	return summary
+}
+
+// Cluster returns k centroids for the stream seen so far, running weighted
+// k-means++ seeding and Lloyd iterations over the coreset until no centroid
+// moves by deltaThreshold or more or iterationThreshold iterations ran.
+func (s *StreamKM[T]) Cluster(deltaThreshold float64, iterationThreshold int) ([][]float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	summary := s.summary()
+	if len(summary.points) < s.k {
+		return nil, fmt.Errorf("fewer observations than clusters: %d", len(summary.points))
+	}
+	centroids := seedPlusPlus(summary.points, summary.weights, s.k, 1, s.rng)
+	centroids, _, _ = weightedLloyd(summary.points, summary.weights, centroids, deltaThreshold, iterationThreshold)
+	return centroids, nil
+}
+
+// reduceCoreset summarises c with size of its points chosen by weighted
+// k-means++ sampling, each weighted by the total weight of the points of c
+// nearest to it.
+func reduceCoreset(c *coreset, size int, rng *rand.Rand) *coreset {
+	points := seedPlusPlus(c.points, c.weights, size, 1, rng)
+	labels := make([]int, len(c.points))
+	nearestPanel(c.points, points, labels)
+	weights := make([]float64, size)
+	for i, j := range labels {
+		weights[j] += c.weights[i]
+	}
+	return &coreset{points: points, weights: weights}
+}
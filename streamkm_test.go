@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStreamKM(t *testing.T) {
+	dataset := blobs(rand.New(rand.NewSource(0)), 20000, 5, 2)
+	s, err := NewStreamKM[Vector](5, 200, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for start := 0; start < len(dataset); start += 1000 {
+		if err := s.Add(dataset[start : start+1000]...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The coreset stays small and keeps the total weight
+	points, weights := s.Coreset()
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if len(points) > 200*8 || total != float64(len(dataset)) {
+		t.Errorf("expected a small coreset of weight %d, got %d points of weight %v", len(dataset), len(points), total)
+	}
+
+	// Clustering the coreset is close to clustering the whole stream
+	centroids, err := s.Cluster(1e-6, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected, err := ClusterResult(dataset, 5, 1e-6, 100, rand.New(rand.NewSource(1)), WithInit(InitKMeansPlusPlus))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inertia := 0.0
+	for _, obs := range dataset {
+		_, d := nearest(obs, centroids, EuclideanDistance)
+		inertia += d * d
+	}
+	if inertia > 1.05*expected.Inertia {
+		t.Errorf("expected inertia close to %v, got %v", expected.Inertia, inertia)
+	}
+}
+
+func TestReduceCoreset(t *testing.T) {
+	c := &coreset{
+		points:  [][]float64{{0}, {0.1}, {10}, {10.1}},
+		weights: []float64{1, 2, 3, 4},
+	}
+	reduced := reduceCoreset(c, 2, rand.New(rand.NewSource(0)))
+	if len(reduced.points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(reduced.points))
+	}
+	for j, point := range reduced.points {
+		expected := 3.0
+		if point[0] > 5 {
+			expected = 7
+		}
+		if math.Abs(reduced.weights[j]-expected) > 1e-12 {
+			t.Errorf("expected weight %v for %v, got %v", expected, point, reduced.weights[j])
+		}
+	}
+}
+
+func TestStreamKMValidation(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	if _, err := NewStreamKM[Numbers](0, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := NewStreamKM[Numbers](3, 2, rng); err == nil {
+		t.Error("expected error for a coreset smaller than k")
+	}
+	if _, err := NewStreamKM[Numbers](3, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+
+	s, err := NewStreamKM[Vector](2, 10, rng)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Cluster(0.01, 10); err == nil {
+		t.Error("expected error with fewer observations than clusters")
+	}
+	if err := s.Add(Vector{1, 2}, Vector{1}); err == nil {
+		t.Error("expected error for inconsistent dimensions")
+	}
+}
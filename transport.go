@@ -0,0 +1,140 @@
+package kmeans
+
+import "math"
+
+// pathCost orders augmenting paths first by the number of size minimums they
+// help meet, counted negatively, then by their cost.
+type pathCost struct {
+	deficit int
+	cost    float64
+}
+
+// less reports whether c is cheaper than o. Costs must differ by more than
+// rounding errors, which keeps rounding from creating cycles of moves.
+func (c pathCost) less(o pathCost) bool {
+	switch {
+	case c.deficit != o.deficit:
+		return c.deficit < o.deficit
+	case math.IsInf(o.cost, 1):
+		return !math.IsInf(c.cost, 1)
+	}
+	return c.cost < o.cost-1e-9*(1+math.Abs(o.cost))
+}
+
+// add returns the cost of a path extended by o.
+func (c pathCost) add(o pathCost) pathCost {
+	return pathCost{c.deficit + o.deficit, c.cost + o.cost}
+}
+
+// transportAssign assigns each of the n rows of costs to one of its k columns
+// so that column j receives between lower[j] and upper[j] rows, minimising the
+// total cost of the assignment. The sum of lower must not exceed n and the
+// sum of upper must be at least n.
+//
+// It solves the underlying min-cost flow by successive shortest paths: each
+// step assigns one more row along the cheapest augmenting path, which may
+// move rows already assigned from column to column. Paths are searched with
+// Bellman-Ford over the k columns only, an edge from column a to column b
+// costing the least cost increase of moving a row of a to b, so a step takes
+// O(nk + k³) time. Paths filling a column below its minimum take precedence,
+// so the minimums are met whenever possible.
+func transportAssign(costs [][]float64, lower, upper []int) []int {
+	n, k := len(costs), len(lower)
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = -1
+	}
+	sizes := make([]int, k)
+
+	unset := pathCost{cost: math.Inf(1)}
+	dist := make([]pathCost, k)
+	pred := make([]int, k)
+	via := make([]int, k)
+	// moves[a][b] is the cheapest row to move from column a to b
+	moves := make([][]int, k)
+	for a := range moves {
+		moves[a] = make([]int, k)
+	}
+	for range n {
+		// Cheapest unassigned row entering each column
+		for j := range k {
+			dist[j], pred[j], via[j] = unset, -1, -1
+		}
+		for i, row := range costs {
+			if labels[i] != -1 {
+				continue
+			}
+			for j, c := range row {
+				if c < dist[j].cost {
+					dist[j], via[j] = pathCost{cost: c}, i
+				}
+			}
+		}
+
+		// Cheapest row moving between each pair of columns
+		for a := range k {
+			for b := range k {
+				moves[a][b] = -1
+			}
+		}
+		for i, a := range labels {
+			if a == -1 {
+				continue
+			}
+			for b, c := range costs[i] {
+				if b == a {
+					continue
+				}
+				if m := moves[a][b]; m == -1 || c-costs[i][a] < costs[m][b]-costs[m][a] {
+					moves[a][b] = i
+				}
+			}
+		}
+
+		// Relax the column graph with Bellman-Ford
+		for range k {
+			changed := false
+			for a := range k {
+				if math.IsInf(dist[a].cost, 1) {
+					continue
+				}
+				for b := range k {
+					m := moves[a][b]
+					if m == -1 {
+						continue
+					}
+					if d := dist[a].add(pathCost{cost: costs[m][b] - costs[m][a]}); d.less(dist[b]) {
+						dist[b], pred[b], via[b] = d, a, m
+						changed = true
+					}
+				}
+			}
+			if !changed {
+				break
+			}
+		}
+
+		// Leave through the cheapest column with room, preferring columns
+		// below their minimum
+		end, best := -1, unset
+		for j := range k {
+			if sizes[j] >= upper[j] || math.IsInf(dist[j].cost, 1) {
+				continue
+			}
+			d := dist[j]
+			if sizes[j] < lower[j] {
+				d = d.add(pathCost{deficit: -1})
+			}
+			if end == -1 || d.less(best) {
+				end, best = j, d
+			}
+		}
+
+		// Apply the moves along the path, back to the newly assigned row
+		sizes[end]++
+		for j := end; j != -1; j = pred[j] {
+			labels[via[j]] = j
+		}
+	}
+	return labels
+}
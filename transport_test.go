@@ -0,0 +1,54 @@
+package kmeans
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTransportAssign(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for range 50 {
+		n, k := 7, 3
+		costs := newMatrix(n, k)
+		for i := range costs {
+			for j := range costs[i] {
+				costs[i][j] = math.Round(rng.Float64() * 100)
+			}
+		}
+		lower := []int{rng.Intn(3), rng.Intn(3), 0}
+		upper := []int{lower[0] + rng.Intn(3), lower[1] + rng.Intn(3), n}
+		labels := transportAssign(costs, lower, upper)
+
+		// Enumerate every feasible assignment
+		total := func(labels []int) (float64, bool) {
+			sizes := make([]int, k)
+			cost := 0.0
+			for i, j := range labels {
+				sizes[j]++
+				cost += costs[i][j]
+			}
+			for j := range k {
+				if sizes[j] < lower[j] || sizes[j] > upper[j] {
+					return 0, false
+				}
+			}
+			return cost, true
+		}
+		best := math.Inf(1)
+		candidate := make([]int, n)
+		for code := range 2187 {
+			for i := range candidate {
+				candidate[i] = code % k
+				code /= k
+			}
+			if cost, ok := total(candidate); ok {
+				best = min(best, cost)
+			}
+		}
+		cost, ok := total(labels)
+		if !ok || cost != best {
+			t.Fatalf("expected cost %v within %v and %v, got %v for %v", best, lower, upper, cost, labels)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package kmeans
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// TrimmedResult holds the outcome of a trimmed k-means run.
+type TrimmedResult[T Observation] struct {
+	// Result describes the clusters of the observations kept. Labels holds
+	// Noise for outliers and Inertia only sums over the observations kept.
+	Result[T]
+	// Outliers holds the observations trimmed from the clusters.
+	Outliers []T
+}
+
+// TrimmedKMeans implements trimmed k-means (Cuesta-Albertos, Gordaliza and
+// Matrán): each iteration assigns every observation to its nearest centroid,
+// sets aside the fraction trim of observations farthest from their centroid
+// as outliers and moves every centroid to the center of the observations
+// kept. A few gross outliers then neither drag a centroid nor claim a cluster
+// of their own. It iterates until no centroid moves by deltaThreshold or
+// more or iterationThreshold iterations ran.
+//
+// trim must leave at least k observations. Initial centroids are chosen as
+// in Cluster, and since a centroid started on an outlier keeps it, strategies
+// seeking distant observations such as InitMaximin suit it poorly. It
+// honours WithDistance, WithCenter and WithWorkers.
+func TrimmedKMeans[T Observation](dataset []T, k int, trim, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*TrimmedResult[T], error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate k
+	if k <= 0 || k > len(dataset) {
+		return nil, fmt.Errorf("invalid number of clusters: %d", k)
+	}
+
+	// Validate trim
+	trimmed := int(trim * float64(len(dataset)))
+	if !(trim >= 0 && trim < 1) || len(dataset)-trimmed < k {
+		return nil, fmt.Errorf("invalid trimmed fraction: %f", trim)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which deterministic runs do not need
+	if rng == nil && !cfg.deterministic() {
+		return nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCentroids(cfg.centroids, k, len(points[0])); err != nil {
+		return nil, err
+	}
+
+	loss := cfg.lossFunc()
+	engine := &lloyd{
+		points:   points,
+		distance: cfg.distanceFunc(),
+		squared:  cfg.distance == nil && !cfg.spherical,
+		workers:  cfg.workerCount(),
+	}
+	centroids := initCentroids(points, k, cfg, rng)
+	labels := make([]int, len(points))
+	losses := make([]float64, len(points))
+	order := make([]int, len(points))
+	kept := make([][]float64, 0, len(points))
+	keptLabels := make([]int, 0, len(points))
+	iterations, converged := 0, false
+	for range iterationThreshold {
+		iterations++
+
+		// Assignment step, then trim the observations farthest from their
+		// centroid
+		engine.assign(centroids, labels)
+		for i, j := range labels {
+			losses[i] = loss(points[i], centroids[j])
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(losses[b], losses[a])
+		})
+		for _, i := range order[:trimmed] {
+			labels[i] = Noise
+		}
+
+		// Update step: calculate new centroids from the observations kept
+		stats := NewStats(k, len(points[0]))
+		kept, keptLabels = kept[:0], keptLabels[:0]
+		for i, j := range labels {
+			if j != Noise {
+				stats.add(points[i], j)
+				kept = append(kept, points[i])
+				keptLabels = append(keptLabels, j)
+			}
+		}
+		newCentroids := update(kept, keptLabels, centroids, stats, cfg)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			converged = true
+			break
+		}
+	}
+
+	result := &TrimmedResult[T]{Result: Result[T]{
+		Clusters:   make([][]T, k),
+		Centroids:  centroids,
+		Labels:     labels,
+		Iterations: iterations,
+		Converged:  converged,
+	}}
+	for i, obs := range dataset {
+		if labels[i] == Noise {
+			result.Outliers = append(result.Outliers, obs)
+			continue
+		}
+		result.Clusters[labels[i]] = append(result.Clusters[labels[i]], obs)
+		result.Inertia += loss(points[i], centroids[labels[i]])
+	}
+	return result, nil
+}
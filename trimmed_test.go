@@ -0,0 +1,67 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTrimmedKMeans(t *testing.T) {
+	// Two gross outliers claim a cluster of their own in plain k-means
+	rng := rand.New(rand.NewSource(0))
+	dataset := append(gaussians(rng, []Vector{{0, 0}, {20, 0}}, 49, 2), Vector{1000, 1000}, Vector{1000, -1000})
+	plain, err := ClusterResult(dataset, 2, 0.001, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.Labels[0] != plain.Labels[49] {
+		t.Fatal("expected the outliers to merge the blobs without trimming")
+	}
+
+	result, err := TrimmedKMeans(dataset, 2, 0.02, 0.001, 100, rand.New(rand.NewSource(0)), WithCenter(MedianCenter))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Outliers) != 2 || result.Labels[98] != Noise || result.Labels[99] != Noise {
+		t.Fatalf("expected the 2 outliers trimmed, got %v", result.Outliers)
+	}
+	for i := range 98 {
+		if result.Labels[i] != result.Labels[i/49*49] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+	if result.Labels[0] == result.Labels[49] || !result.Converged {
+		t.Error("expected the blobs in different clusters")
+	}
+	if len(result.Clusters[0])+len(result.Clusters[1]) != 98 {
+		t.Error("expected the outliers out of the clusters")
+	}
+}
+
+func TestTrimmedKMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := TrimmedKMeans([]Numbers{}, 2, 0.1, 0.01, 10, rng); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+	if _, err := TrimmedKMeans(dataset, 7, 0.1, 0.01, 10, rng); err == nil {
+		t.Error("expected error for invalid k")
+	}
+	if _, err := TrimmedKMeans(dataset, 2, -0.1, 0.01, 10, rng); err == nil {
+		t.Error("expected error for negative trimmed fraction")
+	}
+	if _, err := TrimmedKMeans(dataset, 2, 0.9, 0.01, 10, rng); err == nil {
+		t.Error("expected error for trimming all but one observation")
+	}
+	if _, err := TrimmedKMeans(dataset, 2, 0.1, 0, 10, rng); err == nil {
+		t.Error("expected error for invalid delta threshold")
+	}
+	if _, err := TrimmedKMeans(dataset, 2, 0.1, 0.01, 0, rng); err == nil {
+		t.Error("expected error for invalid iteration threshold")
+	}
+	if _, err := TrimmedKMeans(dataset, 2, 0.1, 0.01, 10, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := TrimmedKMeans(dataset, 2, 0.1, 0.01, 10, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
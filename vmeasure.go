@@ -0,0 +1,30 @@
+package kmeans
+
+// VMeasure returns the homogeneity, completeness and V-measure of a
+// clustering against known classes, given as labels and truth for the same
+// observations. Homogeneity is 1 when every cluster contains observations of
+// a single class, and completeness is 1 when all observations of a class are
+// in the same cluster; each is the share of the entropy of the classes,
+// respectively of the clusters, that the other labeling explains. The
+// V-measure is their harmonic mean. All three range from 0 to 1, and labels
+// are compared for equality only.
+func VMeasure(labels, truth []int) (float64, float64, float64, error) {
+	counts, clusters, classes, err := contingency(labels, truth)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	n := float64(len(labels))
+	mi := mutualInformation(counts, clusters, classes, n)
+
+	homogeneity, completeness := 1.0, 1.0
+	if h := entropy(classes, n); h > 0 {
+		homogeneity = min(1, mi/h)
+	}
+	if h := entropy(clusters, n); h > 0 {
+		completeness = min(1, mi/h)
+	}
+	if homogeneity+completeness == 0 {
+		return 0, 0, 0, nil
+	}
+	return homogeneity, completeness, 2 * homogeneity * completeness / (homogeneity + completeness), nil
+}
@@ -0,0 +1,55 @@
+package kmeans
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVMeasure(t *testing.T) {
+	// Splitting classes keeps clusters homogeneous but incomplete
+	labels := []int{0, 0, 1, 1, 2, 2, 3, 3}
+	truth := []int{0, 0, 0, 0, 1, 1, 1, 1}
+	h, c, v, err := VMeasure(labels, truth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(h-1) > 1e-12 || math.Abs(c-0.5) > 1e-12 || math.Abs(v-2.0/3) > 1e-12 {
+		t.Errorf("expected 1, 0.5 and 2/3, got %f, %f and %f", h, c, v)
+	}
+
+	// Merging classes keeps them complete but not homogeneous
+	h, c, v, err = VMeasure(truth, labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(h-0.5) > 1e-12 || math.Abs(c-1) > 1e-12 || math.Abs(v-2.0/3) > 1e-12 {
+		t.Errorf("expected 0.5, 1 and 2/3, got %f, %f and %f", h, c, v)
+	}
+
+	// The V-measure equals NMI with the arithmetic normalization
+	nmi, err := NormalizedMutualInformation(truth, labels, NormalizationArithmetic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(v-nmi) > 1e-12 {
+		t.Errorf("expected the V-measure %f to equal NMI %f", v, nmi)
+	}
+
+	// Independent labelings
+	h, c, v, err = VMeasure([]int{0, 0, 1, 1}, []int{0, 1, 0, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h != 0 || c != 0 || v != 0 {
+		t.Errorf("expected 0 for independent labelings, got %f, %f and %f", h, c, v)
+	}
+}
+
+func TestVMeasureValidation(t *testing.T) {
+	if _, _, _, err := VMeasure(nil, nil); err == nil {
+		t.Error("expected error for empty labels")
+	}
+	if _, _, _, err := VMeasure([]int{0, 1}, []int{0}); err == nil {
+		t.Error("expected error for labels of different lengths")
+	}
+}
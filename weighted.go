@@ -0,0 +1,134 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// WeightedObservation is an Observation carrying a weight, such as a point of
+// a coreset standing for many observations. Clustering weighted observations
+// minimises the weighted inertia: each centroid is the weighted mean of its
+// observations.
+type WeightedObservation interface {
+	Observation
+	Weight() float64
+}
+
+// observationWeights returns the weight of every observation of dataset, or
+// nil if none is a WeightedObservation. Observations without a weight count
+// once. It fails if a weight is negative or not finite, or if all are zero.
+func observationWeights[T Observation](dataset []T) ([]float64, error) {
+	var weights []float64
+	for i, obs := range dataset {
+		weighted, ok := any(obs).(WeightedObservation)
+		if !ok {
+			if weights != nil {
+				weights[i] = 1
+			}
+			continue
+		}
+		if weights == nil {
+			weights = make([]float64, len(dataset))
+			for j := range i {
+				weights[j] = 1
+			}
+		}
+		weights[i] = weighted.Weight()
+	}
+	if weights == nil {
+		return nil, nil
+	}
+	return weights, validateWeights(weights)
+}
+
+// datasetWeights returns the weights of the observations of dataset, given
+// either with WithWeights or by WeightedObservation, or nil if none is. It
+// fails if both give weights or if the weights are invalid.
+func datasetWeights[T Observation](dataset []T, cfg *config) ([]float64, error) {
+	weights, err := observationWeights(dataset)
+	if err != nil || cfg.sampleWeights == nil {
+		return weights, err
+	}
+	if weights != nil {
+		return nil, fmt.Errorf("weights given both by observations and WithWeights")
+	}
+	if len(cfg.sampleWeights) != len(dataset) {
+		return nil, fmt.Errorf("expected %d weights, got %d", len(dataset), len(cfg.sampleWeights))
+	}
+	return cfg.sampleWeights, validateWeights(cfg.sampleWeights)
+}
+
+// validateWeights fails if a weight is negative or not finite, or if all are
+// zero.
+func validateWeights(weights []float64) error {
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf("invalid weight: %f", w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return fmt.Errorf("total weight is zero")
+	}
+	return nil
+}
+
+// weightedLloyd runs Lloyd iterations with the Euclidean distance over points
+// carrying weights, each centroid moving to the weighted mean of its points,
+// until no centroid moves by deltaThreshold or more or iterationThreshold
+// iterations ran. It returns the final centroids, the number of iterations and
+// whether the run converged. Summaries such as coresets and micro-clusters are
+// clustered this way.
+func weightedLloyd(points [][]float64, weights []float64, centroids [][]float64, deltaThreshold float64, iterationThreshold int) ([][]float64, int, bool) {
+	labels := make([]int, len(points))
+	iterations := 0
+	for range iterationThreshold {
+		iterations++
+		nearestPanel(points, centroids, labels)
+		newCentroids := weightedMeans(points, weights, labels, centroids)
+		maxMovement := maxDrift(centroids, newCentroids)
+		centroids = newCentroids
+		if maxMovement < deltaThreshold {
+			return centroids, iterations, true
+		}
+	}
+	return centroids, iterations, false
+}
+
+// weightedMeans returns the weighted mean of the points of each cluster given
+// by labels. A cluster without weight retains its centroid.
+func weightedMeans(points [][]float64, weights []float64, labels []int, centroids [][]float64) [][]float64 {
+	sums := make([][]float64, len(centroids))
+	totals := make([]float64, len(centroids))
+	for j := range sums {
+		sums[j] = make([]float64, len(centroids[j]))
+	}
+	for i, j := range labels {
+		for d := range points[i] {
+			sums[j][d] += weights[i] * points[i][d]
+		}
+		totals[j] += weights[i]
+	}
+	for j := range sums {
+		if totals[j] == 0 {
+			sums[j] = slices.Clone(centroids[j])
+			continue
+		}
+		for d := range sums[j] {
+			sums[j][d] /= totals[j]
+		}
+	}
+	return sums
+}
+
+// weightedStats accumulates the weighted sum and total weight of the points
+// of each of the k clusters given by labels.
+func weightedStats(points [][]float64, weights []float64, labels []int, k int) *Stats {
+	stats := NewStats(k, len(points[0]))
+	for i, j := range labels {
+		stats.addWeighted(points[i], weights[i], j)
+	}
+	return stats
+}
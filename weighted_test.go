@@ -0,0 +1,86 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWeightedLloyd(t *testing.T) {
+	points := [][]float64{{0}, {1}, {10}, {11}}
+	weights := []float64{3, 1, 1, 0}
+
+	// Centroids move to the weighted means of their points
+	centroids, _, converged := weightedLloyd(points, weights, [][]float64{{0}, {10}}, 1e-9, 10)
+	expected := [][]float64{{0.25}, {10}}
+	if !converged || !slices.EqualFunc(centroids, expected, slices.Equal) {
+		t.Errorf("expected centroids %v, got %v", expected, centroids)
+	}
+}
+
+func TestClusterWeighted(t *testing.T) {
+	dataset := []Weighted[Numbers]{{1, 3}, {2, 1}, {10, 1}, {12, 0}}
+
+	// Centroids are weighted means and the inertia is weighted
+	result, err := ClusterResult(dataset, 2, 0.01, 100, nil, WithInit(InitMaximin))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]float64{{10}, {1.25}}
+	if !slices.EqualFunc(result.Centroids, expected, slices.Equal) {
+		t.Errorf("expected centroids %v, got %v", expected, result.Centroids)
+	}
+	if want := 3*0.25*0.25 + 0.75*0.75 + 0 + 0; math.Abs(result.Inertia-want) > 1e-12 {
+		t.Errorf("expected inertia %v, got %v", want, result.Inertia)
+	}
+}
+
+func TestClusterWithWeights(t *testing.T) {
+	dataset := []Numbers{1, 2, 10, 12}
+
+	// A weights slice is equivalent to weighted observations
+	result, err := ClusterResult(dataset, 2, 0.01, 100, nil, WithInit(InitMaximin), WithWeights([]float64{3, 1, 1, 0}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]float64{{10}, {1.25}}
+	if !slices.EqualFunc(result.Centroids, expected, slices.Equal) {
+		t.Errorf("expected centroids %v, got %v", expected, result.Centroids)
+	}
+
+	fuzzy, err := FuzzyCMeans(dataset, 2, 0.001, 100, nil, WithInit(InitMaximin), WithWeights([]float64{3, 1, 1, 0}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(fuzzy.Centroids[1][0]-1.25) > 0.1 {
+		t.Errorf("expected a fuzzy centroid near 1.25, got %v", fuzzy.Centroids)
+	}
+}
+
+func TestDatasetWeights(t *testing.T) {
+	if _, err := Cluster([]Numbers{1, 2, 3}, 2, 0.01, 100, nil, WithInit(InitMaximin), WithWeights([]float64{1, 1})); err == nil {
+		t.Error("expected error for missing weights")
+	}
+	if _, err := Cluster([]Numbers{1, 2, 3}, 2, 0.01, 100, nil, WithInit(InitMaximin), WithWeights([]float64{1, -1, 1})); err == nil {
+		t.Error("expected error for negative weight")
+	}
+	if _, err := Cluster([]Weighted[Numbers]{{1, 1}, {2, 1}, {3, 1}}, 2, 0.01, 100, nil, WithInit(InitMaximin), WithWeights([]float64{1, 1, 1})); err == nil {
+		t.Error("expected error for weights given twice")
+	}
+}
+
+func TestObservationWeights(t *testing.T) {
+	weights, err := observationWeights([]Numbers{1, 2})
+	if err != nil || weights != nil {
+		t.Errorf("expected no weights, got %v (%v)", weights, err)
+	}
+	if _, err := observationWeights([]Weighted[Numbers]{{1, -1}}); err == nil {
+		t.Error("expected error for negative weight")
+	}
+	if _, err := observationWeights([]Weighted[Numbers]{{1, 0}, {2, 0}}); err == nil {
+		t.Error("expected error for zero total weight")
+	}
+	if _, err := Cluster([]Weighted[Numbers]{{1, 1}, {2, 1}, {3, 1}}, 2, 0.01, 100, nil, WithInit(InitMaximin), WithCenter(MajorityCenter)); err == nil {
+		t.Error("expected error for weights with a custom center")
+	}
+}
@@ -0,0 +1,151 @@
+package kmeans
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// XMeans implements X-means, which selects the number of clusters
+// automatically. Starting from kMin clusters, it tries to split every cluster
+// in two with a local 2-means run and keeps the split when it improves the
+// Bayesian Information Criterion (BIC) of the cluster under a spherical
+// Gaussian model. Centroids are then refined by k-means over the whole
+// dataset, and rounds continue until no split helps or kMax clusters are
+// reached. The chosen k is the number of centroids of the result.
+//
+// Each k-means run iterates until no centroid moves by deltaThreshold or more
+// or iterationThreshold iterations ran. The result's Iterations is the number
+// of splitting rounds and Converged reports whether the search stopped before
+// reaching kMax. It requires the Euclidean distance and mean centroids and
+// honours WithInit and WithCentroids for the initial kMin clusters,
+// WithAlgorithm and WithWorkers.
+func XMeans[T Observation](dataset []T, kMin, kMax int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts ...Option) (*Result[T], error) {
+	cfg, points, err := newSearchRun(dataset, kMin, kMax, deltaThreshold, iterationThreshold, rng, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from kMin clusters
+	labels := make([]int, len(points))
+	centroids, _, _ := lloydLoop(points, initCentroids(points, kMin, cfg, rng), labels, deltaThreshold, iterationThreshold, cfg)
+
+	rounds, converged := 0, false
+	for len(centroids) < kMax {
+		rounds++
+
+		// Improve structure: split clusters whose BIC improves
+		var next [][]float64
+		for j, members := range groupPoints(points, labels, len(centroids)) {
+			if len(members) < 2 || len(centroids)+len(next)-j >= kMax {
+				next = append(next, centroids[j])
+				continue
+			}
+			children, childLabels := split(members, deltaThreshold, iterationThreshold, rng, cfg)
+			if bic(members, children, childLabels) > bic(members, [][]float64{centroids[j]}, make([]int, len(members))) {
+				next = append(next, children...)
+			} else {
+				next = append(next, centroids[j])
+			}
+		}
+		if len(next) == len(centroids) {
+			converged = true
+			break
+		}
+
+		// Improve parameters: refine every centroid over the whole dataset
+		centroids, _, _ = lloydLoop(points, next, labels, deltaThreshold, iterationThreshold, cfg)
+	}
+
+	// Labels of the final centroids
+	newAssigner(points, cfg).assign(centroids, labels)
+	result := newResult(dataset, points, centroids, labels, nil, cfg.lossFunc())
+	result.Iterations = rounds
+	result.Converged = converged
+	return result, nil
+}
+
+// newSearchRun validates the arguments shared by methods searching the number
+// of clusters between kMin and kMax and returns the configuration and the
+// coordinates of the observations.
+func newSearchRun[T Observation](dataset []T, kMin, kMax int, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, opts []Option) (*config, [][]float64, error) {
+	cfg := newConfig(opts)
+
+	// Validate empty dataset
+	if len(dataset) == 0 {
+		return nil, nil, fmt.Errorf("dataset is empty")
+	}
+
+	// Validate kMin and kMax
+	if kMin <= 0 || kMin > len(dataset) {
+		return nil, nil, fmt.Errorf("invalid minimum number of clusters: %d", kMin)
+	}
+	if kMax < kMin || kMax > len(dataset) {
+		return nil, nil, fmt.Errorf("invalid maximum number of clusters: %d", kMax)
+	}
+
+	// Validate deltaThreshold
+	if deltaThreshold <= 0 {
+		return nil, nil, fmt.Errorf("invalid delta threshold: %f", deltaThreshold)
+	}
+
+	// Validate iterationThreshold
+	if iterationThreshold <= 0 {
+		return nil, nil, fmt.Errorf("invalid iteration threshold: %d", iterationThreshold)
+	}
+
+	// Validate init strategy
+	if !cfg.init.valid() {
+		return nil, nil, fmt.Errorf("invalid init strategy: %d", cfg.init)
+	}
+
+	// Validate rng, which splitting clusters needs
+	if rng == nil {
+		return nil, nil, fmt.Errorf("random number generator is nil")
+	}
+
+	// Validate workers
+	if cfg.workers < 0 {
+		return nil, nil, fmt.Errorf("invalid number of workers: %d", cfg.workers)
+	}
+
+	// Validate frozen centroids, which only ClusterResult supports
+	if cfg.frozen != nil {
+		return nil, nil, fmt.Errorf("frozen centroids require ClusterResult")
+	}
+
+	// Validate algorithm, the search relies on full k-means runs
+	if !cfg.algorithm.valid() || cfg.algorithm.stochastic() || cfg.algorithm == MacQueen {
+		return nil, nil, fmt.Errorf("invalid algorithm: %d", cfg.algorithm)
+	}
+
+	// Validate distance, the criteria assume Gaussian clusters
+	if !cfg.euclidean() {
+		return nil, nil, fmt.Errorf("searching the number of clusters requires the Euclidean distance and mean centroids")
+	}
+
+	points, err := materialize(dataset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateCentroids(cfg.centroids, kMin, len(points[0])); err != nil {
+		return nil, nil, err
+	}
+	return cfg, points, nil
+}
+
+// groupPoints returns the points of each of the k clusters given by labels.
+func groupPoints(points [][]float64, labels []int, k int) [][][]float64 {
+	groups := make([][][]float64, k)
+	for i, j := range labels {
+		groups[j] = append(groups[j], points[i])
+	}
+	return groups
+}
+
+// split runs 2-means over points from k-means++ seeds and returns the two
+// centroids and the labels of the points.
+func split(points [][]float64, deltaThreshold float64, iterationThreshold int, rng *rand.Rand, cfg *config) ([][]float64, []int) {
+	labels := make([]int, len(points))
+	centroids, _, _ := lloydLoop(points, seedPlusPlus(points, nil, 2, 1, rng), labels, deltaThreshold, iterationThreshold, cfg)
+	return centroids, labels
+}
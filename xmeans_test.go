@@ -0,0 +1,92 @@
+package kmeans
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// gaussians returns n observations drawn around each of centers with the
+// given standard deviation.
+func gaussians(rng *rand.Rand, centers []Vector, n int, stddev float64) []Vector {
+	var dataset []Vector
+	for _, center := range centers {
+		for range n {
+			obs := make(Vector, len(center))
+			for d := range center {
+				obs[d] = center[d] + rng.NormFloat64()*stddev
+			}
+			dataset = append(dataset, obs)
+		}
+	}
+	return dataset
+}
+
+func TestXMeans(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}, {100, 100}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	result, err := XMeans(dataset, 1, 20, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != len(centers) || !result.Converged {
+		t.Fatalf("expected %d clusters, got %d", len(centers), len(result.Centroids))
+	}
+	for i, label := range result.Labels {
+		if label != result.Labels[i/100*100] {
+			t.Fatalf("observation %v not with its blob", dataset[i])
+		}
+	}
+}
+
+func TestXMeansMaximum(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}, {50, 50}, {100, 100}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 50, 3)
+
+	result, err := XMeans(dataset, 1, 3, 1e-6, 100, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Centroids) != 3 || result.Converged {
+		t.Errorf("expected the search to stop at 3 clusters, got %d", len(result.Centroids))
+	}
+}
+
+func TestXMeansAccelerated(t *testing.T) {
+	centers := []Vector{{0, 0}, {50, 0}, {0, 50}}
+	dataset := gaussians(rand.New(rand.NewSource(0)), centers, 100, 3)
+
+	// The search starts from a single centroid, which has no runner-up
+	for _, algorithm := range []Algorithm{Hamerly, Annulus} {
+		result, err := XMeans(dataset, 1, 10, 1e-6, 100, rand.New(rand.NewSource(1)), WithAlgorithm(algorithm))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Centroids) != len(centers) {
+			t.Errorf("algorithm %d: expected %d clusters, got %d", algorithm, len(centers), len(result.Centroids))
+		}
+	}
+}
+
+func TestXMeansValidation(t *testing.T) {
+	dataset := []Numbers{1, 2, 3, 11, 12, 13}
+	rng := rand.New(rand.NewSource(0))
+	if _, err := XMeans(dataset, 0, 3, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid minimum k")
+	}
+	if _, err := XMeans(dataset, 3, 2, 1e-6, 100, rng); err == nil {
+		t.Error("expected error for invalid maximum k")
+	}
+	if _, err := XMeans(dataset, 1, 3, 1e-6, 100, nil); err == nil {
+		t.Error("expected error for nil random number generator")
+	}
+	if _, err := XMeans(dataset, 1, 3, 1e-6, 100, rng, WithMedians()); err == nil {
+		t.Error("expected error for non-Euclidean distance")
+	}
+	if _, err := XMeans(dataset, 1, 3, 1e-6, 100, rng, WithAlgorithm(MiniBatch)); err == nil {
+		t.Error("expected error for stochastic algorithm")
+	}
+	if _, err := XMeans(dataset, 1, 3, 1e-6, 100, rng, WithFrozenCentroids([][]float64{{1}})); err == nil {
+		t.Error("expected error for frozen centroids")
+	}
+}
@@ -0,0 +1,186 @@
+package kmeans
+
+import (
+	"math"
+	"slices"
+)
+
+// yinyang implements Yinyang k-means. Centroids are split into groups once,
+// by clustering the initial centroids, and each point keeps an upper bound on
+// the distance to its centroid and one lower bound per group on the distance
+// to the other centroids of that group. Groups whose bound exceeds the upper
+// bound are skipped entirely, which scales to thousands of centroids.
+type yinyang struct {
+	points  [][]float64
+	workers int
+	upper   []float64
+	lower   [][]float64
+	// groups lists the centroids of each group, group maps a centroid to its group
+	groups [][]int
+	group  []int
+	// previous holds the centroids of the previous call, nil before the first one
+	previous [][]float64
+}
+
+// newYinyang returns a Yinyang assigner for points.
+func newYinyang(points [][]float64, workers int) *yinyang {
+	return &yinyang{
+		points:  points,
+		workers: workers,
+		upper:   make([]float64, len(points)),
+		lower:   make([][]float64, len(points)),
+	}
+}
+
+func (y *yinyang) assign(centroids [][]float64, labels []int) *Stats {
+	k, dim := len(centroids), len(centroids[0])
+
+	// First call: group the centroids and compute every distance
+	if y.previous == nil {
+		y.previous = cloneAll(centroids)
+		y.groups, y.group = groupCentroids(centroids, max(1, k/10))
+		return shardStats(len(y.points), k, dim, y.workers, func(start, end int, partial *Stats) {
+			dists := make([]float64, k)
+			for i := start; i < end; i++ {
+				for j := range centroids {
+					dists[j] = EuclideanDistance(y.points[i], centroids[j])
+				}
+				labels[i] = 0
+				for j := range dists {
+					if dists[j] < dists[labels[i]] {
+						labels[i] = j
+					}
+				}
+				y.upper[i] = dists[labels[i]]
+				y.lower[i] = make([]float64, len(y.groups))
+				for g := range y.groups {
+					y.lower[i][g] = y.groupBound(g, labels[i], dists)
+				}
+				partial.add(y.points[i], labels[i])
+			}
+		})
+	}
+
+	// How far each centroid moved, and the largest move of each group
+	drift := make([]float64, k)
+	groupDrift := make([]float64, len(y.groups))
+	for j := range centroids {
+		drift[j] = EuclideanDistance(centroids[j], y.previous[j])
+		groupDrift[y.group[j]] = max(groupDrift[y.group[j]], drift[j])
+	}
+	y.previous = cloneAll(centroids)
+
+	return shardStats(len(y.points), k, dim, y.workers, func(start, end int, partial *Stats) {
+		dists := make([]float64, k)
+		examined := make([]bool, len(y.groups))
+		for i := start; i < end; i++ {
+			point, lower := y.points[i], y.lower[i]
+
+			// Loosen the bounds by the centroid drift
+			y.upper[i] += drift[labels[i]]
+			globalLower := math.Inf(1)
+			for g := range lower {
+				lower[g] -= groupDrift[g]
+				globalLower = min(globalLower, lower[g])
+			}
+
+			// Global filter, first with the loose then with a tight upper bound
+			if y.upper[i] <= globalLower {
+				partial.add(point, labels[i])
+				continue
+			}
+			old := labels[i]
+			y.upper[i] = EuclideanDistance(point, centroids[old])
+			if y.upper[i] <= globalLower {
+				partial.add(point, old)
+				continue
+			}
+
+			// Group filter: only search groups whose bound may be beaten
+			dists[old] = y.upper[i]
+			for g, members := range y.groups {
+				examined[g] = lower[g] < y.upper[i]
+				if !examined[g] {
+					continue
+				}
+				for _, j := range members {
+					if j == old {
+						continue
+					}
+					dists[j] = EuclideanDistance(point, centroids[j])
+					if dists[j] < y.upper[i] {
+						labels[i] = j
+						y.upper[i] = dists[j]
+					}
+				}
+			}
+
+			// Recompute the bounds of the searched groups around the new label
+			for g := range y.groups {
+				if examined[g] {
+					lower[g] = y.groupBound(g, labels[i], dists)
+				} else if g == y.group[old] && labels[i] != old {
+					// The former centroid now counts towards its group's bound
+					lower[g] = min(lower[g], dists[old])
+				}
+			}
+			partial.add(point, labels[i])
+		}
+	})
+}
+
+// groupBound returns the smallest distance in dists to a centroid of group g
+// other than label.
+func (y *yinyang) groupBound(g, label int, dists []float64) float64 {
+	bound := math.Inf(1)
+	for _, j := range y.groups[g] {
+		if j != label {
+			bound = min(bound, dists[j])
+		}
+	}
+	return bound
+}
+
+// groupCentroids splits centroids into at most t groups of nearby centroids by
+// running a few k-means iterations on them, seeded with maximin. It returns
+// the members of each non-empty group and the group of each centroid.
+func groupCentroids(centroids [][]float64, t int) ([][]int, []int) {
+	group := make([]int, len(centroids))
+	if t > 1 {
+		centers := initMaximin(centroids, t)
+		for range 5 {
+			for j := range centroids {
+				group[j], _ = nearest(centroids[j], centers, EuclideanDistance)
+			}
+			for g := range centers {
+				var members [][]float64
+				for j := range centroids {
+					if group[j] == g {
+						members = append(members, centroids[j])
+					}
+				}
+				if len(members) > 0 {
+					centers[g] = mean(members)
+				}
+			}
+		}
+	}
+
+	// Drop empty groups and renumber the others
+	var groups [][]int
+	ids := make(map[int]int)
+	for j, g := range group {
+		id, ok := ids[g]
+		if !ok {
+			id = len(groups)
+			ids[g] = id
+			groups = append(groups, nil)
+		}
+		groups[id] = append(groups[id], j)
+		group[j] = id
+	}
+	for _, members := range groups {
+		slices.Sort(members)
+	}
+	return groups, group
+}